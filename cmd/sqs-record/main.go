@@ -0,0 +1,52 @@
+// Command sqs-record snapshots real SQS queues into a demo dataset file (the
+// DEMO_DATA_FILE format) so their shapes can be replayed offline in demo mode.
+//
+// Usage:
+//
+//	sqs-record [-out demo-data.json] [-sample 20] [queueUrl ...]
+//
+// It needs read-only access: queue attributes and tags are read and messages
+// are sampled with a zero visibility timeout, so nothing is consumed. Without
+// queue URLs the tag-filtered queue list is recorded.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+)
+
+func main() {
+	out := flag.String("out", "demo-data.json", "dataset file to write")
+	sample := flag.Int("sample", 20, "messages to record per queue")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	handler, err := sqs.NewSQSHandler()
+	if err != nil {
+		log.Fatalf("Failed to create SQS handler: %v", err)
+	}
+	if handler.IsDemo() {
+		log.Fatalf("No live SQS access: recording needs AWS credentials or SQS_ENDPOINT_URL")
+	}
+
+	ds, err := handler.RecordDataset(ctx, flag.Args(), *sample)
+	if err != nil {
+		log.Fatalf("Recording failed: %v", err)
+	}
+	if err := ds.Validate(); err != nil {
+		log.Fatalf("Recorded dataset is invalid: %v", err)
+	}
+	if err := demo.WriteDataset(*out, ds); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+	log.Printf("Recorded %d queues to %s; replay with DEMO_DATA_FILE=%s FORCE_DEMO_MODE=true", len(ds.Queues), *out, *out)
+}
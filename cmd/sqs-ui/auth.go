@@ -0,0 +1,30 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// apiAuthMiddleware requires "Authorization: Bearer <API_AUTH_TOKEN>" on
+// every request when API_AUTH_TOKEN is set, and passes everything through
+// when it is not. It guards the API and WebSocket only; the health probes
+// are registered outside it so they stay reachable without a token.
+func apiAuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := os.Getenv("API_AUTH_TOKEN")
+		if token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="sqs-ui"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
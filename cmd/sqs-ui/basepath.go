@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// basePathFromEnv returns BASE_PATH normalized to "/prefix" form (leading
+// slash, no trailing slash), or "" when the UI is served at the root.
+func basePathFromEnv() string {
+	basePath := strings.Trim(strings.TrimSpace(os.Getenv("BASE_PATH")), "/")
+	if basePath == "" {
+		return ""
+	}
+	return "/" + basePath
+}
+
+// configScriptHandler serves config.js, which publishes the base path to the
+// frontend so its absolute API and WebSocket URLs resolve behind a proxy.
+func configScriptHandler(basePath string) http.HandlerFunc {
+	encoded, _ := json.Marshal(basePath)
+	script := fmt.Sprintf("window.SQS_UI_BASE_PATH = %s;\n", encoded)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprint(w, script)
+	}
+}
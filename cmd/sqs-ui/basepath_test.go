@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/websocket"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestBasePathFromEnv(t *testing.T) {
+	tests := map[string]string{
+		"":             "",
+		"/":            "",
+		"sqs-ui":       "/sqs-ui",
+		"/sqs-ui/":     "/sqs-ui",
+		" /tools/sqs ": "/tools/sqs",
+	}
+
+	for value, expected := range tests {
+		t.Setenv("BASE_PATH", value)
+		if got := basePathFromEnv(); got != expected {
+			t.Errorf("BASE_PATH=%q: expected %q, got %q", value, expected, got)
+		}
+	}
+}
+
+func TestRouter_BasePath(t *testing.T) {
+	t.Setenv("BASE_PATH", "/sqs-ui")
+	router := newTestRouter()
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{"/sqs-ui/api/queues", http.StatusOK},
+		{"/api/queues", http.StatusNotFound},
+		{"/sqs-ui/css/app.css", http.StatusOK},
+		{"/sqs-ui/", http.StatusOK},
+		{"/sqs-ui", http.StatusMovedPermanently},
+		{"/css/app.css", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+
+	t.Run("config.js publishes the base path", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", "/sqs-ui/config.js", nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		if body := rr.Body.String(); !strings.Contains(body, `window.SQS_UI_BASE_PATH = "/sqs-ui";`) {
+			t.Errorf("unexpected config.js: %q", body)
+		}
+	})
+}
+
+func TestRouter_NoBasePath(t *testing.T) {
+	t.Setenv("BASE_PATH", "")
+	router := newTestRouter()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/config.js", nil))
+
+	if body := rr.Body.String(); !strings.Contains(body, `window.SQS_UI_BASE_PATH = "";`) {
+		t.Errorf("unexpected config.js: %q", body)
+	}
+}
+
+func TestRouter_ShareLinkBasePathAndRedaction(t *testing.T) {
+	t.Setenv("BASE_PATH", "/sqs-ui")
+	t.Setenv("SHARE_LINK_SECRET", "test-secret")
+
+	mock := helpers.NewMockSQSClient()
+	mock.AddMessage("https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue", "msg-1", "hello")
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+	encoded := "https%3A%2F%2Fsqs.us-east-1.amazonaws.com%2F123456789012%2Forders-queue"
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", "/sqs-ui/api/queues/"+encoded+"/messages/msg-1/share", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var share struct {
+		Token string `json:"token"`
+		Path  string `json:"path"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &share); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if share.Path != "/sqs-ui/api/shared/"+share.Token {
+		t.Fatalf("expected the path under the base path, got %q", share.Path)
+	}
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", share.Path, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected the shared path to resolve, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if strings.Contains(logs.String(), share.Token) || !strings.Contains(logs.String(), "/sqs-ui/api/shared/[redacted]") {
+		t.Errorf("expected the token redacted from the request log, got %q", logs.String())
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/gorilla/mux"
+)
+
+// capabilityRoutes maps route-backed capabilities to the route ("METHOD
+// path", relative to BASE_PATH) whose registration enables them.
+var capabilityRoutes = map[string]string{
+	"batchOperations": "POST /api/queues/{queueUrl:.*}/move",
+}
+
+// capabilities reports the optional features this build and configuration
+// provide, so the frontend can show or hide the matching controls.
+type capabilities struct {
+	// Mode is "demo" or "live"
+	Mode string `json:"mode"`
+	// FIFO is set when any listable queue is a FIFO queue
+	FIFO            bool `json:"fifo"`
+	BatchOperations bool `json:"batchOperations"`
+	Auth            bool `json:"auth"`
+	Archive         bool `json:"archive"`
+	// ReadOnly is set when READ_ONLY=true refuses all mutations
+	ReadOnly bool `json:"readOnly"`
+}
+
+// detectCapabilities derives the capabilities from the routes registered on
+// r, the handler and the environment.
+func detectCapabilities(ctx context.Context, r *mux.Router, sqsHandler *sqs.SQSHandler, basePath string) capabilities {
+	routes := registeredRoutes(r, basePath)
+	mode := "live"
+	if sqsHandler.IsDemo() {
+		mode = "demo"
+	}
+	return capabilities{
+		Mode:            mode,
+		FIFO:            sqsHandler.HasFIFOQueues(ctx),
+		BatchOperations: routes[capabilityRoutes["batchOperations"]],
+		Auth:            os.Getenv("API_AUTH_TOKEN") != "",
+		Archive:         sqsHandler.S3 != nil,
+		ReadOnly:        sqs.ReadOnlyEnabled(),
+	}
+}
+
+// registeredRoutes lists r's routes as "METHOD path" keys with basePath
+// stripped. A route without methods matches any, so it is listed under both
+// GET and POST.
+func registeredRoutes(r *mux.Router, basePath string) map[string]bool {
+	routes := make(map[string]bool)
+	_ = r.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		path := strings.TrimPrefix(template, basePath)
+		methods, err := route.GetMethods()
+		if err != nil {
+			methods = []string{"GET", "POST"}
+		}
+		for _, method := range methods {
+			routes[method+" "+path] = true
+		}
+		return nil
+	})
+	return routes
+}
+
+// capabilitiesHandler serves GET /api/capabilities. Routes are looked up per
+// request, so everything registered on r is reported.
+func capabilitiesHandler(r *mux.Router, sqsHandler *sqs.SQSHandler, basePath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		sqs.WriteJSONStatus(w, req, http.StatusOK, detectCapabilities(req.Context(), r, sqsHandler, basePath))
+	}
+}
+
+// startupBannerEnabled reports whether to log the startup banner; set
+// STARTUP_BANNER=false to turn it off.
+func startupBannerEnabled() bool {
+	return os.Getenv("STARTUP_BANNER") != "false"
+}
+
+// logStartupBanner logs the mode and enabled optional features.
+func logStartupBanner(caps capabilities) {
+	enabled := []string{}
+	for _, feature := range []struct {
+		name string
+		on   bool
+	}{
+		{"fifo", caps.FIFO},
+		{"batch operations", caps.BatchOperations},
+		{"auth", caps.Auth},
+		{"archive", caps.Archive},
+		{"read-only", caps.ReadOnly},
+	} {
+		if feature.on {
+			enabled = append(enabled, feature.name)
+		}
+	}
+	log.Printf("SQS UI running in %s mode; features: %s", caps.Mode, strings.Join(enabled, ", "))
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/websocket"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func getCapabilities(t *testing.T, router http.Handler, path string) capabilities {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var caps capabilities
+	if err := json.Unmarshal(rr.Body.Bytes(), &caps); err != nil {
+		t.Fatalf("failed to decode capabilities: %v", err)
+	}
+	return caps
+}
+
+func TestCapabilities_RegisteredRoutes(t *testing.T) {
+	mock := helpers.NewMockSQSClient()
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+
+	caps := getCapabilities(t, router, "/api/capabilities")
+	if !caps.BatchOperations {
+		t.Error("expected batch operations from the registered move route")
+	}
+	if caps.Mode != "live" || caps.FIFO || caps.Auth || caps.Archive || caps.ReadOnly {
+		t.Errorf("unexpected capabilities %+v", caps)
+	}
+}
+
+func TestCapabilities_FIFOFollowsQueues(t *testing.T) {
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue("https://sqs.us-east-1.amazonaws.com/123456789012/orders")
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+
+	if caps := getCapabilities(t, router, "/api/capabilities"); caps.FIFO {
+		t.Error("expected no FIFO capability without FIFO queues")
+	}
+
+	mock.AddQueue("https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo")
+	if caps := getCapabilities(t, router, "/api/capabilities"); !caps.FIFO {
+		t.Error("expected the FIFO capability once a FIFO queue is listed")
+	}
+}
+
+func TestCapabilities_BasePathAndAuth(t *testing.T) {
+	t.Setenv("BASE_PATH", "/sqs-ui")
+	t.Setenv("API_AUTH_TOKEN", "secret")
+
+	mock := helpers.NewMockSQSClient()
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/sqs-ui/api/capabilities", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	router.ServeHTTP(rr, req)
+
+	var caps capabilities
+	if err := json.Unmarshal(rr.Body.Bytes(), &caps); err != nil {
+		t.Fatalf("failed to decode capabilities: %v (status %d)", err, rr.Code)
+	}
+	if !caps.Auth || !caps.BatchOperations {
+		t.Errorf("expected auth and batch operations under BASE_PATH, got %+v", caps)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+)
+
+// readinessTimeout bounds the SQS call behind /readyz so a slow endpoint
+// fails the probe instead of hanging it.
+const readinessTimeout = 3 * time.Second
+
+// writeHealth writes a small JSON health status.
+func writeHealth(w http.ResponseWriter, r *http.Request, status int, state string) {
+	sqs.WriteJSONStatus(w, r, status, map[string]string{"status": state})
+}
+
+// healthzHandler answers liveness probes: the process is up and serving.
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, r, http.StatusOK, "ok")
+}
+
+// readyzHandler answers readiness probes by listing a single queue, so the
+// probe fails with 503 while SQS (or its credentials) is unusable.
+func readyzHandler(sqsHandler *sqs.SQSHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		if _, err := sqsHandler.Client.ListQueues(ctx, &awssqs.ListQueuesInput{MaxResults: aws.Int32(1)}); err != nil {
+			log.Printf("Readiness check failed: %v", err)
+			writeHealth(w, r, http.StatusServiceUnavailable, "unavailable")
+			return
+		}
+		writeHealth(w, r, http.StatusOK, "ready")
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/websocket"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestHealthEndpoints_ExemptFromAuth(t *testing.T) {
+	t.Setenv("API_AUTH_TOKEN", "s3cret")
+	router := newTestRouter()
+
+	tests := []struct {
+		name           string
+		path           string
+		authorization  string
+		expectedStatus int
+	}{
+		{name: "healthz without token", path: "/healthz", expectedStatus: http.StatusOK},
+		{name: "readyz without token", path: "/readyz", expectedStatus: http.StatusOK},
+		{name: "api without token", path: "/api/queues", expectedStatus: http.StatusUnauthorized},
+		{name: "api with wrong token", path: "/api/queues", authorization: "Bearer nope", expectedStatus: http.StatusUnauthorized},
+		{name: "api with token", path: "/api/queues", authorization: "Bearer s3cret", expectedStatus: http.StatusOK},
+		{name: "websocket without token", path: "/ws", expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestHealthEndpoints_NoTokenConfigured(t *testing.T) {
+	t.Setenv("API_AUTH_TOKEN", "")
+	router := newTestRouter()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/queues", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200 without API_AUTH_TOKEN, got %d", rr.Code)
+	}
+}
+
+func TestReadyz_SQSUnavailable(t *testing.T) {
+	mock := helpers.NewMockSQSClient()
+	mock.SetError("ListQueues", errors.New("no credentials"))
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/readyz", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/healthz", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected liveness to stay 200, got %d", rr.Code)
+	}
+}
+
+func TestSharedMessageLink_ExemptFromAuth(t *testing.T) {
+	t.Setenv("API_AUTH_TOKEN", "s3cret")
+	mock := helpers.NewMockSQSClient()
+	mock.AddMessage("https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue", "msg-1", "hello")
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+	sharePath := "/api/queues/https%3A%2F%2Fsqs.us-east-1.amazonaws.com%2F123456789012%2Forders-queue/messages/msg-1/share"
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("POST", sharePath, nil))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected creating a link to need the token, got %d", rr.Code)
+	}
+
+	req := httptest.NewRequest("POST", sharePath, nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var link struct {
+		Path string `json:"path"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&link); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", link.Path, nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected the shared link to work without a token, got %d", rr.Code)
+	}
+}
+
+func TestHealthAndCapabilities_Pretty(t *testing.T) {
+	t.Setenv("API_AUTH_TOKEN", "")
+	router := newTestRouter()
+
+	tests := []struct {
+		path     string
+		expected func(body string) bool
+	}{
+		{path: "/healthz?pretty=true", expected: func(body string) bool { return body == "{\n  \"status\": \"ok\"\n}\n" }},
+		{path: "/healthz", expected: func(body string) bool { return body == "{\"status\":\"ok\"}\n" }},
+		{path: "/api/capabilities?pretty=true", expected: func(body string) bool { return strings.HasPrefix(body, "{\n  \"") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", tt.path, nil))
+			if !tt.expected(rr.Body.String()) {
+				t.Errorf("unexpected body %q", rr.Body.String())
+			}
+		})
+	}
+}
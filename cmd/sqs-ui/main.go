@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"io/fs"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/cjunks94/go-sqs-ui/internal/sqs"
@@ -14,24 +18,55 @@ import (
 )
 
 func main() {
+	if err := validateConfig(); err != nil {
+		exitf(exitInvalidConfig, "Invalid configuration: %v", err)
+	}
+
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
 
+	// Cancelled on SIGINT/SIGTERM to stop background work and the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	sqsHandler, err := sqs.NewSQSHandler()
 	if err != nil {
-		log.Fatal("Failed to create SQS handler:", err)
+		exitf(exitHandlerInit, "Failed to create SQS handler: %v", err)
+	}
+
+	// A DEFAULT_QUEUE that does not resolve is only worth a warning
+	checkCtx, cancelCheck := context.WithTimeout(ctx, 5*time.Second)
+	if err := sqsHandler.CheckDefaultQueue(checkCtx); err != nil {
+		log.Printf("Warning: %v", err)
 	}
+	cancelCheck()
+
+	// Optionally warm the queue attribute cache so the first page load is fast
+	if sqs.PrefetchEnabled() {
+		sqsHandler.EnableAttributeCache()
+		go func() {
+			if err := sqsHandler.WarmAttributeCache(ctx); err != nil {
+				log.Printf("Prefetch: Warm-up stopped: %v", err)
+			}
+		}()
+	}
+
+	// Evaluate registered depth alarms in the background
+	go sqsHandler.RunAlarmSampler(ctx, sqs.AlarmSampleInterval())
 
-	wsManager := websocket.NewWebSocketManager(sqsHandler.Client)
+	wsManager := websocket.NewWebSocketManagerForHandler(sqsHandler)
 
 	staticFS, err := static.GetFS()
 	if err != nil {
-		log.Fatal("Failed to get static filesystem:", err)
+		exitf(exitStaticFS, "Failed to get static filesystem: %v", err)
 	}
 
 	r := newRouter(sqsHandler, wsManager, staticFS)
+	if startupBannerEnabled() {
+		logStartupBanner(detectCapabilities(ctx, r, sqsHandler, basePathFromEnv()))
+	}
 
 	// ReadHeaderTimeout guards against slow-loris; no WriteTimeout so the
 	// long-lived WebSocket stream isn't cut off.
@@ -41,10 +76,20 @@ func main() {
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Server shutdown error: %v", err)
+		}
+	}()
+
 	log.Printf("Server starting on port %s", port)
-	if err := srv.ListenAndServe(); err != nil {
-		log.Fatal("Server failed to start:", err)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		exitf(exitServer, "Server failed to start: %v", err)
 	}
+	log.Printf("Server stopped")
 }
 
 // newRouter wires up all HTTP routes.
@@ -53,28 +98,86 @@ func main() {
 // (URL-encoded), so the decoded "//" must NOT be collapsed into a 301 redirect
 // — that redirect drops the body of POST send/retry requests. Handlers restore
 // the scheme separator via normalizeQueueURL.
+//
+// When BASE_PATH is set (e.g. /sqs-ui behind a reverse proxy) every route,
+// including the WebSocket and static files, is mounted under it.
 func newRouter(sqsHandler *sqs.SQSHandler, wsManager *websocket.WebSocketManager, staticFS fs.FS) *mux.Router {
 	r := mux.NewRouter().SkipClean(true)
 
+	basePath := basePathFromEnv()
+	root := r
+	if basePath != "" {
+		r.Handle(basePath, http.RedirectHandler(basePath+"/", http.StatusMovedPermanently))
+		root = r.PathPrefix(basePath).Subrouter()
+	}
+
+	// Shared message links carry their own signed token instead of API auth,
+	// so they are registered ahead of the API subrouter and its middleware
+	root.Handle("/api/shared/{token}", loggingMiddleware(securityHeadersMiddleware(multiTenantMiddleware(basePath)(sqs.RequestIDMiddleware(http.HandlerFunc(sqsHandler.GetSharedMessage)))))).Methods("GET")
+
 	// API routes with logging middleware
-	api := r.PathPrefix("/api").Subrouter()
+	api := root.PathPrefix("/api").Subrouter()
 	api.Use(loggingMiddleware)
+	api.Use(securityHeadersMiddleware)
+	api.Use(apiAuthMiddleware)
+	api.Use(readOnlyMiddleware(basePath))
+	api.Use(multiTenantMiddleware(basePath))
+	api.Use(sqs.RequestIDMiddleware)
 	api.HandleFunc("/aws-context", sqsHandler.GetAWSContext).Methods("GET")
+	api.HandleFunc("/config", sqsHandler.GetConfig).Methods("GET")
+	api.HandleFunc("/capabilities", capabilitiesHandler(r, sqsHandler, basePath)).Methods("GET")
+	api.HandleFunc("/ws-config", sqsHandler.GetWebSocketConfig).Methods("GET")
+	api.HandleFunc("/validate-message", sqsHandler.ValidateMessage).Methods("POST")
 	api.HandleFunc("/queues", sqsHandler.ListQueues).Methods("GET")
+	api.HandleFunc("/queues/compare", sqsHandler.CompareQueues).Methods("POST")
+	api.HandleFunc("/dlqs", sqsHandler.ListDeadLetterQueues).Methods("GET")
+	api.HandleFunc("/cost-estimate", sqsHandler.GetCostEstimate).Methods("GET")
+	api.HandleFunc("/statistics", sqsHandler.GetAggregateStatistics).Methods("GET")
+	api.HandleFunc("/demo/reset", sqsHandler.ResetDemo).Methods("POST")
 	api.HandleFunc("/queues/{queueUrl:.*}/messages", sqsHandler.GetMessages).Methods("GET")
 	api.HandleFunc("/queues/{queueUrl:.*}/messages", sqsHandler.SendMessage).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/messages/refresh-handles", sqsHandler.RefreshReceiptHandles).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/messages/template", sqsHandler.SendTemplateMessages).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/send-and-verify", sqsHandler.SendAndVerifyMessage).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/messages/{messageId}/body", sqsHandler.GetMessageBody).Methods("GET")
+	api.HandleFunc("/queues/{queueUrl:.*}/messages/{messageId}/share", sqsHandler.ShareMessage).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/messages/{receiptHandle}/edit-resend", sqsHandler.EditResendMessage).Methods("POST")
 	api.HandleFunc("/queues/{queueUrl:.*}/messages/{receiptHandle}", sqsHandler.DeleteMessage).Methods("DELETE")
 	api.HandleFunc("/queues/{queueUrl:.*}/retry", sqsHandler.RetryMessage).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/move", sqsHandler.MoveMessages).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/consume", sqsHandler.ConsumeMessages).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/archive-to-s3", sqsHandler.ArchiveToS3).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/export", sqsHandler.ExportMessages).Methods("GET")
+	api.HandleFunc("/queues/{queueUrl:.*}/import", sqsHandler.ImportMessages).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/snapshot", sqsHandler.CreateSnapshot).Methods("GET")
+	api.HandleFunc("/queues/{queueUrl:.*}/snapshot/{snapshotId}", sqsHandler.GetSnapshotPage).Methods("GET")
 	api.HandleFunc("/queues/{queueUrl:.*}/statistics", sqsHandler.GetQueueStatistics).Methods("GET")
-
-	// WebSocket route (no middleware to avoid hijacker issues)
-	r.HandleFunc("/ws", func(w http.ResponseWriter, req *http.Request) {
+	api.HandleFunc("/queues/{queueUrl:.*}/sources", sqsHandler.GetDeadLetterSources).Methods("GET")
+	api.HandleFunc("/queues/{queueUrl:.*}/throughput", sqsHandler.GetQueueThroughput).Methods("GET")
+	api.HandleFunc("/queues/{queueUrl:.*}/alarms", sqsHandler.CreateAlarm).Methods("POST")
+	api.HandleFunc("/queues/{queueUrl:.*}/alarms", sqsHandler.ListAlarms).Methods("GET")
+	api.HandleFunc("/queues/{queueUrl:.*}/ui-metadata", sqsHandler.GetUIMetadata).Methods("GET")
+	api.HandleFunc("/queues/{queueUrl:.*}/ui-metadata", sqsHandler.PutUIMetadata).Methods("PUT")
+	// Registered last: the catch-all pattern would otherwise shadow the
+	// more specific DELETE routes above
+	api.HandleFunc("/queues/{queueUrl:.*}", sqsHandler.DeleteQueue).Methods("DELETE")
+
+	// Health probes sit outside the API subrouter so they never need a token
+	root.HandleFunc("/healthz", healthzHandler).Methods("GET")
+	root.HandleFunc("/readyz", readyzHandler(sqsHandler)).Methods("GET")
+
+	// WebSocket route (only the auth and multi-tenant checks, which do not
+	// wrap the writer, to avoid hijacker issues)
+	root.Handle("/ws", apiAuthMiddleware(multiTenantMiddleware(basePath)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		log.Printf("WebSocket connection attempt from %s", req.RemoteAddr)
 		wsManager.HandleWebSocket(w, req)
-	})
+	}))))
+
+	// Publishes the base path to the frontend
+	root.Handle("/config.js", securityHeadersMiddleware(configScriptHandler(basePath)))
 
 	// Serve static files (this handles the root path too)
-	r.PathPrefix("/").Handler(http.StripPrefix("/", http.FileServer(http.FS(staticFS))))
+	root.PathPrefix("/").Handler(securityHeadersMiddleware(http.StripPrefix(basePath+"/", http.FileServer(http.FS(staticFS)))))
 
 	return r
 }
@@ -93,7 +196,7 @@ func loggingMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(wrapped, r)
 
 		duration := time.Since(start)
-		log.Printf("%s %s %d %v", r.Method, r.URL.Path, wrapped.statusCode, duration)
+		log.Printf("%s %s %d %v", r.Method, sqs.RedactedPath(r), wrapped.statusCode, duration)
 	})
 }
 
@@ -106,3 +209,11 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Flush forwards to the wrapped writer, so handlers that stream (such as the
+// streamed message listings) still reach the client as they write.
+func (rw *responseWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
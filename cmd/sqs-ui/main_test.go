@@ -54,3 +54,44 @@ func TestNewRouter_SendToEmbeddedQueueURL(t *testing.T) {
 		t.Errorf("expected queue URL %q, got %q", queueURL, got)
 	}
 }
+
+// TestNewRouter_DeleteQueueRouteOrder checks the catch-all DELETE queue route
+// does not shadow message deletes.
+func TestNewRouter_DeleteQueueRouteOrder(t *testing.T) {
+	mock := helpers.NewMockSQSClient()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+	mock.AddQueue(queueURL)
+
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+	encoded := "https%3A%2F%2Fsqs.us-east-1.amazonaws.com%2F123456789012%2Forders-queue"
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("DELETE", "/api/queues/"+encoded+"/messages/handle-1", nil))
+	if rr.Code != http.StatusNoContent || len(mock.DeleteMessageCalls) != 1 {
+		t.Fatalf("expected message delete (204), got %d with %d DeleteMessage calls", rr.Code, len(mock.DeleteMessageCalls))
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("DELETE", "/api/queues/"+encoded+"?confirm=true", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected queue delete (200), got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestLoggingMiddleware_Flush(t *testing.T) {
+	handler := loggingMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected the wrapped writer to implement http.Flusher")
+		}
+		w.Write([]byte("partial"))
+		flusher.Flush()
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+	if !rr.Flushed {
+		t.Error("expected the flush to reach the underlying writer")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/gorilla/mux"
+)
+
+// mutatingMethods are the HTTP methods refused in read-only mode.
+var mutatingMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// readOnlyPostRoutes are the API routes (relative to BASE_PATH) that use
+// POST only to carry a request body and change nothing.
+var readOnlyPostRoutes = map[string]bool{
+	"/api/validate-message":                                true,
+	"/api/queues/compare":                                  true,
+	"/api/queues/{queueUrl:.*}/messages/{messageId}/share": true,
+}
+
+// consumingGetRoutes are the API routes (relative to BASE_PATH) whose GET
+// deletes the message read when ?consume=true is set.
+var consumingGetRoutes = map[string]bool{
+	"/api/queues/{queueUrl:.*}/messages/{messageId}/body": true,
+}
+
+// readOnlyMiddleware answers 403 "read-only mode" to mutating API requests
+// when READ_ONLY=true, leaving reads untouched. A consuming read counts as a
+// mutation.
+func readOnlyMiddleware(basePath string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !sqs.ReadOnlyEnabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			var path string
+			if route := mux.CurrentRoute(r); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil {
+					path = strings.TrimPrefix(template, basePath)
+				}
+			}
+			consuming := r.URL.Query().Get("consume") == "true" && consumingGetRoutes[path]
+			if (!mutatingMethods[r.Method] && !consuming) || readOnlyPostRoutes[path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+			http.Error(w, sqs.ReadOnlyMessage, http.StatusForbidden)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/websocket"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	t.Setenv("READ_ONLY", "true")
+
+	mock := helpers.NewMockSQSClient()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+	mock.AddQueue(queueURL)
+	mock.AddMessage(queueURL, "msg-1", "hello")
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+	encoded := "https%3A%2F%2Fsqs.us-east-1.amazonaws.com%2F123456789012%2Forders-queue"
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		{"get messages", "GET", "/api/queues/" + encoded + "/messages", "", http.StatusOK},
+		{"list queues", "GET", "/api/queues", "", http.StatusOK},
+		{"delete message", "DELETE", "/api/queues/" + encoded + "/messages/receipt-msg-1", "", http.StatusForbidden},
+		{"send message", "POST", "/api/queues/" + encoded + "/messages", `{"body":"hi"}`, http.StatusForbidden},
+		{"delete queue", "DELETE", "/api/queues/" + encoded + "?confirm=true", "", http.StatusForbidden},
+		{"validate message", "POST", "/api/validate-message", `{"body":"hi"}`, http.StatusOK},
+		{"read message body", "GET", "/api/queues/" + encoded + "/messages/msg-1/body", "", http.StatusOK},
+		{"consume message body", "GET", "/api/queues/" + encoded + "/messages/msg-1/body?consume=true", "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, bytes.NewReader([]byte(tt.body))))
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if rr.Code == http.StatusForbidden && strings.TrimSpace(rr.Body.String()) != sqs.ReadOnlyMessage {
+				t.Errorf("expected %q, got %q", sqs.ReadOnlyMessage, rr.Body.String())
+			}
+		})
+	}
+
+	if len(mock.DeleteMessageCalls) != 0 || len(mock.SendMessageCalls) != 0 {
+		t.Error("expected no mutations to reach SQS")
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strings"
+)
+
+// defaultFrameOptions forbids framing the UI unless X_FRAME_OPTIONS says otherwise.
+const defaultFrameOptions = "DENY"
+
+// baseContentSecurityPolicy is tuned for the embedded UI: scripts, styles and
+// assets are served from this origin, index.html uses inline event handlers
+// (onclick) so script-src needs 'unsafe-inline', and the live stream connects
+// back over ws:/wss:.
+const baseContentSecurityPolicy = "default-src 'self'; " +
+	"script-src 'self' 'unsafe-inline'; " +
+	"style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self' ws: wss:; " +
+	"base-uri 'self'; " +
+	"form-action 'self'"
+
+// securityHeaders returns the headers applied to static and API responses.
+//
+// X_FRAME_OPTIONS overrides X-Frame-Options (e.g. SAMEORIGIN); "off" omits it.
+// CONTENT_SECURITY_POLICY replaces the policy wholesale, for users embedding
+// the UI in an iframe on another origin.
+func securityHeaders() map[string]string {
+	headers := map[string]string{
+		"X-Content-Type-Options": "nosniff",
+		"Referrer-Policy":        "strict-origin-when-cross-origin",
+	}
+
+	frameOptions := strings.TrimSpace(os.Getenv("X_FRAME_OPTIONS"))
+	if frameOptions == "" {
+		frameOptions = defaultFrameOptions
+	}
+	if !strings.EqualFold(frameOptions, "off") {
+		headers["X-Frame-Options"] = frameOptions
+	}
+
+	csp := strings.TrimSpace(os.Getenv("CONTENT_SECURITY_POLICY"))
+	if csp == "" {
+		csp = baseContentSecurityPolicy
+		// Keep frame-ancestors consistent with X-Frame-Options, which modern
+		// browsers ignore when a CSP frame-ancestors directive is present.
+		switch strings.ToUpper(frameOptions) {
+		case "DENY":
+			csp += "; frame-ancestors 'none'"
+		case "SAMEORIGIN":
+			csp += "; frame-ancestors 'self'"
+		}
+	}
+	headers["Content-Security-Policy"] = csp
+
+	return headers
+}
+
+// securityHeadersMiddleware sets the security headers on every response. The
+// environment is read once when the middleware is built. It must not wrap the
+// WebSocket route.
+func securityHeadersMiddleware(next http.Handler) http.Handler {
+	headers := securityHeaders()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range headers {
+			w.Header().Set(name, value)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
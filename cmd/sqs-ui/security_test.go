@@ -0,0 +1,104 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/websocket"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func newTestRouter() http.Handler {
+	mock := helpers.NewMockSQSClient()
+	staticFS := fstest.MapFS{
+		"index.html":  &fstest.MapFile{Data: []byte("<html></html>")},
+		"css/app.css": &fstest.MapFile{Data: []byte("body {}")},
+	}
+	return newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), staticFS)
+}
+
+func TestSecurityHeaders_StaticAndAPI(t *testing.T) {
+	router := newTestRouter()
+
+	for _, path := range []string{"/", "/css/app.css", "/api/aws-context"} {
+		t.Run(path, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rr.Code)
+			}
+
+			expected := map[string]string{
+				"X-Content-Type-Options": "nosniff",
+				"X-Frame-Options":        "DENY",
+				"Referrer-Policy":        "strict-origin-when-cross-origin",
+			}
+			for name, value := range expected {
+				if got := rr.Header().Get(name); got != value {
+					t.Errorf("%s: expected %q, got %q", name, value, got)
+				}
+			}
+
+			csp := rr.Header().Get("Content-Security-Policy")
+			if !strings.Contains(csp, "default-src 'self'") || !strings.Contains(csp, "frame-ancestors 'none'") {
+				t.Errorf("unexpected Content-Security-Policy: %q", csp)
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders_NotOnWebSocket(t *testing.T) {
+	router := newTestRouter()
+
+	// A plain GET fails the upgrade; the headers must still not be applied.
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/ws", nil))
+
+	if got := rr.Header().Get("Content-Security-Policy"); got != "" {
+		t.Errorf("expected no Content-Security-Policy on /ws, got %q", got)
+	}
+}
+
+func TestSecurityHeaders_Overrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		frameOptions  string
+		csp           string
+		expectedFrame string
+		expectedCSP   string
+	}{
+		{
+			name:          "sameorigin framing",
+			frameOptions:  "SAMEORIGIN",
+			expectedFrame: "SAMEORIGIN",
+			expectedCSP:   baseContentSecurityPolicy + "; frame-ancestors 'self'",
+		},
+		{
+			name:          "framing header disabled with custom policy",
+			frameOptions:  "off",
+			csp:           "frame-ancestors https://portal.example.com",
+			expectedFrame: "",
+			expectedCSP:   "frame-ancestors https://portal.example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("X_FRAME_OPTIONS", tt.frameOptions)
+			t.Setenv("CONTENT_SECURITY_POLICY", tt.csp)
+
+			headers := securityHeaders()
+			if got := headers["X-Frame-Options"]; got != tt.expectedFrame {
+				t.Errorf("X-Frame-Options: expected %q, got %q", tt.expectedFrame, got)
+			}
+			if got := headers["Content-Security-Policy"]; got != tt.expectedCSP {
+				t.Errorf("Content-Security-Policy: expected %q, got %q", tt.expectedCSP, got)
+			}
+		})
+	}
+}
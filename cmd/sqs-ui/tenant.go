@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/gorilla/mux"
+)
+
+// tenantRoutes are the routes ("METHOD path", relative to BASE_PATH) served
+// in MULTI_TENANT mode: those that act with the request's credentials, and
+// those that neither reach SQS nor keep per-queue state.
+var tenantRoutes = map[string]bool{
+	"GET /api/queues":                        true,
+	"GET /api/queues/{queueUrl:.*}/messages": true,
+	"GET /api/config":                        true,
+	"GET /api/capabilities":                  true,
+	"GET /api/ws-config":                     true,
+	"POST /api/validate-message":             true,
+}
+
+// multiTenantMiddleware answers 403 "not available in multi-tenant mode" to
+// every route outside tenantRoutes when MULTI_TENANT=true. Those routes would
+// otherwise use the server's credentials, or state such as UI metadata and
+// alarms shared by all tenants.
+func multiTenantMiddleware(basePath string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !sqs.MultiTenantEnabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if route := mux.CurrentRoute(r); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil && tenantRoutes[r.Method+" "+strings.TrimPrefix(template, basePath)] {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			http.Error(w, sqs.MultiTenantMessage, http.StatusForbidden)
+		})
+	}
+}
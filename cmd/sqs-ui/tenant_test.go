@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/websocket"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestMultiTenantMiddleware(t *testing.T) {
+	t.Setenv("MULTI_TENANT", "true")
+
+	mock := helpers.NewMockSQSClient()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+	mock.AddQueue(queueURL)
+	mock.AddMessage(queueURL, "msg-1", "hello")
+	router := newRouter(&sqs.SQSHandler{Client: mock}, websocket.NewWebSocketManager(mock), fstest.MapFS{})
+	encoded := "https%3A%2F%2Fsqs.us-east-1.amazonaws.com%2F123456789012%2Forders-queue"
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+	}{
+		// Tenant-aware routes ask for credentials instead of using the server's
+		{"list queues", "GET", "/api/queues", "", http.StatusUnauthorized},
+		{"get messages", "GET", "/api/queues/" + encoded + "/messages", "", http.StatusUnauthorized},
+		// Routes that never reach SQS are served
+		{"validate message", "POST", "/api/validate-message", `{"body":"hi"}`, http.StatusOK},
+		{"config", "GET", "/api/config", "", http.StatusOK},
+		// Routes that would act with the server's credentials are refused
+		{"send message", "POST", "/api/queues/" + encoded + "/messages", `{"body":"hi"}`, http.StatusForbidden},
+		{"queue statistics", "GET", "/api/queues/" + encoded + "/statistics", "", http.StatusForbidden},
+		{"delete queue", "DELETE", "/api/queues/" + encoded + "?confirm=true", "", http.StatusForbidden},
+		// Routes backed by state shared across tenants are refused
+		{"ui metadata", "GET", "/api/queues/" + encoded + "/ui-metadata", "", http.StatusForbidden},
+		{"alarms", "GET", "/api/queues/" + encoded + "/alarms", "", http.StatusForbidden},
+		{"shared message", "GET", "/api/shared/token", "", http.StatusForbidden},
+		{"websocket", "GET", "/ws", "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, httptest.NewRequest(tt.method, tt.path, bytes.NewReader([]byte(tt.body))))
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if rr.Code == http.StatusForbidden && strings.TrimSpace(rr.Body.String()) != sqs.MultiTenantMessage {
+				t.Errorf("expected %q, got %q", sqs.MultiTenantMessage, rr.Body.String())
+			}
+		})
+	}
+
+	if len(mock.ReceiveMessageCalls) != 0 || len(mock.SendMessageCalls) != 0 {
+		t.Error("expected no request to use the server's client")
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cjunks94/go-sqs-ui/internal/sqs"
+)
+
+// Process exit codes, so automation can tell startup failures apart.
+const (
+	// exitInvalidConfig: validateConfig rejected the environment
+	exitInvalidConfig = 2
+	// exitHandlerInit: the SQS handler could not be created (e.g.
+	// FORCE_LIVE_MODE without working AWS access)
+	exitHandlerInit = 3
+	// exitStaticFS: the embedded static files are unavailable
+	exitStaticFS = 4
+	// exitServer: the HTTP server failed to listen or serve
+	exitServer = 5
+)
+
+// positiveIntSettings are numeric settings that must be positive integers
+// when set. Their readers fall back to defaults on bad values; at startup we
+// reject them instead so typos don't go unnoticed.
+var positiveIntSettings = []string{
+	"WS_BACKOFF_AFTER_ERRORS",
+	"WS_WRITE_TIMEOUT_SECONDS",
+	"WS_MAX_FRAME_MESSAGES",
+	"WS_MAX_POLL_ERRORS",
+	"WS_WRITE_QUEUE_SIZE",
+	"MAX_QUEUES_RETURNED",
+	"STATISTICS_TIMEOUT_MS",
+	"STATISTICS_MAX_QUEUES",
+	"SEND_VERIFY_TIMEOUT_MS",
+	"ATTRIBUTE_CACHE_TTL_SECONDS",
+	"STREAM_FLUSH_EVERY",
+	"AWS_MAX_CONCURRENCY",
+	"ALARM_SAMPLE_INTERVAL_SECONDS",
+	"SNAPSHOT_TTL_SECONDS",
+	"MAX_SNAPSHOTS",
+	"AWS_HTTP_TIMEOUT_SECONDS",
+	"AWS_HTTP_DIAL_TIMEOUT_SECONDS",
+	"AWS_HTTP_TLS_HANDSHAKE_TIMEOUT_SECONDS",
+	"DEMO_LATENCY_MS",
+	"DEMO_QUEUE_COUNT",
+	"DEMO_MESSAGES_PER_QUEUE",
+	"MESSAGES_DEFAULT_LIMIT",
+	"MESSAGES_MAX_LIMIT",
+	"MAX_REQUEST_BODY_BYTES",
+	"SHARE_LINK_TTL_SECONDS",
+	"DRAIN_CONCURRENCY",
+	"WS_RECONNECT_BASE_DELAY_MS",
+	"WS_RECONNECT_MAX_DELAY_MS",
+}
+
+// validateConfig checks the environment for invalid or conflicting settings
+// before anything starts, returning every problem found joined together.
+func validateConfig() error {
+	var problems []error
+
+	if os.Getenv("FORCE_DEMO_MODE") == "true" && os.Getenv("FORCE_LIVE_MODE") == "true" {
+		problems = append(problems, errors.New("FORCE_DEMO_MODE and FORCE_LIVE_MODE cannot both be set"))
+	}
+
+	// Multi-tenant mode never uses demo data
+	if os.Getenv("MULTI_TENANT") == "true" && os.Getenv("FORCE_DEMO_MODE") == "true" {
+		problems = append(problems, errors.New("MULTI_TENANT and FORCE_DEMO_MODE cannot both be set"))
+	}
+
+	if port := os.Getenv("PORT"); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			problems = append(problems, fmt.Errorf("PORT must be a port number between 1 and 65535, got %q", port))
+		}
+	}
+
+	if rate := os.Getenv("DEMO_ERROR_RATE"); rate != "" {
+		if f, err := strconv.ParseFloat(rate, 64); err != nil || f < 0 || f > 1 {
+			problems = append(problems, fmt.Errorf("DEMO_ERROR_RATE must be a number between 0 and 1, got %q", rate))
+		}
+	}
+
+	if jitter := os.Getenv("WS_RECONNECT_JITTER"); jitter != "" {
+		if f, err := strconv.ParseFloat(jitter, 64); err != nil || f < 0 || f > 1 {
+			problems = append(problems, fmt.Errorf("WS_RECONNECT_JITTER must be a number between 0 and 1, got %q", jitter))
+		}
+	}
+
+	if attempts := os.Getenv("WS_RECONNECT_MAX_ATTEMPTS"); attempts != "" {
+		if n, err := strconv.Atoi(attempts); err != nil || n < 0 {
+			problems = append(problems, fmt.Errorf("WS_RECONNECT_MAX_ATTEMPTS must be a non-negative integer, got %q", attempts))
+		}
+	}
+
+	for _, name := range []string{"SQS_PRICE_PER_MILLION_REQUESTS", "SQS_FIFO_PRICE_PER_MILLION_REQUESTS"} {
+		if price := os.Getenv(name); price != "" {
+			if f, err := strconv.ParseFloat(price, 64); err != nil || f < 0 {
+				problems = append(problems, fmt.Errorf("%s must be a non-negative number, got %q", name, price))
+			}
+		}
+	}
+
+	if seed := os.Getenv("DEMO_RANDOM_SEED"); seed != "" {
+		if _, err := strconv.ParseInt(seed, 10, 64); err != nil {
+			problems = append(problems, fmt.Errorf("DEMO_RANDOM_SEED must be an integer, got %q", seed))
+		}
+	}
+
+	if mode := os.Getenv("TAG_FETCH_FAILURE_MODE"); mode != "" && mode != "skip" && mode != "include" {
+		problems = append(problems, fmt.Errorf("TAG_FETCH_FAILURE_MODE must be skip or include, got %q", mode))
+	}
+
+	if level := strings.ToLower(os.Getenv("LOG_LEVEL")); level != "" && level != "debug" && level != "info" {
+		problems = append(problems, fmt.Errorf("LOG_LEVEL must be debug or info, got %q", os.Getenv("LOG_LEVEL")))
+	}
+
+	if err := sqs.ValidateRetryTargetAllow(); err != nil {
+		problems = append(problems, err)
+	}
+
+	if err := sqs.ValidateDefaultMessageAttributes(); err != nil {
+		problems = append(problems, err)
+	}
+
+	for _, name := range positiveIntSettings {
+		if value := os.Getenv(name); value != "" {
+			if n, err := strconv.Atoi(value); err != nil || n <= 0 {
+				problems = append(problems, fmt.Errorf("%s must be a positive integer, got %q", name, value))
+			}
+		}
+	}
+
+	return errors.Join(problems...)
+}
+
+// exitf logs a fatal startup error and exits with the given code.
+func exitf(code int, format string, args ...interface{}) {
+	log.Printf(format, args...)
+	os.Exit(code)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		env         map[string]string
+		expectedErr []string
+	}{
+		{
+			name: "defaults are valid",
+		},
+		{
+			name: "valid settings",
+			env:  map[string]string{"PORT": "9000", "FORCE_DEMO_MODE": "true", "WS_WRITE_TIMEOUT_SECONDS": "5"},
+		},
+		{
+			name:        "conflicting force flags",
+			env:         map[string]string{"FORCE_DEMO_MODE": "true", "FORCE_LIVE_MODE": "true"},
+			expectedErr: []string{"FORCE_DEMO_MODE and FORCE_LIVE_MODE"},
+		},
+		{
+			name:        "invalid port",
+			env:         map[string]string{"PORT": "http"},
+			expectedErr: []string{"PORT"},
+		},
+		{
+			name:        "invalid numeric settings are all reported",
+			env:         map[string]string{"STREAM_FLUSH_EVERY": "0", "ATTRIBUTE_CACHE_TTL_SECONDS": "30s"},
+			expectedErr: []string{"STREAM_FLUSH_EVERY", "ATTRIBUTE_CACHE_TTL_SECONDS"},
+		},
+		{
+			name:        "invalid demo fault settings",
+			env:         map[string]string{"DEMO_ERROR_RATE": "1.5", "DEMO_RANDOM_SEED": "abc", "DEMO_LATENCY_MS": "-1"},
+			expectedErr: []string{"DEMO_ERROR_RATE", "DEMO_RANDOM_SEED", "DEMO_LATENCY_MS"},
+		},
+		{
+			name:        "invalid tag fetch failure mode",
+			env:         map[string]string{"TAG_FETCH_FAILURE_MODE": "show"},
+			expectedErr: []string{"TAG_FETCH_FAILURE_MODE"},
+		},
+		{
+			name:        "negative request price",
+			env:         map[string]string{"SQS_PRICE_PER_MILLION_REQUESTS": "-0.4"},
+			expectedErr: []string{"SQS_PRICE_PER_MILLION_REQUESTS"},
+		},
+		{
+			name:        "invalid reconnect jitter",
+			env:         map[string]string{"WS_RECONNECT_JITTER": "1.5", "WS_RECONNECT_MAX_ATTEMPTS": "-2"},
+			expectedErr: []string{"WS_RECONNECT_JITTER", "WS_RECONNECT_MAX_ATTEMPTS"},
+		},
+		{
+			name:        "invalid log level",
+			env:         map[string]string{"LOG_LEVEL": "verbose"},
+			expectedErr: []string{"LOG_LEVEL"},
+		},
+		{
+			name:        "multi-tenant demo mode",
+			env:         map[string]string{"MULTI_TENANT": "true", "FORCE_DEMO_MODE": "true"},
+			expectedErr: []string{"MULTI_TENANT"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range append([]string{"FORCE_DEMO_MODE", "FORCE_LIVE_MODE", "PORT", "DEMO_ERROR_RATE", "DEMO_RANDOM_SEED", "TAG_FETCH_FAILURE_MODE", "MULTI_TENANT", "LOG_LEVEL", "SQS_PRICE_PER_MILLION_REQUESTS", "SQS_FIFO_PRICE_PER_MILLION_REQUESTS", "WS_RECONNECT_JITTER", "WS_RECONNECT_MAX_ATTEMPTS"}, positiveIntSettings...) {
+				t.Setenv(name, tt.env[name])
+			}
+
+			err := validateConfig()
+			if len(tt.expectedErr) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, expected := range tt.expectedErr {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected error to mention %s, got %v", expected, err)
+				}
+			}
+		})
+	}
+}
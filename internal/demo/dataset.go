@@ -0,0 +1,152 @@
+package demo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// Dataset is the DEMO_DATA_FILE format: queues recorded from a real account,
+// with their attributes, tags and a sample of messages, replayed offline by
+// the demo client in place of the built-in demo data.
+type Dataset struct {
+	Queues []DatasetQueue `json:"queues"`
+}
+
+// DatasetQueue is one recorded queue.
+type DatasetQueue struct {
+	URL        string            `json:"url"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Messages   []DatasetMessage  `json:"messages"`
+}
+
+// DatasetMessage is one recorded message.
+type DatasetMessage struct {
+	MessageID         string                             `json:"messageId"`
+	Body              string                             `json:"body"`
+	Attributes        map[string]string                  `json:"attributes,omitempty"`
+	MessageAttributes map[string]DatasetMessageAttribute `json:"messageAttributes,omitempty"`
+}
+
+// DatasetMessageAttribute is a recorded message attribute. Binary values are
+// base64-encoded by encoding/json.
+type DatasetMessageAttribute struct {
+	DataType    string `json:"dataType"`
+	StringValue string `json:"stringValue,omitempty"`
+	BinaryValue []byte `json:"binaryValue,omitempty"`
+}
+
+// Validate checks the dataset has at least one queue and that queue URLs and
+// message IDs are present and unique.
+func (ds *Dataset) Validate() error {
+	if len(ds.Queues) == 0 {
+		return errors.New("dataset has no queues")
+	}
+	queueURLs := make(map[string]bool, len(ds.Queues))
+	for i, queue := range ds.Queues {
+		if queue.URL == "" {
+			return fmt.Errorf("queue %d has no url", i)
+		}
+		if queueURLs[queue.URL] {
+			return fmt.Errorf("queue %s appears twice", queue.URL)
+		}
+		queueURLs[queue.URL] = true
+
+		messageIDs := make(map[string]bool, len(queue.Messages))
+		for j, msg := range queue.Messages {
+			if msg.MessageID == "" {
+				return fmt.Errorf("queue %s: message %d has no messageId", queue.URL, j)
+			}
+			if messageIDs[msg.MessageID] {
+				return fmt.Errorf("queue %s: message %s appears twice", queue.URL, msg.MessageID)
+			}
+			messageIDs[msg.MessageID] = true
+		}
+	}
+	return nil
+}
+
+// LoadDataset reads and validates a dataset file.
+func LoadDataset(path string) (*Dataset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var ds Dataset
+	if err := json.Unmarshal(data, &ds); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if err := ds.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid dataset %s: %w", path, err)
+	}
+	return &ds, nil
+}
+
+// WriteDataset writes the dataset to path as indented JSON.
+func WriteDataset(path string, ds *Dataset) error {
+	data, err := json.MarshalIndent(ds, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// NewDemoSQSClientFromDataset creates a demo client serving the dataset's
+// queues instead of the built-in ones. Recorded queue attributes are reported
+// as-is, except ApproximateNumberOfMessages, which tracks the replayed
+// messages.
+func NewDemoSQSClientFromDataset(ds *Dataset) *DemoSQSClient {
+	demo := &DemoSQSClient{
+		messages:        make(map[string][]types.Message),
+		fifoDedup:       make(map[string]map[string]fifoDedupEntry),
+		now:             time.Now,
+		tags:            make(map[string]map[string]string),
+		queueAttributes: make(map[string]map[string]string),
+		faults:          faultInjectorFromEnv(),
+		seed:            func() *DemoSQSClient { return NewDemoSQSClientFromDataset(ds) },
+	}
+
+	for _, queue := range ds.Queues {
+		demo.queues = append(demo.queues, queue.URL)
+		demo.SetQueueTags(queue.URL, queue.Tags)
+
+		attributes := make(map[string]string, len(queue.Attributes))
+		for k, v := range queue.Attributes {
+			attributes[k] = v
+		}
+		demo.queueAttributes[queue.URL] = attributes
+
+		messages := make([]types.Message, 0, len(queue.Messages))
+		for _, msg := range queue.Messages {
+			message := types.Message{
+				MessageId:     aws.String(msg.MessageID),
+				Body:          aws.String(msg.Body),
+				ReceiptHandle: aws.String("receipt-" + msg.MessageID),
+				Attributes:    make(map[string]string, len(msg.Attributes)),
+			}
+			for k, v := range msg.Attributes {
+				message.Attributes[k] = v
+			}
+			if len(msg.MessageAttributes) > 0 {
+				message.MessageAttributes = make(map[string]types.MessageAttributeValue, len(msg.MessageAttributes))
+				for name, value := range msg.MessageAttributes {
+					attribute := types.MessageAttributeValue{DataType: aws.String(value.DataType), BinaryValue: value.BinaryValue}
+					if value.StringValue != "" {
+						attribute.StringValue = aws.String(value.StringValue)
+					}
+					message.MessageAttributes[name] = attribute
+				}
+			}
+			messages = append(messages, message)
+		}
+		demo.messages[queue.URL] = messages
+	}
+
+	return demo
+}
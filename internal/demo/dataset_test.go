@@ -0,0 +1,36 @@
+package demo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDatasetValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		ds      Dataset
+		wantErr string
+	}{
+		{"valid", Dataset{Queues: []DatasetQueue{{URL: "q1", Messages: []DatasetMessage{{MessageID: "m1"}}}}}, ""},
+		{"no queues", Dataset{}, "no queues"},
+		{"missing url", Dataset{Queues: []DatasetQueue{{}}}, "no url"},
+		{"duplicate queue", Dataset{Queues: []DatasetQueue{{URL: "q1"}, {URL: "q1"}}}, "appears twice"},
+		{"missing message id", Dataset{Queues: []DatasetQueue{{URL: "q1", Messages: []DatasetMessage{{Body: "x"}}}}}, "no messageId"},
+		{"duplicate message", Dataset{Queues: []DatasetQueue{{URL: "q1", Messages: []DatasetMessage{{MessageID: "m1"}, {MessageID: "m1"}}}}}, "appears twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ds.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
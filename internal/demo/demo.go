@@ -3,8 +3,15 @@ package demo
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -12,14 +19,68 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 )
 
+// demoOrdersQueuePolicy is the access policy reported for demo-orders-queue.
+const demoOrdersQueuePolicy = `{"Version":"2012-10-17","Statement":[` +
+	`{"Sid":"AllowOrderEvents","Effect":"Allow","Principal":{"Service":"sns.amazonaws.com"},"Action":"sqs:SendMessage",` +
+	`"Resource":"arn:aws:sqs:us-east-1:123456789012:demo-orders-queue",` +
+	`"Condition":{"ArnEquals":{"aws:SourceArn":"arn:aws:sns:us-east-1:123456789012:demo-order-events"}}},` +
+	`{"Sid":"AllowOrderWorkers","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:role/demo-order-worker"},` +
+	`"Action":["sqs:ReceiveMessage","sqs:DeleteMessage","sqs:ChangeMessageVisibility"],` +
+	`"Resource":"arn:aws:sqs:us-east-1:123456789012:demo-orders-queue"}]}`
+
+// demoDeadLetterQueueName is the demo DLQ, and demoRedriveSourceNames the
+// demo queues whose RedrivePolicy sends failed messages to it.
+const demoDeadLetterQueueName = "demo-deadletter-queue"
+
+var demoRedriveSourceNames = []string{"demo-orders-queue", "demo-payments-queue", "demo-notifications-queue"}
+
+// demoContentDedupQueueName is the demo FIFO queue with content-based
+// deduplication enabled, so sends to it may omit the deduplication ID.
+const demoContentDedupQueueName = "demo-audit.fifo"
+
 // DemoSQSClient provides mock data for demonstration when AWS isn't configured
 type DemoSQSClient struct {
+	// mu guards all fields below; the WebSocket pollers and HTTP handlers
+	// call into the client concurrently.
+	mu       sync.Mutex
 	queues   []string
 	messages map[string][]types.Message
+	// fifoDedup remembers, per FIFO queue, recently used deduplication IDs
+	// and the message each one enqueued.
+	fifoDedup map[string]map[string]fifoDedupEntry
+	// now is the demo clock, replaceable in tests.
+	now func() time.Time
+	// lastMessageID numbers sent messages; IDs are never reused, even after
+	// deletes.
+	lastMessageID int
+	// tags holds the tags reported for each queue URL. They deliberately
+	// differ so the default tag filter hides some demo queues.
+	tags map[string]map[string]string
+	// queueAttributes holds recorded attributes for queues loaded from a
+	// dataset, reported in place of the computed demo attributes. It is set
+	// once at construction and needs no locking.
+	queueAttributes map[string]map[string]string
+	// faults injects the configured latency and errors; it is set once at
+	// construction and needs no locking.
+	faults *faultInjector
+	// seed rebuilds the client's initial data for Reset; it is set once at
+	// construction and needs no locking.
+	seed func() *DemoSQSClient
 }
 
 // NewDemoSQSClient creates a new demo SQS client with pre-populated queues and sample messages.
+// When DEMO_QUEUE_COUNT or DEMO_MESSAGES_PER_QUEUE is set, generated queues
+// of that volume replace the curated set.
 func NewDemoSQSClient() *DemoSQSClient {
+	if queueCount, messagesPerQueue, ok := syntheticVolumeFromEnv(); ok {
+		return newSyntheticDemoSQSClient(queueCount, messagesPerQueue)
+	}
+	return newCuratedDemoSQSClient()
+}
+
+// newCuratedDemoSQSClient creates the demo client with the built-in queues
+// and messages.
+func newCuratedDemoSQSClient() *DemoSQSClient {
 	demo := &DemoSQSClient{
 		queues: []string{
 			"https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue",
@@ -27,10 +88,42 @@ func NewDemoSQSClient() *DemoSQSClient {
 			"https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue",
 			"https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue",
 			"https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue",
+			"https://sqs.us-east-1.amazonaws.com/123456789012/demo-events.fifo",
+			"https://sqs.us-east-1.amazonaws.com/123456789012/demo-audit.fifo",
 		},
-		messages: make(map[string][]types.Message),
+		messages:  make(map[string][]types.Message),
+		fifoDedup: make(map[string]map[string]fifoDedupEntry),
+		now:       time.Now,
+		tags:      make(map[string]map[string]string),
+		faults:    faultInjectorFromEnv(),
+		seed:      newCuratedDemoSQSClient,
 	}
 
+	// Queue tags: orders, payments and the DLQ match the default filter
+	// (degrees/amt/stg|prod); notifications is a dev queue and analytics
+	// belongs to another product, so both are filtered out by default.
+	demo.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue", map[string]string{
+		"businessunit": "degrees", "product": "amt", "env": "stg",
+	})
+	demo.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-notifications-queue", map[string]string{
+		"businessunit": "degrees", "product": "amt", "env": "dev",
+	})
+	demo.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue", map[string]string{
+		"businessunit": "degrees", "product": "amt", "env": "prod",
+	})
+	demo.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue", map[string]string{
+		"businessunit": "degrees", "product": "insights", "env": "stg",
+	})
+	demo.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue", map[string]string{
+		"businessunit": "degrees", "product": "amt", "env": "stg",
+	})
+	demo.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-events.fifo", map[string]string{
+		"businessunit": "degrees", "product": "amt", "env": "stg",
+	})
+	demo.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-audit.fifo", map[string]string{
+		"businessunit": "degrees", "product": "amt", "env": "stg",
+	})
+
 	// Use dynamic timestamps relative to now
 	now := time.Now()
 
@@ -44,6 +137,7 @@ func NewDemoSQSClient() *DemoSQSClient {
 				"SentTimestamp":                    fmt.Sprintf("%d", now.Add(-1*time.Hour).UnixMilli()),
 				"ApproximateReceiveCount":          "1",
 				"ApproximateFirstReceiveTimestamp": fmt.Sprintf("%d", now.Add(-50*time.Minute).UnixMilli()),
+				"AWSTraceHeader":                   "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
 			},
 			MessageAttributes: map[string]types.MessageAttributeValue{
 				"Priority": {
@@ -177,6 +271,10 @@ func NewDemoSQSClient() *DemoSQSClient {
 					DataType:    aws.String("Number"),
 					StringValue: aws.String("3"),
 				},
+				"ErrorType": {
+					DataType:    aws.String("String"),
+					StringValue: aws.String("ValidationError"),
+				},
 			},
 		},
 		{
@@ -197,6 +295,10 @@ func NewDemoSQSClient() *DemoSQSClient {
 					DataType:    aws.String("Number"),
 					StringValue: aws.String("3"),
 				},
+				"ErrorType": {
+					DataType:    aws.String("String"),
+					StringValue: aws.String("GatewayTimeout"),
+				},
 			},
 		},
 		{
@@ -217,6 +319,67 @@ func NewDemoSQSClient() *DemoSQSClient {
 					DataType:    aws.String("Number"),
 					StringValue: aws.String("3"),
 				},
+				"ErrorType": {
+					DataType:    aws.String("String"),
+					StringValue: aws.String("ValidationError"),
+				},
+			},
+		},
+	}
+
+	// Events FIFO Queue - Ordered per customer message group
+	demo.messages["https://sqs.us-east-1.amazonaws.com/123456789012/demo-events.fifo"] = []types.Message{
+		{
+			MessageId:     aws.String("evt-001"),
+			Body:          aws.String(`{"event": "account.created", "customerId": "cust-001"}`),
+			ReceiptHandle: aws.String("receipt-evt-001"),
+			Attributes: map[string]string{
+				"SentTimestamp":           fmt.Sprintf("%d", now.Add(-20*time.Minute).UnixMilli()),
+				"ApproximateReceiveCount": "0",
+				"MessageGroupId":          "cust-001",
+				"MessageDeduplicationId":  "evt-001",
+				"SequenceNumber":          "18849496460467696128",
+			},
+		},
+		{
+			MessageId:     aws.String("evt-002"),
+			Body:          aws.String(`{"event": "account.verified", "customerId": "cust-001"}`),
+			ReceiptHandle: aws.String("receipt-evt-002"),
+			Attributes: map[string]string{
+				"SentTimestamp":           fmt.Sprintf("%d", now.Add(-10*time.Minute).UnixMilli()),
+				"ApproximateReceiveCount": "0",
+				"MessageGroupId":          "cust-001",
+				"MessageDeduplicationId":  "evt-002",
+				"SequenceNumber":          "18849496460467696129",
+			},
+		},
+	}
+
+	// Audit FIFO Queue - content-based deduplication, so the deduplication
+	// IDs are SHA-256 digests of the bodies
+	demo.messages["https://sqs.us-east-1.amazonaws.com/123456789012/demo-audit.fifo"] = []types.Message{
+		{
+			MessageId:     aws.String("aud-001"),
+			Body:          aws.String(`{"action": "user.login", "userId": "user-042"}`),
+			ReceiptHandle: aws.String("receipt-aud-001"),
+			Attributes: map[string]string{
+				"SentTimestamp":           fmt.Sprintf("%d", now.Add(-15*time.Minute).UnixMilli()),
+				"ApproximateReceiveCount": "0",
+				"MessageGroupId":          "user-042",
+				"MessageDeduplicationId":  "a94d8b087c3f4bbe3d32098a16bf2734b972d551a08630ad240a178cbd969f0b",
+				"SequenceNumber":          "18849496460467696200",
+			},
+		},
+		{
+			MessageId:     aws.String("aud-002"),
+			Body:          aws.String(`{"action": "role.granted", "userId": "user-042", "role": "admin"}`),
+			ReceiptHandle: aws.String("receipt-aud-002"),
+			Attributes: map[string]string{
+				"SentTimestamp":           fmt.Sprintf("%d", now.Add(-5*time.Minute).UnixMilli()),
+				"ApproximateReceiveCount": "0",
+				"MessageGroupId":          "user-042",
+				"MessageDeduplicationId":  "4772e373d744cfba2385828a5f64f5093c70136c6d62d3b2611e9f69bcb3d462",
+				"SequenceNumber":          "18849496460467696201",
 			},
 		},
 	}
@@ -226,28 +389,78 @@ func NewDemoSQSClient() *DemoSQSClient {
 
 // ListQueues returns the list of demo SQS queues.
 func (d *DemoSQSClient) ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error) {
+	if err := d.faults.inject(ctx, "ListQueues"); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	log.Printf("Demo: ListQueues called, returning %d demo queues", len(d.queues))
 	return &sqs.ListQueuesOutput{
-		QueueUrls: d.queues,
+		QueueUrls: append([]string(nil), d.queues...),
 	}, nil
 }
 
+// AddQueue adds an empty demo queue with the given tags, as if it had been
+// created out-of-band. Adding an existing queue only replaces its tags.
+func (d *DemoSQSClient) AddQueue(queueURL string, tags map[string]string) {
+	d.mu.Lock()
+	exists := false
+	for _, existing := range d.queues {
+		if existing == queueURL {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		d.queues = append(d.queues, queueURL)
+	}
+	d.mu.Unlock()
+
+	d.SetQueueTags(queueURL, tags)
+}
+
 // ListQueueTags returns demo tags for the specified queue.
 func (d *DemoSQSClient) ListQueueTags(ctx context.Context, params *sqs.ListQueueTagsInput, optFns ...func(*sqs.Options)) (*sqs.ListQueueTagsOutput, error) {
-	log.Printf("Demo: ListQueueTags called for queue %s", aws.ToString(params.QueueUrl))
+	if err := d.faults.inject(ctx, "ListQueueTags"); err != nil {
+		return nil, err
+	}
+
+	queueURL := aws.ToString(params.QueueUrl)
+	log.Printf("Demo: ListQueueTags called for queue %s", queueURL)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	tags := make(map[string]string, len(d.tags[queueURL]))
+	for k, v := range d.tags[queueURL] {
+		tags[k] = v
+	}
 
-	// Return demo tags that match your filter criteria
 	return &sqs.ListQueueTagsOutput{
-		Tags: map[string]string{
-			"businessunit": "degrees",
-			"product":      "amt",
-			"env":          "stg",
-		},
+		Tags: tags,
 	}, nil
 }
 
+// SetQueueTags replaces the tags reported for a demo queue.
+func (d *DemoSQSClient) SetQueueTags(queueURL string, tags map[string]string) {
+	copied := make(map[string]string, len(tags))
+	for k, v := range tags {
+		copied[k] = v
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.tags[queueURL] = copied
+}
+
 // GetQueueAttributes returns demo attributes for the specified queue including message count and ARN.
 func (d *DemoSQSClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	if err := d.faults.inject(ctx, "GetQueueAttributes"); err != nil {
+		return nil, err
+	}
+
 	queueURL := aws.ToString(params.QueueUrl)
 	queueName := queueURL
 	if len(queueURL) > 0 {
@@ -259,12 +472,14 @@ func (d *DemoSQSClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQ
 		}
 	}
 
+	d.mu.Lock()
 	var messageCount string
 	if messages, exists := d.messages[queueURL]; exists {
 		messageCount = fmt.Sprintf("%d", len(messages))
 	} else {
 		messageCount = "0"
 	}
+	d.mu.Unlock()
 
 	attributes := map[string]string{
 		"QueueArn":                    fmt.Sprintf("arn:aws:sqs:us-east-1:123456789012:%s", queueName),
@@ -276,13 +491,36 @@ func (d *DemoSQSClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQ
 	}
 
 	// Add DLQ-specific attributes for the deadletter queue
-	switch queueName {
-	case "demo-deadletter-queue":
+	switch {
+	case queueName == demoDeadLetterQueueName:
 		// RedriveAllowPolicy indicates this IS a DLQ that can receive messages from source queues
 		attributes["RedriveAllowPolicy"] = `{"redrivePermission":"allowAll"}`
-	case "demo-orders-queue", "demo-payments-queue", "demo-notifications-queue":
+	case slices.Contains(demoRedriveSourceNames, queueName):
 		// RedrivePolicy indicates these queues send failed messages TO the DLQ
-		attributes["RedrivePolicy"] = `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:demo-deadletter-queue","maxReceiveCount":"3"}`
+		attributes["RedrivePolicy"] = `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:` + demoDeadLetterQueueName + `","maxReceiveCount":"3"}`
+	}
+
+	// The orders queue has an access policy: an SNS topic may send to it
+	// and the account may consume from it
+	if queueName == "demo-orders-queue" {
+		attributes["Policy"] = demoOrdersQueuePolicy
+	}
+
+	// FIFO queues report their ordering and deduplication settings
+	if isFIFOQueue(queueURL) {
+		attributes["FifoQueue"] = "true"
+		attributes["ContentBasedDeduplication"] = strconv.FormatBool(d.contentBasedDeduplication(queueURL))
+		attributes["DeduplicationScope"] = "messageGroup"
+		attributes["FifoThroughputLimit"] = "perMessageGroupId"
+	}
+
+	// Queues replayed from a dataset report what was recorded
+	if recorded, ok := d.queueAttributes[queueURL]; ok {
+		attributes = make(map[string]string, len(recorded)+1)
+		for k, v := range recorded {
+			attributes[k] = v
+		}
+		attributes["ApproximateNumberOfMessages"] = messageCount
 	}
 
 	return &sqs.GetQueueAttributesOutput{
@@ -292,7 +530,15 @@ func (d *DemoSQSClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQ
 
 // ReceiveMessage retrieves demo messages from the specified queue.
 func (d *DemoSQSClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	if err := d.faults.inject(ctx, "ReceiveMessage"); err != nil {
+		return nil, err
+	}
+
 	queueURL := aws.ToString(params.QueueUrl)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	messages := d.messages[queueURL]
 
 	log.Printf("Demo: ReceiveMessage called for queue %s, found %d messages", queueURL, len(messages))
@@ -303,23 +549,74 @@ func (d *DemoSQSClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveM
 		}, nil
 	}
 
-	maxMessages := int(params.MaxNumberOfMessages)
-	if maxMessages > len(messages) {
-		maxMessages = len(messages)
+	if isFIFOQueue(queueURL) {
+		messages = fifoGroupHeads(messages)
+	}
+
+	maxMessages := max(0, min(int(params.MaxNumberOfMessages), len(messages)))
+
+	// Return a copy: DeleteMessage shifts the backing array in place
+	received := make([]types.Message, maxMessages)
+	copy(received, messages[:maxMessages])
+
+	// Like SQS, report the MD5 digest of each body
+	for i := range received {
+		digest := md5.Sum([]byte(aws.ToString(received[i].Body)))
+		received[i].MD5OfBody = aws.String(hex.EncodeToString(digest[:]))
 	}
 
 	return &sqs.ReceiveMessageOutput{
-		Messages: messages[:maxMessages],
+		Messages: received,
 	}, nil
 }
 
+// contentBasedDeduplication reports the queue's ContentBasedDeduplication
+// attribute: the recorded value for a dataset queue, otherwise the demo
+// default, which enables it on demoContentDedupQueueName only.
+func (d *DemoSQSClient) contentBasedDeduplication(queueURL string) bool {
+	if recorded, ok := d.queueAttributes[queueURL]; ok {
+		return recorded["ContentBasedDeduplication"] == "true"
+	}
+	return strings.HasSuffix(queueURL, "/"+demoContentDedupQueueName)
+}
+
 // SendMessage adds a new demo message to the specified queue.
 func (d *DemoSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	if err := d.faults.inject(ctx, "SendMessage"); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.sendMessage(params), nil
+}
+
+// sendMessage enqueues one message. The caller must hold d.mu.
+func (d *DemoSQSClient) sendMessage(params *sqs.SendMessageInput) *sqs.SendMessageOutput {
 	queueURL := aws.ToString(params.QueueUrl)
 	messageBody := aws.ToString(params.MessageBody)
+	dedupID := aws.ToString(params.MessageDeduplicationId)
+	if dedupID == "" && isFIFOQueue(queueURL) && d.contentBasedDeduplication(queueURL) {
+		// Content-based deduplication hashes the body, as SQS does
+		digest := sha256.Sum256([]byte(messageBody))
+		dedupID = hex.EncodeToString(digest[:])
+	}
+
+	// Like SQS, a FIFO send repeating a deduplication ID within the window is
+	// accepted but not enqueued again, and reports the original message ID.
+	if isFIFOQueue(queueURL) && dedupID != "" {
+		if original, duplicate := d.fifoDuplicate(queueURL, dedupID); duplicate {
+			log.Printf("Demo: Dropping duplicate send to %s (dedup ID %s)", queueURL, dedupID)
+			return &sqs.SendMessageOutput{
+				MessageId: aws.String(original),
+			}
+		}
+	}
 
 	// Generate a new message ID
-	messageID := fmt.Sprintf("demo-msg-%d", len(d.messages[queueURL])+1)
+	d.lastMessageID++
+	messageID := fmt.Sprintf("demo-msg-%d", d.lastMessageID)
 
 	// Add the message to our demo storage
 	newMessage := types.Message{
@@ -327,9 +624,20 @@ func (d *DemoSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessage
 		Body:          aws.String(messageBody),
 		ReceiptHandle: aws.String(fmt.Sprintf("receipt-%s", messageID)),
 		Attributes: map[string]string{
-			"SentTimestamp":           fmt.Sprintf("%d", 1722268800000+int64(len(d.messages[queueURL]))*60000), // July 30, 2025 base + minutes
+			"SentTimestamp":           fmt.Sprintf("%d", d.now().UnixMilli()),
 			"ApproximateReceiveCount": "0",
 		},
+		MessageAttributes: copyMessageAttributes(params.MessageAttributes),
+	}
+	if groupID := aws.ToString(params.MessageGroupId); groupID != "" {
+		newMessage.Attributes["MessageGroupId"] = groupID
+	}
+	if isFIFOQueue(queueURL) && dedupID != "" {
+		newMessage.Attributes["MessageDeduplicationId"] = dedupID
+		d.rememberFIFODedup(queueURL, dedupID, messageID)
+	}
+	if traceHeader, ok := params.MessageSystemAttributes[string(types.MessageSystemAttributeNameForSendsAWSTraceHeader)]; ok {
+		newMessage.Attributes["AWSTraceHeader"] = aws.ToString(traceHeader.StringValue)
 	}
 
 	if d.messages[queueURL] == nil {
@@ -339,14 +647,65 @@ func (d *DemoSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessage
 
 	return &sqs.SendMessageOutput{
 		MessageId: aws.String(messageID),
-	}, nil
+	}
+}
+
+// copyMessageAttributes copies a send's message attributes, so a caller
+// reusing its map or byte slices cannot change the stored message.
+func copyMessageAttributes(attributes map[string]types.MessageAttributeValue) map[string]types.MessageAttributeValue {
+	if attributes == nil {
+		return nil
+	}
+	copied := make(map[string]types.MessageAttributeValue, len(attributes))
+	for name, value := range attributes {
+		value.BinaryValue = slices.Clone(value.BinaryValue)
+		value.StringListValues = slices.Clone(value.StringListValues)
+		value.BinaryListValues = slices.Clone(value.BinaryListValues)
+		copied[name] = value
+	}
+	return copied
+}
+
+// SendMessageBatch enqueues each entry in order, reporting every entry as
+// successful.
+func (d *DemoSQSClient) SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+	if err := d.faults.inject(ctx, "SendMessageBatch"); err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	output := &sqs.SendMessageBatchOutput{}
+	for _, entry := range params.Entries {
+		result := d.sendMessage(&sqs.SendMessageInput{
+			QueueUrl:                params.QueueUrl,
+			MessageBody:             entry.MessageBody,
+			MessageAttributes:       entry.MessageAttributes,
+			MessageGroupId:          entry.MessageGroupId,
+			MessageDeduplicationId:  entry.MessageDeduplicationId,
+			MessageSystemAttributes: entry.MessageSystemAttributes,
+		})
+		output.Successful = append(output.Successful, types.SendMessageBatchResultEntry{
+			Id:        entry.Id,
+			MessageId: result.MessageId,
+		})
+	}
+	return output, nil
 }
 
 // DeleteMessage removes a message from the specified demo queue using its receipt handle.
 func (d *DemoSQSClient) DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	if err := d.faults.inject(ctx, "DeleteMessage"); err != nil {
+		return nil, err
+	}
+
 	queueURL := aws.ToString(params.QueueUrl)
 	receiptHandle := aws.ToString(params.ReceiptHandle)
 
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	// Remove message with matching receipt handle
 	messages := d.messages[queueURL]
 	for i, msg := range messages {
@@ -358,3 +717,125 @@ func (d *DemoSQSClient) DeleteMessage(ctx context.Context, params *sqs.DeleteMes
 
 	return &sqs.DeleteMessageOutput{}, nil
 }
+
+// ChangeMessageVisibility changes how long a received message stays hidden.
+// The demo does not hide received messages, so this is a no-op.
+func (d *DemoSQSClient) ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error) {
+	if err := d.faults.inject(ctx, "ChangeMessageVisibility"); err != nil {
+		return nil, err
+	}
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+// ListDeadLetterSourceQueues returns the demo queues that redrive to the
+// given queue: the seeded sources for the deadletter queue that still exist,
+// and none for any other queue.
+func (d *DemoSQSClient) ListDeadLetterSourceQueues(ctx context.Context, params *sqs.ListDeadLetterSourceQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListDeadLetterSourceQueuesOutput, error) {
+	if err := d.faults.inject(ctx, "ListDeadLetterSourceQueues"); err != nil {
+		return nil, err
+	}
+
+	queueURL := aws.ToString(params.QueueUrl)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !slices.Contains(d.queues, queueURL) {
+		return nil, &types.QueueDoesNotExist{Message: aws.String("The specified queue does not exist.")}
+	}
+
+	sources := []string{}
+	if queueURL[strings.LastIndex(queueURL, "/")+1:] == demoDeadLetterQueueName {
+		for _, candidate := range d.queues {
+			if slices.Contains(demoRedriveSourceNames, candidate[strings.LastIndex(candidate, "/")+1:]) {
+				sources = append(sources, candidate)
+			}
+		}
+	}
+	return &sqs.ListDeadLetterSourceQueuesOutput{QueueUrls: sources}, nil
+}
+
+// DeleteQueue removes a demo queue with its messages, tags and FIFO state.
+// Unlike SQS the queue is gone immediately. Unknown queues fail with
+// QueueDoesNotExist.
+func (d *DemoSQSClient) DeleteQueue(ctx context.Context, params *sqs.DeleteQueueInput, optFns ...func(*sqs.Options)) (*sqs.DeleteQueueOutput, error) {
+	if err := d.faults.inject(ctx, "DeleteQueue"); err != nil {
+		return nil, err
+	}
+
+	queueURL := aws.ToString(params.QueueUrl)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	index := slices.Index(d.queues, queueURL)
+	if index < 0 {
+		return nil, &types.QueueDoesNotExist{Message: aws.String("The specified queue does not exist.")}
+	}
+	d.queues = slices.Delete(d.queues, index, index+1)
+	delete(d.messages, queueURL)
+	delete(d.tags, queueURL)
+	delete(d.fifoDedup, queueURL)
+
+	log.Printf("Demo: DeleteQueue removed queue %s", queueURL)
+	return &sqs.DeleteQueueOutput{}, nil
+}
+
+// isFIFOQueue reports whether the queue URL names a FIFO queue.
+func isFIFOQueue(queueURL string) bool {
+	return strings.HasSuffix(queueURL, ".fifo")
+}
+
+// fifoGroupHeads simulates FIFO per-group ordering: only the oldest message of
+// each MessageGroupId is deliverable, so the next one becomes visible once it
+// is deleted. Messages without a group ID are treated as their own group.
+func fifoGroupHeads(messages []types.Message) []types.Message {
+	heads := []types.Message{}
+	seenGroups := make(map[string]bool)
+	for _, msg := range messages {
+		groupID, grouped := msg.Attributes["MessageGroupId"]
+		if !grouped {
+			heads = append(heads, msg)
+			continue
+		}
+		if seenGroups[groupID] {
+			continue
+		}
+		seenGroups[groupID] = true
+		heads = append(heads, msg)
+	}
+	return heads
+}
+
+// fifoDedupWindow is how long SQS remembers a FIFO deduplication ID.
+const fifoDedupWindow = 5 * time.Minute
+
+// fifoDedupEntry records the message a deduplication ID enqueued, and when.
+type fifoDedupEntry struct {
+	messageID string
+	sentAt    time.Time
+}
+
+// fifoDuplicate reports whether dedupID was used on queueURL within the
+// deduplication window, returning the original message ID. Expired IDs are
+// pruned as a side effect.
+func (d *DemoSQSClient) fifoDuplicate(queueURL, dedupID string) (string, bool) {
+	now := d.now()
+	seen := d.fifoDedup[queueURL]
+	for id, entry := range seen {
+		if now.Sub(entry.sentAt) >= fifoDedupWindow {
+			delete(seen, id)
+		}
+	}
+
+	entry, ok := seen[dedupID]
+	return entry.messageID, ok
+}
+
+// rememberFIFODedup starts the deduplication window for dedupID.
+func (d *DemoSQSClient) rememberFIFODedup(queueURL, dedupID, messageID string) {
+	if d.fifoDedup[queueURL] == nil {
+		d.fifoDedup[queueURL] = make(map[string]fifoDedupEntry)
+	}
+	d.fifoDedup[queueURL][dedupID] = fifoDedupEntry{messageID: messageID, sentAt: d.now()}
+}
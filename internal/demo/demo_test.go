@@ -2,11 +2,15 @@ package demo
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
 )
 
 func TestNewDemoSQSClient(t *testing.T) {
@@ -15,8 +19,8 @@ func TestNewDemoSQSClient(t *testing.T) {
 		t.Fatal("NewDemoSQSClient returned nil")
 	}
 
-	if len(client.queues) != 5 {
-		t.Errorf("Expected 5 demo queues, got %d", len(client.queues))
+	if len(client.queues) != 7 {
+		t.Errorf("Expected 7 demo queues, got %d", len(client.queues))
 	}
 
 	expectedQueues := []string{
@@ -25,6 +29,8 @@ func TestNewDemoSQSClient(t *testing.T) {
 		"demo-payments-queue",
 		"demo-analytics-queue",
 		"demo-deadletter-queue",
+		"demo-events.fifo",
+		"demo-audit.fifo",
 	}
 
 	for _, expectedName := range expectedQueues {
@@ -50,8 +56,8 @@ func TestDemoSQSClient_ListQueues(t *testing.T) {
 		t.Fatalf("ListQueues failed: %v", err)
 	}
 
-	if len(output.QueueUrls) != 5 {
-		t.Errorf("Expected 5 queue URLs, got %d", len(output.QueueUrls))
+	if len(output.QueueUrls) != 7 {
+		t.Errorf("Expected 7 queue URLs, got %d", len(output.QueueUrls))
 	}
 
 	for _, url := range output.QueueUrls {
@@ -129,6 +135,36 @@ func TestDemoSQSClient_ListQueueTags(t *testing.T) {
 	}
 }
 
+func TestDemoSQSClient_ListQueueTags_PerQueue(t *testing.T) {
+	client := NewDemoSQSClient()
+	ctx := context.Background()
+
+	tagsFor := func(queueURL string) map[string]string {
+		t.Helper()
+		output, err := client.ListQueueTags(ctx, &sqs.ListQueueTagsInput{
+			QueueUrl: aws.String(queueURL),
+		})
+		if err != nil {
+			t.Fatalf("ListQueueTags failed: %v", err)
+		}
+		return output.Tags
+	}
+
+	if env := tagsFor("https://sqs.us-east-1.amazonaws.com/123456789012/demo-notifications-queue")["env"]; env != "dev" {
+		t.Errorf("Expected notifications queue env tag 'dev', got %q", env)
+	}
+
+	custom := "https://sqs.us-east-1.amazonaws.com/123456789012/custom-queue"
+	if tags := tagsFor(custom); len(tags) != 0 {
+		t.Errorf("Expected no tags for an untagged queue, got %v", tags)
+	}
+
+	client.SetQueueTags(custom, map[string]string{"team": "platform"})
+	if team := tagsFor(custom)["team"]; team != "platform" {
+		t.Errorf("Expected team tag 'platform', got %q", team)
+	}
+}
+
 func TestDemoSQSClient_ReceiveMessage(t *testing.T) {
 	client := NewDemoSQSClient()
 	ctx := context.Background()
@@ -246,6 +282,40 @@ func TestDemoSQSClient_SendMessage(t *testing.T) {
 	}
 }
 
+func TestDemoSQSClient_SendMessageCopiesAttributes(t *testing.T) {
+	client := NewDemoSQSClient()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	attributes := map[string]types.MessageAttributeValue{
+		"source": {DataType: aws.String("String"), StringValue: aws.String("web")},
+		"blob":   {DataType: aws.String("Binary"), BinaryValue: []byte("abc")},
+	}
+	output, err := client.SendMessage(context.Background(), &sqs.SendMessageInput{
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String("hello"),
+		MessageAttributes: attributes,
+	})
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+
+	// The caller reusing its map and buffers must not reach the stored message
+	attributes["source"] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String("changed")}
+	attributes["extra"] = types.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String("x")}
+	attributes["blob"].BinaryValue[0] = 'z'
+
+	for _, msg := range client.messages[queueURL] {
+		if aws.ToString(msg.MessageId) != aws.ToString(output.MessageId) {
+			continue
+		}
+		if len(msg.MessageAttributes) != 2 || aws.ToString(msg.MessageAttributes["source"].StringValue) != "web" || string(msg.MessageAttributes["blob"].BinaryValue) != "abc" {
+			t.Errorf("Expected the attributes as sent, got %+v", msg.MessageAttributes)
+		}
+		return
+	}
+	t.Fatal("Sent message not found")
+}
+
 func TestDemoSQSClient_DeleteMessage(t *testing.T) {
 	client := NewDemoSQSClient()
 	ctx := context.Background()
@@ -294,6 +364,54 @@ func TestDemoSQSClient_DeleteMessage(t *testing.T) {
 	}
 }
 
+func TestDemoSQSClient_SendAfterDeleteKeepsIDsUnique(t *testing.T) {
+	client := NewDemoSQSClient()
+	sentAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return sentAt }
+	ctx := context.Background()
+
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue"
+	send := func(body string) string {
+		t.Helper()
+		output, err := client.SendMessage(ctx, &sqs.SendMessageInput{
+			QueueUrl:    aws.String(queueURL),
+			MessageBody: aws.String(body),
+		})
+		if err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+		return aws.ToString(output.MessageId)
+	}
+
+	first := send("first")
+	second := send("second")
+	if _, err := client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: aws.String("receipt-" + first),
+	}); err != nil {
+		t.Fatalf("DeleteMessage failed: %v", err)
+	}
+	third := send("third")
+
+	if third == first || third == second {
+		t.Fatalf("expected a fresh ID after delete, got %s (earlier %s, %s)", third, first, second)
+	}
+
+	seen := map[string]bool{}
+	for _, msg := range client.messages[queueURL] {
+		id := aws.ToString(msg.MessageId)
+		if seen[id] {
+			t.Errorf("duplicate message ID %s in queue", id)
+		}
+		seen[id] = true
+		if id == third {
+			if got := msg.Attributes["SentTimestamp"]; got != fmt.Sprintf("%d", sentAt.UnixMilli()) {
+				t.Errorf("expected SentTimestamp from the demo clock, got %s", got)
+			}
+		}
+	}
+}
+
 func TestDemoSQSClient_InvalidQueue(t *testing.T) {
 	client := NewDemoSQSClient()
 	ctx := context.Background()
@@ -322,3 +440,276 @@ func TestDemoSQSClient_InvalidQueue(t *testing.T) {
 		t.Error("Invalid queue should return no messages")
 	}
 }
+
+func TestDemoSQSClient_FIFOGroupHeads(t *testing.T) {
+	client := NewDemoSQSClient()
+	ctx := context.Background()
+
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders.fifo"
+	sends := []struct{ group, body string }{
+		{"group-a", "a-1"},
+		{"group-a", "a-2"},
+		{"group-b", "b-1"},
+		{"group-b", "b-2"},
+	}
+	for _, s := range sends {
+		if _, err := client.SendMessage(ctx, &sqs.SendMessageInput{
+			QueueUrl:       aws.String(queueURL),
+			MessageBody:    aws.String(s.body),
+			MessageGroupId: aws.String(s.group),
+		}); err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+	}
+
+	receiveBodies := func() []string {
+		t.Helper()
+		output, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(queueURL),
+			MaxNumberOfMessages: 10,
+		})
+		if err != nil {
+			t.Fatalf("ReceiveMessage failed: %v", err)
+		}
+		bodies := []string{}
+		for _, msg := range output.Messages {
+			bodies = append(bodies, aws.ToString(msg.Body))
+		}
+		return bodies
+	}
+
+	if got := strings.Join(receiveBodies(), ","); got != "a-1,b-1" {
+		t.Fatalf("expected only group heads a-1,b-1, got %s", got)
+	}
+
+	// Receiving again must not advance a group while its head is in flight.
+	if got := strings.Join(receiveBodies(), ","); got != "a-1,b-1" {
+		t.Fatalf("expected heads unchanged on re-receive, got %s", got)
+	}
+
+	if _, err := client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: aws.String("receipt-demo-msg-1"),
+	}); err != nil {
+		t.Fatalf("DeleteMessage failed: %v", err)
+	}
+
+	if got := strings.Join(receiveBodies(), ","); got != "a-2,b-1" {
+		t.Errorf("expected deleting a-1 to reveal a-2, got %s", got)
+	}
+}
+
+func TestDemoSQSClient_FIFODeduplication(t *testing.T) {
+	client := NewDemoSQSClient()
+	ctx := context.Background()
+
+	now := time.Date(2025, 7, 30, 12, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders.fifo"
+	send := func(body, dedupID string) string {
+		t.Helper()
+		output, err := client.SendMessage(ctx, &sqs.SendMessageInput{
+			QueueUrl:               aws.String(queueURL),
+			MessageBody:            aws.String(body),
+			MessageGroupId:         aws.String("orders"),
+			MessageDeduplicationId: aws.String(dedupID),
+		})
+		if err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+		return aws.ToString(output.MessageId)
+	}
+
+	first := send("order 1", "dedup-1")
+	now = now.Add(4 * time.Minute)
+	if duplicate := send("order 1 again", "dedup-1"); duplicate != first {
+		t.Errorf("Expected duplicate send to return original ID %s, got %s", first, duplicate)
+	}
+	if count := len(client.messages[queueURL]); count != 1 {
+		t.Fatalf("Expected 1 enqueued message within the window, got %d", count)
+	}
+
+	// A different dedup ID is enqueued
+	send("order 2", "dedup-2")
+	if count := len(client.messages[queueURL]); count != 2 {
+		t.Fatalf("Expected 2 enqueued messages, got %d", count)
+	}
+
+	// Once the 5-minute window has passed the ID can be reused
+	now = now.Add(2 * time.Minute)
+	if reused := send("order 1 later", "dedup-1"); reused == first {
+		t.Error("Expected a new message ID after the dedup window expired")
+	}
+	if count := len(client.messages[queueURL]); count != 3 {
+		t.Errorf("Expected 3 enqueued messages, got %d", count)
+	}
+}
+
+func TestDemoSQSClient_DeduplicationIgnoredForStandardQueues(t *testing.T) {
+	client := NewDemoSQSClient()
+	ctx := context.Background()
+
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	before := len(client.messages[queueURL])
+	for i := 0; i < 2; i++ {
+		if _, err := client.SendMessage(ctx, &sqs.SendMessageInput{
+			QueueUrl:               aws.String(queueURL),
+			MessageBody:            aws.String("same"),
+			MessageDeduplicationId: aws.String("dedup-1"),
+		}); err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+	}
+	if got := len(client.messages[queueURL]) - before; got != 2 {
+		t.Errorf("Expected both sends to be enqueued on a standard queue, got %d", got)
+	}
+}
+
+func TestDemoSQSClient_ContentBasedDeduplicationFollowsAttribute(t *testing.T) {
+	ctx := context.Background()
+	enabledURL := "https://sqs.us-east-1.amazonaws.com/123456789012/ledger.fifo"
+	disabledURL := "https://sqs.us-east-1.amazonaws.com/123456789012/" + demoContentDedupQueueName
+	client := NewDemoSQSClientFromDataset(&Dataset{Queues: []DatasetQueue{
+		{URL: enabledURL, Attributes: map[string]string{"FifoQueue": "true", "ContentBasedDeduplication": "true"}},
+		{URL: disabledURL, Attributes: map[string]string{"FifoQueue": "true", "ContentBasedDeduplication": "false"}},
+	}})
+
+	for _, tt := range []struct {
+		queueURL string
+		expected int
+	}{
+		{enabledURL, 1},
+		{disabledURL, 2},
+	} {
+		for i := 0; i < 2; i++ {
+			if _, err := client.SendMessage(ctx, &sqs.SendMessageInput{
+				QueueUrl:       aws.String(tt.queueURL),
+				MessageBody:    aws.String("same"),
+				MessageGroupId: aws.String("group-1"),
+			}); err != nil {
+				t.Fatalf("SendMessage failed: %v", err)
+			}
+		}
+		if got := len(client.messages[tt.queueURL]); got != tt.expected {
+			t.Errorf("Expected %d messages on %s, got %d", tt.expected, tt.queueURL, got)
+		}
+	}
+}
+
+func TestDemoS3Client_PutObject(t *testing.T) {
+	client := NewDemoS3Client()
+
+	if err := client.PutObject(context.Background(), "archive", "dlq/msg-1.json", []byte(`{"id":"1"}`)); err != nil {
+		t.Fatalf("PutObject failed: %v", err)
+	}
+
+	body, ok := client.Object("archive", "dlq/msg-1.json")
+	if !ok || string(body) != `{"id":"1"}` {
+		t.Errorf("Expected stored object, got %q (found=%v)", body, ok)
+	}
+	if _, ok := client.Object("archive", "dlq/missing.json"); ok {
+		t.Error("Expected missing object to be absent")
+	}
+}
+
+// Run with -race: pollers, senders and deleters share the client in demo mode.
+func TestDemoSQSClient_ConcurrentAccess(t *testing.T) {
+	client := NewDemoSQSClient()
+	ctx := context.Background()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				output, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+					QueueUrl:            aws.String(queueURL),
+					MaxNumberOfMessages: 10,
+				})
+				if err != nil {
+					t.Errorf("ReceiveMessage failed: %v", err)
+					return
+				}
+				for _, msg := range output.Messages {
+					_ = aws.ToString(msg.Body)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := client.SendMessage(ctx, &sqs.SendMessageInput{
+					QueueUrl:    aws.String(queueURL),
+					MessageBody: aws.String("concurrent"),
+				}); err != nil {
+					t.Errorf("SendMessage failed: %v", err)
+					return
+				}
+				client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{QueueUrl: aws.String(queueURL)})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				output, _ := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+					QueueUrl:            aws.String(queueURL),
+					MaxNumberOfMessages: 1,
+				})
+				for _, msg := range output.Messages {
+					client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+						QueueUrl:      aws.String(queueURL),
+						ReceiptHandle: msg.ReceiptHandle,
+					})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDemoSQSClient_ReceiveMessageReturnsCopy(t *testing.T) {
+	client := NewDemoSQSClient()
+	ctx := context.Background()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	output, _ := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: 10,
+	})
+	if len(output.Messages) < 2 {
+		t.Fatalf("expected at least 2 seeded messages, got %d", len(output.Messages))
+	}
+	secondID := aws.ToString(output.Messages[1].MessageId)
+
+	// Deleting the head must not shift the slice the caller already holds
+	client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: output.Messages[0].ReceiptHandle,
+	})
+
+	if got := aws.ToString(output.Messages[1].MessageId); got != secondID {
+		t.Errorf("received slice was mutated by DeleteMessage: expected %s at index 1, got %s", secondID, got)
+	}
+}
+
+func TestDemoSQSClient_ReceiveMessageClampsCount(t *testing.T) {
+	client := NewDemoSQSClient()
+	ctx := context.Background()
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	for _, max := range []int32{-1, 0, 1000} {
+		output, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(queueURL),
+			MaxNumberOfMessages: max,
+		})
+		if err != nil {
+			t.Fatalf("MaxNumberOfMessages=%d: ReceiveMessage failed: %v", max, err)
+		}
+		if max <= 0 && len(output.Messages) != 0 {
+			t.Errorf("MaxNumberOfMessages=%d: expected no messages, got %d", max, len(output.Messages))
+		}
+	}
+}
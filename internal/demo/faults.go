@@ -0,0 +1,102 @@
+package demo
+
+import (
+	"context"
+	"log"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go"
+)
+
+// injectedErrorCode is the error code of failures injected by DEMO_ERROR_RATE.
+const injectedErrorCode = "ServiceUnavailable"
+
+// faultInjector makes demo operations behave more like a remote service, for
+// exercising the UI's loading and error states: DEMO_LATENCY_MS delays every
+// operation and DEMO_ERROR_RATE (0 to 1) fails that fraction of them.
+// DEMO_RANDOM_SEED fixes the sequence of injected errors.
+type faultInjector struct {
+	latency   time.Duration
+	errorRate float64
+
+	// mu guards rng, which is not safe for concurrent use. It is separate
+	// from the client's lock so injected latency never serializes calls.
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+// faultInjectorFromEnv reads DEMO_LATENCY_MS, DEMO_ERROR_RATE and
+// DEMO_RANDOM_SEED, logging and ignoring invalid values.
+func faultInjectorFromEnv() *faultInjector {
+	f := &faultInjector{}
+
+	if value := os.Getenv("DEMO_LATENCY_MS"); value != "" {
+		if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+			f.latency = time.Duration(ms) * time.Millisecond
+		} else {
+			log.Printf("Invalid DEMO_LATENCY_MS %q, injecting no latency", value)
+		}
+	}
+
+	if value := os.Getenv("DEMO_ERROR_RATE"); value != "" {
+		if rate, err := strconv.ParseFloat(value, 64); err == nil && rate >= 0 && rate <= 1 {
+			f.errorRate = rate
+		} else {
+			log.Printf("Invalid DEMO_ERROR_RATE %q, injecting no errors", value)
+		}
+	}
+
+	seed := uint64(time.Now().UnixNano())
+	if value := os.Getenv("DEMO_RANDOM_SEED"); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			seed = uint64(parsed)
+		} else {
+			log.Printf("Invalid DEMO_RANDOM_SEED %q, using a random seed", value)
+		}
+	}
+	f.rng = rand.New(rand.NewPCG(seed, seed))
+
+	if f.latency > 0 || f.errorRate > 0 {
+		log.Printf("Demo: Injecting %v latency and a %.2f error rate into every operation", f.latency, f.errorRate)
+	}
+	return f
+}
+
+// inject applies the configured latency, returning early with the context's
+// error if it is cancelled first, and then fails the operation at the
+// configured rate.
+func (f *faultInjector) inject(ctx context.Context, operation string) error {
+	if f == nil {
+		return nil
+	}
+
+	if f.latency > 0 {
+		timer := time.NewTimer(f.latency)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if f.errorRate > 0 {
+		f.mu.Lock()
+		roll := f.rng.Float64()
+		f.mu.Unlock()
+
+		if roll < f.errorRate {
+			log.Printf("Demo: Injecting an error into %s (DEMO_ERROR_RATE)", operation)
+			return &smithy.GenericAPIError{
+				Code:    injectedErrorCode,
+				Message: "demo: injected " + operation + " failure (DEMO_ERROR_RATE)",
+				Fault:   smithy.FaultServer,
+			}
+		}
+	}
+	return nil
+}
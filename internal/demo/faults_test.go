@@ -0,0 +1,133 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+)
+
+const faultTestQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+// demoOperations calls every SQS operation of the demo client once.
+func demoOperations(client *DemoSQSClient) map[string]func(context.Context) error {
+	queueURL := aws.String(faultTestQueueURL)
+	return map[string]func(context.Context) error{
+		"ListQueues": func(ctx context.Context) error {
+			_, err := client.ListQueues(ctx, &sqs.ListQueuesInput{})
+			return err
+		},
+		"ListQueueTags": func(ctx context.Context) error {
+			_, err := client.ListQueueTags(ctx, &sqs.ListQueueTagsInput{QueueUrl: queueURL})
+			return err
+		},
+		"GetQueueAttributes": func(ctx context.Context) error {
+			_, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{QueueUrl: queueURL})
+			return err
+		},
+		"ReceiveMessage": func(ctx context.Context) error {
+			_, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{QueueUrl: queueURL, MaxNumberOfMessages: 1})
+			return err
+		},
+		"SendMessage": func(ctx context.Context) error {
+			_, err := client.SendMessage(ctx, &sqs.SendMessageInput{QueueUrl: queueURL, MessageBody: aws.String("hi")})
+			return err
+		},
+		"SendMessageBatch": func(ctx context.Context) error {
+			_, err := client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+				QueueUrl: queueURL,
+				Entries:  []types.SendMessageBatchRequestEntry{{Id: aws.String("1"), MessageBody: aws.String("hi")}},
+			})
+			return err
+		},
+		"DeleteMessage": func(ctx context.Context) error {
+			_, err := client.DeleteMessage(ctx, &sqs.DeleteMessageInput{QueueUrl: queueURL, ReceiptHandle: aws.String("missing")})
+			return err
+		},
+		"ChangeMessageVisibility": func(ctx context.Context) error {
+			_, err := client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{QueueUrl: queueURL, ReceiptHandle: aws.String("missing")})
+			return err
+		},
+	}
+}
+
+func TestDemoSQSClient_ErrorRate(t *testing.T) {
+	t.Setenv("DEMO_ERROR_RATE", "1.0")
+	client := NewDemoSQSClient()
+
+	for name, call := range demoOperations(client) {
+		t.Run(name, func(t *testing.T) {
+			err := call(context.Background())
+			var apiErr smithy.APIError
+			if !errors.As(err, &apiErr) || apiErr.ErrorCode() != injectedErrorCode {
+				t.Errorf("expected an injected %s error, got %v", injectedErrorCode, err)
+			}
+		})
+	}
+}
+
+func TestDemoSQSClient_NoFaultsByDefault(t *testing.T) {
+	t.Setenv("DEMO_ERROR_RATE", "")
+	t.Setenv("DEMO_LATENCY_MS", "")
+	client := NewDemoSQSClient()
+
+	for name, call := range demoOperations(client) {
+		if err := call(context.Background()); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+}
+
+func TestDemoSQSClient_ErrorRateIsSeeded(t *testing.T) {
+	t.Setenv("DEMO_ERROR_RATE", "0.5")
+	t.Setenv("DEMO_RANDOM_SEED", "42")
+
+	outcomes := func() []bool {
+		client := NewDemoSQSClient()
+		var failed []bool
+		for i := 0; i < 32; i++ {
+			_, err := client.ListQueues(context.Background(), &sqs.ListQueuesInput{})
+			failed = append(failed, err != nil)
+		}
+		return failed
+	}
+
+	first, second := outcomes(), outcomes()
+	failures := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("expected the same outcomes under a fixed seed, call %d differed", i)
+		}
+		if first[i] {
+			failures++
+		}
+	}
+	if failures == 0 || failures == len(first) {
+		t.Errorf("expected a mix of failures at rate 0.5, got %d of %d", failures, len(first))
+	}
+}
+
+func TestDemoSQSClient_Latency(t *testing.T) {
+	t.Setenv("DEMO_LATENCY_MS", "50")
+	client := NewDemoSQSClient()
+
+	start := time.Now()
+	if _, err := client.GetQueueAttributes(context.Background(), &sqs.GetQueueAttributesInput{QueueUrl: aws.String(faultTestQueueURL)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the call to take at least 50ms, took %v", elapsed)
+	}
+
+	// A cancelled request gives up on the injected delay
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.ListQueues(ctx, &sqs.ListQueuesInput{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
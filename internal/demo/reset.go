@@ -0,0 +1,25 @@
+package demo
+
+import "log"
+
+// Reset restores the queues, messages and tags the client was created with,
+// discarding everything sent, deleted or in flight since. Message IDs keep
+// counting up, so IDs and receipt handles from before the reset are never
+// handed out again for different messages.
+func (d *DemoSQSClient) Reset() {
+	// Build the seeded state outside the lock; seeding can be slow for
+	// large synthetic volumes
+	fresh := d.seed()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.queues = fresh.queues
+	d.messages = fresh.messages
+	d.fifoDedup = fresh.fifoDedup
+	d.tags = fresh.tags
+	// queueAttributes never changes after construction, so the reseeded copy
+	// is identical and the existing map is kept
+
+	log.Printf("Demo: Reset to %d seeded queues", len(d.queues))
+}
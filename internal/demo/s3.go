@@ -0,0 +1,39 @@
+package demo
+
+import (
+	"context"
+	"log"
+	"sync"
+)
+
+// DemoS3Client is an in-memory object store standing in for S3 in demo mode.
+type DemoS3Client struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// NewDemoS3Client creates an empty in-memory object store.
+func NewDemoS3Client() *DemoS3Client {
+	return &DemoS3Client{
+		objects: make(map[string][]byte),
+	}
+}
+
+// PutObject stores body under bucket/key.
+func (d *DemoS3Client) PutObject(ctx context.Context, bucket, key string, body []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	log.Printf("Demo: PutObject s3://%s/%s (%d bytes)", bucket, key, len(body))
+	d.objects[bucket+"/"+key] = append([]byte(nil), body...)
+	return nil
+}
+
+// Object returns the stored object at bucket/key, if any.
+func (d *DemoS3Client) Object(bucket, key string) ([]byte, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	body, ok := d.objects[bucket+"/"+key]
+	return body, ok
+}
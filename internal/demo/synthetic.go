@@ -0,0 +1,123 @@
+package demo
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// defaultSyntheticQueueCount is the queue count used when only
+	// DEMO_MESSAGES_PER_QUEUE is set.
+	defaultSyntheticQueueCount = 10
+	// defaultSyntheticMessagesPerQueue is the per-queue volume used when only
+	// DEMO_QUEUE_COUNT is set.
+	defaultSyntheticMessagesPerQueue = 100
+	// syntheticMessageSpread is how far back synthetic sent timestamps reach.
+	syntheticMessageSpread = 24 * time.Hour
+)
+
+var (
+	syntheticPriorities = []string{"high", "normal", "low"}
+	syntheticSources    = []string{"web-app", "mobile-app", "batch-job", "partner-api"}
+	syntheticStatuses   = []string{"pending", "processing", "completed", "failed"}
+)
+
+// syntheticVolumeFromEnv reads DEMO_QUEUE_COUNT and DEMO_MESSAGES_PER_QUEUE,
+// logging and ignoring invalid values. ok is false when neither is set, in
+// which case the curated demo data is used.
+func syntheticVolumeFromEnv() (queueCount, messagesPerQueue int, ok bool) {
+	queueCount, messagesPerQueue = defaultSyntheticQueueCount, defaultSyntheticMessagesPerQueue
+	for _, setting := range []struct {
+		name   string
+		target *int
+	}{
+		{"DEMO_QUEUE_COUNT", &queueCount},
+		{"DEMO_MESSAGES_PER_QUEUE", &messagesPerQueue},
+	} {
+		value := os.Getenv(setting.name)
+		if value == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			*setting.target = n
+			ok = true
+		} else {
+			log.Printf("Invalid %s %q, ignoring it", setting.name, value)
+		}
+	}
+	return queueCount, messagesPerQueue, ok
+}
+
+// newSyntheticDemoSQSClient creates a demo client with queueCount generated
+// standard queues of messagesPerQueue messages each, for load-testing
+// paging, streaming and list rendering. Every queue matches the default tag
+// filter; messages are spread over the last day and cycle through a few
+// attribute values. The data is deterministic.
+func newSyntheticDemoSQSClient(queueCount, messagesPerQueue int) *DemoSQSClient {
+	demo := &DemoSQSClient{
+		messages:  make(map[string][]types.Message, queueCount),
+		fifoDedup: make(map[string]map[string]fifoDedupEntry),
+		now:       time.Now,
+		tags:      make(map[string]map[string]string, queueCount),
+		faults:    faultInjectorFromEnv(),
+		seed: func() *DemoSQSClient {
+			return newSyntheticDemoSQSClient(queueCount, messagesPerQueue)
+		},
+	}
+
+	now := time.Now()
+	step := syntheticMessageSpread / time.Duration(messagesPerQueue)
+	for q := 1; q <= queueCount; q++ {
+		queueURL := fmt.Sprintf("https://sqs.us-east-1.amazonaws.com/123456789012/demo-synthetic-%04d-queue", q)
+		demo.queues = append(demo.queues, queueURL)
+		demo.SetQueueTags(queueURL, map[string]string{
+			"businessunit": "degrees", "product": "amt", "env": "stg",
+		})
+
+		messages := make([]types.Message, messagesPerQueue)
+		for m := range messages {
+			messageID := fmt.Sprintf("syn-%04d-%06d", q, m+1)
+			// Newest first, like the curated queues
+			sent := now.Add(-time.Duration(m+1) * step)
+			receiveCount := m % 4
+			attributes := map[string]string{
+				"SentTimestamp":           fmt.Sprintf("%d", sent.UnixMilli()),
+				"ApproximateReceiveCount": strconv.Itoa(receiveCount),
+			}
+			if receiveCount > 0 {
+				attributes["ApproximateFirstReceiveTimestamp"] = fmt.Sprintf("%d", sent.Add(time.Duration(m%60+1)*time.Second).UnixMilli())
+			}
+
+			messages[m] = types.Message{
+				MessageId:     aws.String(messageID),
+				Body:          aws.String(fmt.Sprintf(`{"queue": %d, "sequence": %d, "status": %q}`, q, m+1, syntheticStatuses[m%len(syntheticStatuses)])),
+				ReceiptHandle: aws.String("receipt-" + messageID),
+				Attributes:    attributes,
+				MessageAttributes: map[string]types.MessageAttributeValue{
+					"Priority": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(syntheticPriorities[m%len(syntheticPriorities)]),
+					},
+					"Source": {
+						DataType:    aws.String("String"),
+						StringValue: aws.String(syntheticSources[(q+m)%len(syntheticSources)]),
+					},
+					"Sequence": {
+						DataType:    aws.String("Number"),
+						StringValue: aws.String(strconv.Itoa(m + 1)),
+					},
+				},
+			}
+		}
+		demo.messages[queueURL] = messages
+	}
+
+	log.Printf("Demo mode: generated %d synthetic queues of %d messages", queueCount, messagesPerQueue)
+	return demo
+}
@@ -0,0 +1,107 @@
+package demo
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+func TestNewDemoSQSClient_SyntheticVolume(t *testing.T) {
+	tests := []struct {
+		name             string
+		queueCount       string
+		messagesPerQueue string
+		expectedQueues   int
+		expectedMessages int
+	}{
+		{"both set", "25", "250", 25, 250},
+		{"queue count only", "3", "", 3, defaultSyntheticMessagesPerQueue},
+		{"messages only", "", "7", defaultSyntheticQueueCount, 7},
+		{"invalid count ignored", "zero", "5", defaultSyntheticQueueCount, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DEMO_QUEUE_COUNT", tt.queueCount)
+			t.Setenv("DEMO_MESSAGES_PER_QUEUE", tt.messagesPerQueue)
+			client := NewDemoSQSClient()
+			ctx := context.Background()
+
+			listed, err := client.ListQueues(ctx, &sqs.ListQueuesInput{MaxResults: aws.Int32(1000)})
+			if err != nil {
+				t.Fatalf("ListQueues failed: %v", err)
+			}
+			if len(listed.QueueUrls) != tt.expectedQueues {
+				t.Fatalf("expected %d queues, got %d", tt.expectedQueues, len(listed.QueueUrls))
+			}
+
+			for _, queueURL := range listed.QueueUrls {
+				attrs, err := client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+					QueueUrl:       aws.String(queueURL),
+					AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+				})
+				if err != nil {
+					t.Fatalf("GetQueueAttributes failed for %s: %v", queueURL, err)
+				}
+				if got := attrs.Attributes["ApproximateNumberOfMessages"]; got != strconv.Itoa(tt.expectedMessages) {
+					t.Errorf("expected %d messages in %s, got %s", tt.expectedMessages, queueURL, got)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDemoSQSClient_SyntheticMessagesVary(t *testing.T) {
+	t.Setenv("DEMO_QUEUE_COUNT", "1")
+	t.Setenv("DEMO_MESSAGES_PER_QUEUE", "12")
+	client := NewDemoSQSClient()
+
+	messages := client.messages[client.queues[0]]
+	timestamps := make(map[string]bool)
+	priorities := make(map[string]bool)
+	for _, msg := range messages {
+		timestamps[msg.Attributes["SentTimestamp"]] = true
+		priorities[aws.ToString(msg.MessageAttributes["Priority"].StringValue)] = true
+	}
+	if len(timestamps) != len(messages) {
+		t.Errorf("expected %d distinct sent timestamps, got %d", len(messages), len(timestamps))
+	}
+	if len(priorities) != len(syntheticPriorities) {
+		t.Errorf("expected every priority to appear, got %v", priorities)
+	}
+}
+
+func TestNewDemoSQSClient_CuratedByDefault(t *testing.T) {
+	t.Setenv("DEMO_QUEUE_COUNT", "")
+	t.Setenv("DEMO_MESSAGES_PER_QUEUE", "")
+	client := NewDemoSQSClient()
+	if len(client.queues) != 7 || client.queues[0] != faultTestQueueURL {
+		t.Errorf("expected the curated demo queues, got %v", client.queues)
+	}
+}
+
+func TestDemoSQSClient_ResetSynthetic(t *testing.T) {
+	t.Setenv("DEMO_QUEUE_COUNT", "2")
+	t.Setenv("DEMO_MESSAGES_PER_QUEUE", "5")
+	client := NewDemoSQSClient()
+	queueURL := client.queues[0]
+
+	if _, err := client.DeleteMessage(context.Background(), &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: client.messages[queueURL][0].ReceiptHandle,
+	}); err != nil {
+		t.Fatalf("DeleteMessage failed: %v", err)
+	}
+
+	// The env no longer matters: the client resets to what it was created with
+	t.Setenv("DEMO_QUEUE_COUNT", "")
+	t.Setenv("DEMO_MESSAGES_PER_QUEUE", "")
+	client.Reset()
+	if len(client.queues) != 2 || len(client.messages[queueURL]) != 5 {
+		t.Errorf("expected 2 synthetic queues of 5 messages after reset, got %d queues and %d messages", len(client.queues), len(client.messages[queueURL]))
+	}
+}
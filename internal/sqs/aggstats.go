@@ -0,0 +1,183 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+const (
+	// defaultStatisticsTimeout bounds how long GetAggregateStatistics gathers
+	// queue attributes before answering with what it has.
+	defaultStatisticsTimeout = 10 * time.Second
+	// defaultStatisticsMaxQueues caps the queues GetAggregateStatistics
+	// aggregates.
+	defaultStatisticsMaxQueues = 100
+)
+
+// statisticsTimeoutFromEnv reads STATISTICS_TIMEOUT_MS, falling back to the
+// default for missing or non-positive values.
+func statisticsTimeoutFromEnv() time.Duration {
+	if n, err := strconv.Atoi(os.Getenv("STATISTICS_TIMEOUT_MS")); err == nil && n > 0 {
+		return time.Duration(n) * time.Millisecond
+	}
+	return defaultStatisticsTimeout
+}
+
+// statisticsMaxQueuesFromEnv reads STATISTICS_MAX_QUEUES, falling back to the
+// default for missing or non-positive values.
+func statisticsMaxQueuesFromEnv() int {
+	if n, err := strconv.Atoi(os.Getenv("STATISTICS_MAX_QUEUES")); err == nil && n > 0 {
+		return n
+	}
+	return defaultStatisticsMaxQueues
+}
+
+// AggregateStatistics totals the depth of the queues gathered by
+// GetAggregateStatistics. Partial is set when the queue cap or the deadline
+// left queues out; Queues counts the queues included.
+type AggregateStatistics struct {
+	Queues           int  `json:"queues"`
+	FIFOQueues       int  `json:"fifoQueues"`
+	DeadLetterQueues int  `json:"deadLetterQueues"`
+	TotalMessages    int  `json:"totalMessages"`
+	MessagesInFlight int  `json:"messagesInFlight"`
+	MessagesDelayed  int  `json:"messagesDelayed"`
+	Partial          bool `json:"partial"`
+	Capped           bool `json:"capped,omitempty"`
+	TimedOut         bool `json:"timedOut,omitempty"`
+	// Failed counts queues whose tags or attributes could not be read
+	Failed int `json:"failed"`
+}
+
+// queueStatisticsResult is one queue's contribution to the aggregate; skip is
+// set for queues the tag filter excludes or that no longer exist.
+type queueStatisticsResult struct {
+	queueURL   string
+	attributes map[string]string
+	skip       bool
+	err        error
+}
+
+// GetAggregateStatistics handles HTTP requests for depth totals across the
+// tag-filtered queues. At most STATISTICS_MAX_QUEUES queues are aggregated
+// (?maxQueues= may lower it), and gathering stops at the STATISTICS_TIMEOUT_MS
+// deadline (?timeoutMs= may shorten it) or when the client goes away; either
+// way the queues gathered so far are returned with "partial": true rather
+// than failing the request.
+func (h *SQSHandler) GetAggregateStatistics(w http.ResponseWriter, r *http.Request) {
+	timeout := statisticsTimeoutFromEnv()
+	maxQueues := statisticsMaxQueuesFromEnv()
+	for _, param := range []struct {
+		name  string
+		apply func(int)
+	}{
+		{"timeoutMs", func(n int) { timeout = min(timeout, time.Duration(n)*time.Millisecond) }},
+		{"maxQueues", func(n int) { maxQueues = min(maxQueues, n) }},
+	} {
+		if value := r.URL.Query().Get(param.name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				http.Error(w, fmt.Sprintf("%s must be a positive integer", param.name), http.StatusBadRequest)
+				return
+			}
+			param.apply(n)
+		}
+	}
+
+	disableTagFilter, requiredTags, err := tagFilterForRequest(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The deadline covers listing too; the request context ends it early
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{MaxResults: aws.Int32(1000)})
+	if err != nil {
+		log.Printf("GetAggregateStatistics: Error listing queues: %v", err)
+		writeSQSError(w, r, err, "")
+		return
+	}
+	setRequestID(w, result.ResultMetadata)
+
+	var stats AggregateStatistics
+	queueURLs := result.QueueUrls
+	if len(queueURLs) > maxQueues {
+		queueURLs = queueURLs[:maxQueues]
+		stats.Capped = true
+	}
+
+	// Buffered so workers finishing after the deadline never block
+	results := make(chan queueStatisticsResult, len(queueURLs))
+	for _, queueURL := range queueURLs {
+		go func(queueURL string) {
+			results <- h.gatherQueueStatistics(ctx, queueURL, disableTagFilter, requiredTags)
+		}(queueURL)
+	}
+
+gather:
+	for pending := len(queueURLs); pending > 0; pending-- {
+		select {
+		case res := <-results:
+			switch {
+			case res.skip:
+			case res.err != nil:
+				debugf("GetAggregateStatistics: Error reading queue %s: %v", res.queueURL, res.err)
+				stats.Failed++
+			default:
+				stats.add(res.queueURL, res.attributes)
+			}
+		case <-ctx.Done():
+			stats.TimedOut = true
+			break gather
+		}
+	}
+
+	stats.Partial = stats.Capped || stats.TimedOut
+	log.Printf("GetAggregateStatistics: Aggregated %d of %d queues (partial: %v)", stats.Queues, len(result.QueueUrls), stats.Partial)
+	writeJSON(w, r, stats)
+}
+
+// gatherQueueStatistics applies the tag filter to one queue and reads its
+// attributes.
+func (h *SQSHandler) gatherQueueStatistics(ctx context.Context, queueURL string, disableTagFilter bool, requiredTags map[string][]string) queueStatisticsResult {
+	if !disableTagFilter {
+		tags, err := h.listQueueTags(ctx, queueURL)
+		if err != nil {
+			return queueStatisticsResult{queueURL: queueURL, skip: isQueueNotFound(err), err: err}
+		}
+		if !matchesRequiredTags(queueURL, tags, requiredTags) {
+			return queueStatisticsResult{queueURL: queueURL, skip: true}
+		}
+	}
+
+	attributes, err := h.queueAttributes(ctx, queueURL)
+	if err != nil {
+		return queueStatisticsResult{queueURL: queueURL, skip: isQueueNotFound(err), err: err}
+	}
+	return queueStatisticsResult{queueURL: queueURL, attributes: attributes}
+}
+
+// add counts one queue into the totals.
+func (s *AggregateStatistics) add(queueURL string, attributes map[string]string) {
+	s.Queues++
+	if isFIFOQueue(queueURL) {
+		s.FIFOQueues++
+	}
+	if isDeadLetterQueue(queueNameFromURL(queueURL), attributes) {
+		s.DeadLetterQueues++
+	}
+	s.TotalMessages += parseIntSafe(attributes["ApproximateNumberOfMessages"])
+	s.MessagesInFlight += parseIntSafe(attributes["ApproximateNumberOfMessagesNotVisible"])
+	s.MessagesDelayed += parseIntSafe(attributes["ApproximateNumberOfMessagesDelayed"])
+}
@@ -0,0 +1,124 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+// latencyClient delays GetQueueAttributes per queue, giving up when the
+// caller's context ends as the SDK does.
+type latencyClient struct {
+	*helpers.MockSQSClient
+	delays map[string]time.Duration
+}
+
+func (c *latencyClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	select {
+	case <-time.After(c.delays[*params.QueueUrl]):
+		return c.MockSQSClient.GetQueueAttributes(ctx, params, optFns...)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestSQSHandler_GetAggregateStatistics(t *testing.T) {
+	t.Setenv("DISABLE_TAG_FILTER", "true")
+
+	const base = "https://sqs.us-east-1.amazonaws.com/123456789012/"
+	newHandler := func(slowDelay time.Duration) *SQSHandler {
+		mock := helpers.NewMockSQSClient()
+		for _, name := range []string{"orders-queue", "events.fifo", "slow-queue"} {
+			mock.AddQueue(base + name)
+		}
+		return &SQSHandler{Client: &latencyClient{
+			MockSQSClient: mock,
+			delays:        map[string]time.Duration{base + "slow-queue": slowDelay},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		slowDelay time.Duration
+		query     string
+		expected  AggregateStatistics
+	}{
+		{
+			name:     "all queues within the deadline",
+			query:    "?timeoutMs=2000",
+			expected: AggregateStatistics{Queues: 3, FIFOQueues: 1, TotalMessages: 15},
+		},
+		{
+			name:      "deadline leaves the slow queue out",
+			slowDelay: 5 * time.Second,
+			query:     "?timeoutMs=200",
+			expected:  AggregateStatistics{Queues: 2, FIFOQueues: 1, TotalMessages: 10, Partial: true, TimedOut: true},
+		},
+		{
+			name:     "capped",
+			query:    "?maxQueues=2",
+			expected: AggregateStatistics{Queues: 2, FIFOQueues: 1, TotalMessages: 10, Partial: true, Capped: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			rr := httptest.NewRecorder()
+			newHandler(tt.slowDelay).GetAggregateStatistics(rr, httptest.NewRequest("GET", "/api/statistics"+tt.query, nil))
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if elapsed := time.Since(start); elapsed > 2*time.Second {
+				t.Errorf("expected an answer by the deadline, took %v", elapsed)
+			}
+			var stats AggregateStatistics
+			if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if stats != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, stats)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetAggregateStatistics_RequestCancelled(t *testing.T) {
+	t.Setenv("DISABLE_TAG_FILTER", "true")
+
+	const slowURL = "https://sqs.us-east-1.amazonaws.com/123456789012/slow-queue"
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue(slowURL)
+	handler := &SQSHandler{Client: &latencyClient{MockSQSClient: mock, delays: map[string]time.Duration{slowURL: 5 * time.Second}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	rr := httptest.NewRecorder()
+	handler.GetAggregateStatistics(rr, httptest.NewRequest("GET", "/api/statistics", nil).WithContext(ctx))
+
+	var stats AggregateStatistics
+	if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if !stats.Partial || !stats.TimedOut || stats.Queues != 0 {
+		t.Errorf("expected an empty partial result once the request was cancelled, got %+v", stats)
+	}
+}
+
+func TestSQSHandler_GetAggregateStatistics_InvalidParams(t *testing.T) {
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+	for _, query := range []string{"?timeoutMs=0", "?maxQueues=abc"} {
+		rr := httptest.NewRecorder()
+		handler.GetAggregateStatistics(rr, httptest.NewRequest("GET", "/api/statistics"+query, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, rr.Code)
+		}
+	}
+}
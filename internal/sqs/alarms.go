@@ -0,0 +1,319 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultAlarmSampleInterval is how often the sampler checks alarms when
+	// ALARM_SAMPLE_INTERVAL_SECONDS is not set.
+	defaultAlarmSampleInterval = 30 * time.Second
+	// alarmWebhookTimeout bounds each webhook POST from the default client.
+	alarmWebhookTimeout = 5 * time.Second
+)
+
+// Alarm metrics: the visible backlog or the in-flight count.
+const (
+	alarmMetricMessages = "messages"
+	alarmMetricInFlight = "inFlight"
+)
+
+// Alarm states, reported in webhook payloads.
+const (
+	alarmStateOK    = "ok"
+	alarmStateAlarm = "alarm"
+)
+
+// Alarm is a depth threshold on one queue. It enters the alarm state when the
+// metric reaches Threshold and only returns to ok once the metric drops below
+// Threshold minus a hysteresis band, so a depth hovering at the threshold
+// doesn't flap. Each transition POSTs to WebhookURL.
+type Alarm struct {
+	ID         string `json:"id"`
+	QueueURL   string `json:"queueUrl"`
+	Metric     string `json:"metric"`
+	Threshold  int    `json:"threshold"`
+	WebhookURL string `json:"webhookUrl"`
+	State      string `json:"state"`
+
+	// seq orders alarms by registration
+	seq int
+}
+
+// clearBelow is the metric value the alarm must fall under to return to ok:
+// the threshold less 10% (at least 1), but never below 1 so that an empty
+// queue always clears.
+func (a Alarm) clearBelow() int {
+	return max(a.Threshold-max(a.Threshold/10, 1), 1)
+}
+
+// value picks the alarm's metric out of a depth sample.
+func (a Alarm) value(sample queueDepthSample) int {
+	if a.Metric == alarmMetricInFlight {
+		return sample.InFlight
+	}
+	return sample.Visible
+}
+
+// alarmWebhookPayload is the JSON body POSTed on each state change.
+type alarmWebhookPayload struct {
+	AlarmID   string `json:"alarmId"`
+	QueueURL  string `json:"queueUrl"`
+	Metric    string `json:"metric"`
+	Threshold int    `json:"threshold"`
+	Value     int    `json:"value"`
+	State     string `json:"state"`
+	Timestamp string `json:"timestamp"`
+}
+
+// alarmRegistry holds registered alarms in memory. The zero value is ready to
+// use; client may be replaced (e.g. in tests) before alarms are evaluated.
+type alarmRegistry struct {
+	mu     sync.Mutex
+	alarms map[string]*Alarm
+	nextID int
+	client *http.Client
+}
+
+// add registers an alarm in the ok state and returns a copy of it.
+func (r *alarmRegistry) add(alarm Alarm) Alarm {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.alarms == nil {
+		r.alarms = make(map[string]*Alarm)
+	}
+	r.nextID++
+	alarm.ID = fmt.Sprintf("alarm-%d", r.nextID)
+	alarm.seq = r.nextID
+	alarm.State = alarmStateOK
+	r.alarms[alarm.ID] = &alarm
+	return alarm
+}
+
+// list returns copies of the alarms on queueURL (every alarm when empty),
+// ordered by creation.
+func (r *alarmRegistry) list(queueURL string) []Alarm {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alarms := []Alarm{}
+	for _, alarm := range r.alarms {
+		if queueURL == "" || alarm.QueueURL == queueURL {
+			alarms = append(alarms, *alarm)
+		}
+	}
+	sort.Slice(alarms, func(i, j int) bool { return alarms[i].seq < alarms[j].seq })
+	return alarms
+}
+
+// removeQueue drops every alarm on queueURL, returning how many there were.
+func (r *alarmRegistry) removeQueue(queueURL string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	removed := 0
+	for id, alarm := range r.alarms {
+		if alarm.QueueURL == queueURL {
+			delete(r.alarms, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// observe records a metric value for an alarm, returning the new state when
+// it changed.
+func (r *alarmRegistry) observe(id string, value int) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alarm, ok := r.alarms[id]
+	if !ok {
+		return "", false
+	}
+	switch {
+	case alarm.State == alarmStateOK && value >= alarm.Threshold:
+		alarm.State = alarmStateAlarm
+	case alarm.State == alarmStateAlarm && value < alarm.clearBelow():
+		alarm.State = alarmStateOK
+	default:
+		return "", false
+	}
+	return alarm.State, true
+}
+
+// httpClient returns the client used for webhook POSTs.
+func (r *alarmRegistry) httpClient() *http.Client {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client == nil {
+		r.client = &http.Client{Timeout: alarmWebhookTimeout}
+	}
+	return r.client
+}
+
+// AlarmSampleInterval returns ALARM_SAMPLE_INTERVAL_SECONDS, falling back to
+// defaultAlarmSampleInterval when unset or invalid.
+func AlarmSampleInterval() time.Duration {
+	if value := os.Getenv("ALARM_SAMPLE_INTERVAL_SECONDS"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		log.Printf("Invalid ALARM_SAMPLE_INTERVAL_SECONDS %q, using %s", value, defaultAlarmSampleInterval)
+	}
+	return defaultAlarmSampleInterval
+}
+
+// CreateAlarm handles HTTP requests to register a depth alarm on a queue:
+// {"metric": "messages"|"inFlight", "threshold": N, "webhookUrl": "..."}.
+// Alarms live in memory and are lost on restart.
+func (h *SQSHandler) CreateAlarm(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var payload struct {
+		Metric     string `json:"metric"`
+		Threshold  int    `json:"threshold"`
+		WebhookURL string `json:"webhookUrl"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if payload.Metric == "" {
+		payload.Metric = alarmMetricMessages
+	}
+	if payload.Metric != alarmMetricMessages && payload.Metric != alarmMetricInFlight {
+		http.Error(w, fmt.Sprintf("metric must be %q or %q", alarmMetricMessages, alarmMetricInFlight), http.StatusBadRequest)
+		return
+	}
+	if payload.Threshold <= 0 {
+		http.Error(w, "threshold must be a positive integer", http.StatusBadRequest)
+		return
+	}
+	webhook, err := url.Parse(payload.WebhookURL)
+	if err != nil || (webhook.Scheme != "http" && webhook.Scheme != "https") || webhook.Host == "" {
+		http.Error(w, "webhookUrl must be an absolute http(s) URL", http.StatusBadRequest)
+		return
+	}
+
+	alarm := h.alarms.add(Alarm{
+		QueueURL:   queueURL,
+		Metric:     payload.Metric,
+		Threshold:  payload.Threshold,
+		WebhookURL: payload.WebhookURL,
+	})
+
+	log.Printf("CreateAlarm: Registered %s on queue %s (%s >= %d)", alarm.ID, queueURL, alarm.Metric, alarm.Threshold)
+
+	writeJSONStatus(w, r, http.StatusCreated, alarm)
+}
+
+// ListAlarms handles HTTP requests to list the alarms registered on a queue.
+func (h *SQSHandler) ListAlarms(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, r, h.alarms.list(queueURL))
+}
+
+// RunAlarmSampler evaluates alarms every interval until ctx is cancelled.
+func (h *SQSHandler) RunAlarmSampler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.EvaluateAlarms(ctx)
+		}
+	}
+}
+
+// EvaluateAlarms samples each alarmed queue once and POSTs to the webhook of
+// every alarm whose state changed. Queues that can't be sampled are skipped
+// until the next round.
+func (h *SQSHandler) EvaluateAlarms(ctx context.Context) {
+	samples := make(map[string]queueDepthSample)
+	unavailable := make(map[string]bool)
+
+	for _, alarm := range h.alarms.list("") {
+		if unavailable[alarm.QueueURL] {
+			continue
+		}
+		sample, sampled := samples[alarm.QueueURL]
+		if !sampled {
+			var err error
+			if sample, err = h.sampleQueueDepth(ctx, alarm.QueueURL); err != nil {
+				log.Printf("Alarms: Error sampling queue %s: %v", alarm.QueueURL, err)
+				unavailable[alarm.QueueURL] = true
+				continue
+			}
+			samples[alarm.QueueURL] = sample
+		}
+
+		value := alarm.value(sample)
+		if state, changed := h.alarms.observe(alarm.ID, value); changed {
+			h.notifyAlarm(ctx, alarm, state, value)
+		}
+	}
+}
+
+// notifyAlarm POSTs a state change to the alarm's webhook. Failures are
+// logged; the state change stands, so a flaky webhook doesn't re-fire.
+func (h *SQSHandler) notifyAlarm(ctx context.Context, alarm Alarm, state string, value int) {
+	body, err := json.Marshal(alarmWebhookPayload{
+		AlarmID:   alarm.ID,
+		QueueURL:  alarm.QueueURL,
+		Metric:    alarm.Metric,
+		Threshold: alarm.Threshold,
+		Value:     value,
+		State:     state,
+		Timestamp: h.clock().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		log.Printf("Alarms: Error encoding webhook payload for %s: %v", alarm.ID, err)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, alarm.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Alarms: Error building webhook request for %s: %v", alarm.ID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.alarms.httpClient().Do(req)
+	if err != nil {
+		log.Printf("Alarms: Webhook for %s failed: %v", alarm.ID, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		log.Printf("Alarms: Webhook for %s returned %s", alarm.ID, resp.Status)
+		return
+	}
+	log.Printf("Alarms: %s on queue %s is now %s (%s = %d)", alarm.ID, alarm.QueueURL, state, alarm.Metric, value)
+}
@@ -0,0 +1,198 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func createAlarmReq(queueURL, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/alarms", strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+}
+
+func TestSQSHandler_EvaluateAlarms_Webhook(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	var mu sync.Mutex
+	var received []alarmWebhookPayload
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload alarmWebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode webhook payload: %v", err)
+		}
+		mu.Lock()
+		received = append(received, payload)
+		mu.Unlock()
+	}))
+	defer webhook.Close()
+
+	// Threshold 10 clears below 9: 12 alarms, 9 and 11 hold, 4 clears
+	client := &depthSequenceClient{
+		MockSQSClient: helpers.NewMockSQSClient(),
+		samples: []queueDepthSample{
+			{Visible: 5}, {Visible: 12}, {Visible: 9}, {Visible: 11}, {Visible: 4},
+		},
+	}
+	handler := &SQSHandler{Client: client}
+	handler.alarms.client = webhook.Client()
+
+	rr := httptest.NewRecorder()
+	handler.CreateAlarm(rr, createAlarmReq(queueURL, `{"metric":"messages","threshold":10,"webhookUrl":"`+webhook.URL+`"}`))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	expectedCalls := []int{0, 1, 1, 1, 2}
+	for round, expected := range expectedCalls {
+		handler.EvaluateAlarms(context.Background())
+
+		mu.Lock()
+		got := len(received)
+		mu.Unlock()
+		if got != expected {
+			t.Fatalf("round %d: expected %d webhook calls, got %d", round, expected, got)
+		}
+	}
+
+	if received[0].State != alarmStateAlarm || received[0].Value != 12 || received[0].QueueURL != queueURL {
+		t.Errorf("unexpected alarm payload: %+v", received[0])
+	}
+	if received[1].State != alarmStateOK || received[1].Value != 4 {
+		t.Errorf("unexpected clear payload: %+v", received[1])
+	}
+}
+
+func TestAlarm_ClearBelow(t *testing.T) {
+	tests := []struct {
+		threshold int
+		expected  int
+	}{
+		{1, 1},
+		{2, 1},
+		{10, 9},
+		{100, 90},
+	}
+
+	for _, tt := range tests {
+		if got := (Alarm{Threshold: tt.threshold}).clearBelow(); got != tt.expected {
+			t.Errorf("threshold %d: expected clearBelow %d, got %d", tt.threshold, tt.expected, got)
+		}
+	}
+}
+
+func TestSQSHandler_EvaluateAlarms_ThresholdOneClears(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer webhook.Close()
+
+	client := &depthSequenceClient{
+		MockSQSClient: helpers.NewMockSQSClient(),
+		samples:       []queueDepthSample{{Visible: 1}, {Visible: 0}},
+	}
+	handler := &SQSHandler{Client: client}
+	handler.alarms.client = webhook.Client()
+	handler.CreateAlarm(httptest.NewRecorder(), createAlarmReq(queueURL, `{"metric":"messages","threshold":1,"webhookUrl":"`+webhook.URL+`"}`))
+
+	for _, expected := range []string{alarmStateAlarm, alarmStateOK} {
+		handler.EvaluateAlarms(context.Background())
+		if alarms := handler.alarms.list(queueURL); len(alarms) != 1 || alarms[0].State != expected {
+			t.Fatalf("expected state %s, got %+v", expected, alarms)
+		}
+	}
+}
+
+func TestSQSHandler_EvaluateAlarms_SamplesQueueOnce(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer webhook.Close()
+
+	client := &depthSequenceClient{
+		MockSQSClient: helpers.NewMockSQSClient(),
+		samples:       []queueDepthSample{{Visible: 3, InFlight: 7}},
+	}
+	handler := &SQSHandler{Client: client}
+	handler.alarms.client = webhook.Client()
+
+	for _, body := range []string{
+		`{"metric":"messages","threshold":5,"webhookUrl":"` + webhook.URL + `"}`,
+		`{"metric":"inFlight","threshold":5,"webhookUrl":"` + webhook.URL + `"}`,
+	} {
+		handler.CreateAlarm(httptest.NewRecorder(), createAlarmReq(queueURL, body))
+	}
+
+	handler.EvaluateAlarms(context.Background())
+
+	if client.calls != 1 {
+		t.Errorf("expected one depth sample for the queue, got %d", client.calls)
+	}
+	alarms := handler.alarms.list(queueURL)
+	if len(alarms) != 2 || alarms[0].State != alarmStateOK || alarms[1].State != alarmStateAlarm {
+		t.Errorf("expected only the inFlight alarm to fire, got %+v", alarms)
+	}
+}
+
+func TestSQSHandler_CreateAlarm_Validation(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid json", `{`},
+		{"unknown metric", `{"metric":"age","threshold":5,"webhookUrl":"https://hooks.example.com/x"}`},
+		{"non-positive threshold", `{"threshold":0,"webhookUrl":"https://hooks.example.com/x"}`},
+		{"relative webhook", `{"threshold":5,"webhookUrl":"/hook"}`},
+		{"non-http webhook", `{"threshold":5,"webhookUrl":"file:///etc/passwd"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+			rr := httptest.NewRecorder()
+			handler.CreateAlarm(rr, createAlarmReq(queueURL, tt.body))
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", rr.Code)
+			}
+			if alarms := handler.alarms.list(""); len(alarms) != 0 {
+				t.Errorf("expected no alarms registered, got %+v", alarms)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_ListAlarms(t *testing.T) {
+	const ordersURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	const paymentsURL = "https://sqs.us-east-1.amazonaws.com/123456789012/payments"
+
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+	handler.CreateAlarm(httptest.NewRecorder(), createAlarmReq(ordersURL, `{"threshold":5,"webhookUrl":"https://hooks.example.com/a"}`))
+	handler.CreateAlarm(httptest.NewRecorder(), createAlarmReq(paymentsURL, `{"threshold":5,"webhookUrl":"https://hooks.example.com/b"}`))
+	handler.CreateAlarm(httptest.NewRecorder(), createAlarmReq(ordersURL, `{"threshold":50,"webhookUrl":"https://hooks.example.com/c"}`))
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/alarms", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": ordersURL})
+	rr := httptest.NewRecorder()
+	handler.ListAlarms(rr, req)
+
+	var alarms []Alarm
+	if err := json.NewDecoder(rr.Body).Decode(&alarms); err != nil {
+		t.Fatalf("failed to decode alarms: %v", err)
+	}
+	if len(alarms) != 2 || alarms[0].ID != "alarm-1" || alarms[1].ID != "alarm-3" {
+		t.Errorf("expected alarm-1 and alarm-3 for orders, got %+v", alarms)
+	}
+	if alarms[0].Metric != alarmMetricMessages || alarms[0].State != alarmStateOK {
+		t.Errorf("expected default metric and ok state, got %+v", alarms[0])
+	}
+}
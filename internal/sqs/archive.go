@@ -0,0 +1,112 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// S3ClientInterface defines the S3 operation needed to archive messages. Like
+// SQSClientInterface it keeps the handler testable: live mode uses an adapter
+// over the AWS S3 client (see newS3Client), demo mode an in-memory store.
+type S3ClientInterface interface {
+	PutObject(ctx context.Context, bucket, key string, body []byte) error
+}
+
+// archiveFailure reports a message that could not be archived.
+type archiveFailure struct {
+	MessageID string `json:"messageId"`
+	Error     string `json:"error"`
+}
+
+// ArchiveToS3 handles HTTP requests to drain a queue into S3, writing each
+// message as a JSON object under {prefix}{messageId}.json. The queue is
+// drained first, with DRAIN_CONCURRENCY receives in flight. With
+// deleteAfterArchive set, a message is deleted only after its object was
+// written successfully.
+func (h *SQSHandler) ArchiveToS3(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var payload struct {
+		Bucket             string `json:"bucket"`
+		Prefix             string `json:"prefix"`
+		DeleteAfterArchive bool   `json:"deleteAfterArchive"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(payload.Bucket) == "" {
+		http.Error(w, "bucket is required", http.StatusBadRequest)
+		return
+	}
+
+	if h.S3 == nil {
+		http.Error(w, "S3 archiving is not configured", http.StatusNotImplemented)
+		return
+	}
+
+	ctx := r.Context()
+	drained, err := h.drainMessages(ctx, queueURL, maxDrainMessages, drainConcurrencyFromEnv())
+	if err != nil {
+		log.Printf("ArchiveToS3: Error receiving from queue %s: %v", queueURL, err)
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+
+	archived, deleted := 0, 0
+	failed := []archiveFailure{}
+
+	// Messages that fail to archive stay on the queue and reappear once
+	// their visibility timeout ends.
+	for _, msg := range drained {
+		messageID := aws.ToString(msg.MessageId)
+
+		object, err := json.Marshal(ConvertMessage(msg))
+		if err == nil {
+			err = h.S3.PutObject(ctx, payload.Bucket, payload.Prefix+messageID+".json", object)
+		}
+		if err != nil {
+			log.Printf("ArchiveToS3: Failed to archive message %s: %v", messageID, err)
+			failed = append(failed, archiveFailure{MessageID: messageID, Error: err.Error()})
+			continue
+		}
+		archived++
+
+		if !payload.DeleteAfterArchive {
+			continue
+		}
+		if _, err := h.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(queueURL),
+			ReceiptHandle: msg.ReceiptHandle,
+		}); err != nil {
+			log.Printf("ArchiveToS3: Archived message %s but failed to delete it: %v", messageID, err)
+			failed = append(failed, archiveFailure{MessageID: messageID, Error: err.Error()})
+			continue
+		}
+		deleted++
+	}
+
+	log.Printf("ArchiveToS3: Archived %d messages from %s to s3://%s/%s (%d deleted, %d failed)", archived, queueURL, payload.Bucket, payload.Prefix, deleted, len(failed))
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:        statusArchived,
+		AffectedCount: affected(archived),
+		Details: map[string]interface{}{
+			"archived": archived,
+			"deleted":  deleted,
+			"failed":   failed,
+		},
+	})
+}
@@ -0,0 +1,141 @@
+package sqs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func archiveReq(queueURL, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/archive-to-s3", strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+}
+
+func TestSQSHandler_ArchiveToS3(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+	mockClient.AddMessage(queueURL, "msg-1", `{"orderId": "1"}`)
+	mockClient.AddMessage(queueURL, "msg-2", `{"orderId": "2"}`)
+	mockClient.AddMessage(queueURL, "msg-3", `{"orderId": "3"}`)
+
+	mockS3 := helpers.NewMockS3Client()
+	mockS3.SetKeyError("archive/2024/msg-2.json", errors.New("AccessDenied"))
+
+	handler := &SQSHandler{Client: mockClient, S3: mockS3}
+	rr := httptest.NewRecorder()
+	handler.ArchiveToS3(rr, archiveReq(queueURL, `{"bucket": "dlq-archive", "prefix": "archive/2024/", "deleteAfterArchive": true}`))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Status        string `json:"status"`
+		AffectedCount int    `json:"affectedCount"`
+		Details       struct {
+			Archived int              `json:"archived"`
+			Deleted  int              `json:"deleted"`
+			Failed   []archiveFailure `json:"failed"`
+		} `json:"details"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Status != "archived" || response.AffectedCount != 2 {
+		t.Errorf("expected status archived affecting 2, got %+v", response)
+	}
+	if response.Details.Archived != 2 || response.Details.Deleted != 2 {
+		t.Errorf("expected 2 archived and 2 deleted, got %+v", response.Details)
+	}
+	if len(response.Details.Failed) != 1 || response.Details.Failed[0].MessageID != "msg-2" {
+		t.Errorf("expected msg-2 to fail, got %+v", response.Details.Failed)
+	}
+
+	// Every drained message was offered to S3 as a JSON object
+	if len(mockS3.PutObjectCalls) != 3 {
+		t.Fatalf("expected 3 PutObject calls, got %d", len(mockS3.PutObjectCalls))
+	}
+	for _, call := range mockS3.PutObjectCalls {
+		if call.Bucket != "dlq-archive" {
+			t.Errorf("expected bucket dlq-archive, got %s", call.Bucket)
+		}
+		var archived types.Message
+		if err := json.Unmarshal(call.Body, &archived); err != nil {
+			t.Errorf("object %s is not a JSON message: %v", call.Key, err)
+			continue
+		}
+		if call.Key != "archive/2024/"+archived.MessageId+".json" {
+			t.Errorf("unexpected key %s for message %s", call.Key, archived.MessageId)
+		}
+	}
+
+	// Only successfully archived messages were deleted
+	deleted := map[string]bool{}
+	for _, call := range mockClient.DeleteMessageCalls {
+		deleted[call.ReceiptHandle] = true
+	}
+	if !deleted["receipt-msg-1"] || !deleted["receipt-msg-3"] {
+		t.Errorf("expected msg-1 and msg-3 to be deleted, got %v", deleted)
+	}
+	if deleted["receipt-msg-2"] {
+		t.Error("msg-2 must not be deleted after a failed put")
+	}
+}
+
+func TestSQSHandler_ArchiveToS3_KeepsMessagesByDefault(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddMessage(queueURL, "msg-1", "body")
+	mockS3 := helpers.NewMockS3Client()
+
+	handler := &SQSHandler{Client: mockClient, S3: mockS3}
+	rr := httptest.NewRecorder()
+	handler.ArchiveToS3(rr, archiveReq(queueURL, `{"bucket": "dlq-archive"}`))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	if len(mockS3.PutObjectCalls) != 1 {
+		t.Errorf("expected 1 PutObject call, got %d", len(mockS3.PutObjectCalls))
+	}
+	if len(mockClient.DeleteMessageCalls) != 0 {
+		t.Errorf("expected no deletes, got %d", len(mockClient.DeleteMessageCalls))
+	}
+}
+
+func TestSQSHandler_ArchiveToS3_Errors(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	tests := []struct {
+		name           string
+		s3             S3ClientInterface
+		body           string
+		expectedStatus int
+	}{
+		{"invalid body", helpers.NewMockS3Client(), "not json", http.StatusBadRequest},
+		{"missing bucket", helpers.NewMockS3Client(), `{"prefix": "x/"}`, http.StatusBadRequest},
+		{"archiving not configured", nil, `{"bucket": "dlq-archive"}`, http.StatusNotImplemented},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: helpers.NewMockSQSClient(), S3: tt.s3}
+			rr := httptest.NewRecorder()
+			handler.ArchiveToS3(rr, archiveReq(queueURL, tt.body))
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
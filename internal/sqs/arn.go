@@ -0,0 +1,153 @@
+package sqs
+
+import (
+	"container/list"
+	"os"
+	"strings"
+	"sync"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// queueIdentityCacheSize caps how many queues have their derived identity
+// cached at once.
+const queueIdentityCacheSize = 1024
+
+// queueARN holds the components of an SQS queue ARN
+// (arn:partition:sqs:region:account-id:queue-name).
+type queueARN struct {
+	Partition string
+	Region    string
+	AccountID string
+	Name      string
+}
+
+// parseQueueARN splits an SQS queue ARN into its components. It reports false
+// when the string is not a well-formed ARN.
+func parseQueueARN(arn string) (queueARN, bool) {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 || parts[0] != "arn" || parts[5] == "" {
+		return queueARN{}, false
+	}
+	return queueARN{
+		Partition: parts[1],
+		Region:    parts[3],
+		AccountID: parts[4],
+		Name:      parts[5],
+	}, true
+}
+
+// queueARNFieldsEnabled reports whether listed queues carry the fields
+// parsed from their ARN; QUEUE_ARN_FIELDS=false leaves them out.
+func queueARNFieldsEnabled() bool {
+	return os.Getenv("QUEUE_ARN_FIELDS") != "false"
+}
+
+// setQueueARNFields copies the region, account ID and name parsed from the
+// queue's ARN onto queue, when the ARN was valid and the fields are enabled.
+func setQueueARNFields(queue *internal_types.Queue, identity queueIdentity) {
+	if !identity.Valid || !queueARNFieldsEnabled() {
+		return
+	}
+	queue.Region = identity.Parsed.Region
+	queue.AccountID = identity.Parsed.AccountID
+	queue.QueueName = identity.Parsed.Name
+}
+
+// parseARN is the parser the identity cache calls on a miss. It is a variable
+// so tests can count how often parsing actually happens.
+var parseARN = parseQueueARN
+
+// queueIdentity is the display name and parsed ARN derived for a queue URL.
+type queueIdentity struct {
+	arn    string
+	Name   string
+	Parsed queueARN
+	Valid  bool
+}
+
+// queueIdentityCache is a small, concurrency-safe LRU of derived queue
+// identities keyed by queue URL, so frequently polled accounts don't re-parse
+// every ARN on every request.
+type queueIdentityCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type queueIdentityEntry struct {
+	queueURL string
+	identity queueIdentity
+}
+
+func newQueueIdentityCache(capacity int) *queueIdentityCache {
+	return &queueIdentityCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// queueIdentities is shared by all handlers.
+var queueIdentities = newQueueIdentityCache(queueIdentityCacheSize)
+
+// derive returns the identity for queueURL given its QueueArn attribute. A
+// cached entry is reused only while the ARN is unchanged. When the ARN is
+// missing or malformed the name falls back to the queue URL.
+func (c *queueIdentityCache) derive(queueURL, arn string) queueIdentity {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[queueURL]; ok {
+		entry := elem.Value.(*queueIdentityEntry)
+		if entry.identity.arn == arn {
+			c.order.MoveToFront(elem)
+			return entry.identity
+		}
+		c.order.Remove(elem)
+		delete(c.entries, queueURL)
+	}
+
+	identity := queueIdentity{arn: arn, Name: queueURL}
+	if parsed, ok := parseARN(arn); ok {
+		identity.Parsed = parsed
+		identity.Valid = true
+		identity.Name = parsed.Name
+	}
+
+	c.entries[queueURL] = c.order.PushFront(&queueIdentityEntry{queueURL: queueURL, identity: identity})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*queueIdentityEntry).queueURL)
+	}
+
+	return identity
+}
+
+// evict drops the cached identity for queueURL, e.g. once the queue is gone.
+func (c *queueIdentityCache) evict(queueURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[queueURL]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, queueURL)
+	}
+}
+
+// contains reports whether queueURL has a cached identity.
+func (c *queueIdentityCache) contains(queueURL string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.entries[queueURL]
+	return ok
+}
+
+// len returns the number of cached entries.
+func (c *queueIdentityCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
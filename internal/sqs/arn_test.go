@@ -0,0 +1,200 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestParseQueueARN(t *testing.T) {
+	parsed, ok := parseQueueARN("arn:aws:sqs:us-east-1:123456789012:orders-queue")
+	if !ok {
+		t.Fatal("expected a standard ARN to parse")
+	}
+	want := queueARN{Partition: "aws", Region: "us-east-1", AccountID: "123456789012", Name: "orders-queue"}
+	if parsed != want {
+		t.Errorf("expected %+v, got %+v", want, parsed)
+	}
+
+	for _, bad := range []string{"", "not-an-arn", "arn:aws:sqs:us-east-1:123456789012:"} {
+		if _, ok := parseQueueARN(bad); ok {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestSetQueueARNFields_Partitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		arn      string
+		expected internal_types.Queue
+	}{
+		{
+			name:     "standard",
+			arn:      "arn:aws:sqs:us-east-1:123456789012:orders-queue",
+			expected: internal_types.Queue{Region: "us-east-1", AccountID: "123456789012", QueueName: "orders-queue"},
+		},
+		{
+			name:     "GovCloud",
+			arn:      "arn:aws-us-gov:sqs:us-gov-west-1:210987654321:gov-orders.fifo",
+			expected: internal_types.Queue{Region: "us-gov-west-1", AccountID: "210987654321", QueueName: "gov-orders.fifo"},
+		},
+		{
+			name:     "China",
+			arn:      "arn:aws-cn:sqs:cn-north-1:111122223333:cn-orders",
+			expected: internal_types.Queue{Region: "cn-north-1", AccountID: "111122223333", QueueName: "cn-orders"},
+		},
+		{
+			name: "malformed",
+			arn:  "arn:aws-cn:sqs:cn-north-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queue internal_types.Queue
+			setQueueARNFields(&queue, newQueueIdentityCache(4).derive("https://example.com/queue", tt.arn))
+			if !reflect.DeepEqual(queue, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, queue)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_ListQueues_ARNFields(t *testing.T) {
+	t.Setenv("DISABLE_TAG_FILTER", "true")
+
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue("https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue")
+	handler := &SQSHandler{Client: mock}
+
+	list := func() []internal_types.Queue {
+		rr := httptest.NewRecorder()
+		handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var queues []internal_types.Queue
+		if err := json.Unmarshal(rr.Body.Bytes(), &queues); err != nil || len(queues) != 1 {
+			t.Fatalf("expected one queue, got %s (%v)", rr.Body.String(), err)
+		}
+		return queues
+	}
+
+	queue := list()[0]
+	if queue.Region != "us-east-1" || queue.AccountID != "123456789012" || queue.QueueName != "orders-queue" {
+		t.Errorf("expected parsed ARN fields, got %+v", queue)
+	}
+
+	t.Setenv("MASK_ACCOUNT_IDS", "true")
+	if queue := list()[0]; queue.AccountID != maskedAccountID {
+		t.Errorf("expected a masked account ID, got %q", queue.AccountID)
+	}
+
+	t.Setenv("QUEUE_ARN_FIELDS", "false")
+	if queue := list()[0]; queue.Region != "" || queue.AccountID != "" || queue.QueueName != "" {
+		t.Errorf("expected no ARN fields with QUEUE_ARN_FIELDS=false, got %+v", queue)
+	}
+}
+
+// countParses swaps parseARN for a counting wrapper for the duration of a test.
+func countParses(t *testing.T) *int {
+	t.Helper()
+	var mu sync.Mutex
+	calls := 0
+	original := parseARN
+	parseARN = func(arn string) (queueARN, bool) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return original(arn)
+	}
+	t.Cleanup(func() { parseARN = original })
+	return &calls
+}
+
+func TestQueueIdentityCache_Hit(t *testing.T) {
+	calls := countParses(t)
+	cache := newQueueIdentityCache(10)
+
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+	const arn = "arn:aws:sqs:us-east-1:123456789012:orders-queue"
+
+	first := cache.derive(queueURL, arn)
+	second := cache.derive(queueURL, arn)
+
+	if first.Name != "orders-queue" || second.Name != "orders-queue" {
+		t.Errorf("expected name orders-queue, got %q and %q", first.Name, second.Name)
+	}
+	if *calls != 1 {
+		t.Errorf("expected the second derive to hit the cache (1 parse), got %d parses", *calls)
+	}
+
+	// A changed ARN for the same URL must not serve the stale entry.
+	cache.derive(queueURL, "arn:aws:sqs:us-west-2:123456789012:orders-queue")
+	if *calls != 2 {
+		t.Errorf("expected a changed ARN to re-parse, got %d parses", *calls)
+	}
+}
+
+func TestQueueIdentityCache_FallsBackToURL(t *testing.T) {
+	cache := newQueueIdentityCache(10)
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	if got := cache.derive(queueURL, "").Name; got != queueURL {
+		t.Errorf("expected name to fall back to the URL, got %q", got)
+	}
+}
+
+func TestQueueIdentityCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	calls := countParses(t)
+	cache := newQueueIdentityCache(2)
+
+	derive := func(name string) {
+		cache.derive("https://sqs.us-east-1.amazonaws.com/123456789012/"+name, "arn:aws:sqs:us-east-1:123456789012:"+name)
+	}
+
+	derive("a")
+	derive("b")
+	derive("a") // a is now most recently used
+	derive("c") // evicts b
+
+	if got := cache.len(); got != 2 {
+		t.Errorf("expected cache capped at 2 entries, got %d", got)
+	}
+
+	*calls = 0
+	derive("a")
+	if *calls != 0 {
+		t.Errorf("expected a to still be cached, got %d parses", *calls)
+	}
+	derive("b")
+	if *calls != 1 {
+		t.Errorf("expected b to have been evicted, got %d parses", *calls)
+	}
+}
+
+func TestQueueIdentityCache_Concurrent(t *testing.T) {
+	cache := newQueueIdentityCache(8)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("queue-%d", i%16)
+			cache.derive("https://sqs.us-east-1.amazonaws.com/123456789012/"+name, "arn:aws:sqs:us-east-1:123456789012:"+name)
+		}(i)
+	}
+	wg.Wait()
+
+	if got := cache.len(); got > 8 {
+		t.Errorf("expected at most 8 entries, got %d", got)
+	}
+}
@@ -0,0 +1,30 @@
+package sqs
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// ParseAttributeNames validates requested message system attribute names for
+// ReceiveMessage. An empty list means All, the historical default. Names are
+// matched exactly, as SQS does.
+func ParseAttributeNames(names []string) ([]types.QueueAttributeName, error) {
+	if len(names) == 0 {
+		return []types.QueueAttributeName{types.QueueAttributeNameAll}, nil
+	}
+
+	valid := map[string]bool{string(types.QueueAttributeNameAll): true}
+	for _, name := range types.MessageSystemAttributeName("").Values() {
+		valid[string(name)] = true
+	}
+
+	parsed := make([]types.QueueAttributeName, 0, len(names))
+	for _, name := range names {
+		if !valid[name] {
+			return nil, fmt.Errorf("unknown attribute name %q", name)
+		}
+		parsed = append(parsed, types.QueueAttributeName(name))
+	}
+	return parsed, nil
+}
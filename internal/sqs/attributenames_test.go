@@ -0,0 +1,44 @@
+package sqs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAttributeNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []string
+		expected  string
+		expectErr bool
+	}{
+		{name: "empty defaults to all", expected: "All"},
+		{name: "explicit all", input: []string{"All"}, expected: "All"},
+		{name: "system attributes", input: []string{"SentTimestamp", "ApproximateReceiveCount"}, expected: "SentTimestamp,ApproximateReceiveCount"},
+		{name: "unknown name", input: []string{"SentTimestamp", "Bogus"}, expectErr: true},
+		{name: "case sensitive", input: []string{"senttimestamp"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseAttributeNames(tt.input)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", parsed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			names := []string{}
+			for _, name := range parsed {
+				names = append(names, string(name))
+			}
+			if got := strings.Join(names, ","); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
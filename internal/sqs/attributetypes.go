@@ -0,0 +1,110 @@
+package sqs
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// numberAttributePattern matches the decimal and exponent notation SQS
+// accepts for Number attributes.
+var numberAttributePattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?$`)
+
+// sendAttribute is a message attribute as given to SendMessage: either a
+// plain string, sent as a String attribute, or {"dataType", "value"} where
+// dataType is String, Number or Binary, optionally with a custom suffix such
+// as "Number.int". Binary values are base64.
+type sendAttribute struct {
+	DataType string `json:"dataType"`
+	Value    string `json:"value"`
+}
+
+// UnmarshalJSON accepts both the plain string and the typed object form.
+func (a *sendAttribute) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		a.DataType = stringAttributeType
+		return json.Unmarshal(data, &a.Value)
+	}
+
+	type typed sendAttribute
+	var value typed
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*a = sendAttribute(value)
+	if a.DataType == "" {
+		a.DataType = stringAttributeType
+	}
+	return nil
+}
+
+// attributeTypeError is an attribute whose value does not match its declared
+// data type.
+type attributeTypeError struct {
+	Attribute string
+	Reason    string
+}
+
+func (e *attributeTypeError) Error() string {
+	return fmt.Sprintf("attribute %q: %s", e.Attribute, e.Reason)
+}
+
+// typedMessageAttributes converts send attributes to SQS message attributes,
+// checking each value against its data type so AWS is never handed a value
+// it would reject. Binary values are decoded into BinaryValue. Attributes are
+// checked in name order, so the error names the first offending one.
+func typedMessageAttributes(attributes map[string]sendAttribute) (map[string]types.MessageAttributeValue, *attributeTypeError) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	converted := make(map[string]types.MessageAttributeValue, len(attributes))
+	for _, name := range names {
+		attribute := attributes[name]
+		value := types.MessageAttributeValue{DataType: aws.String(attribute.DataType)}
+
+		baseType, _, _ := strings.Cut(attribute.DataType, ".")
+		switch baseType {
+		case "String":
+			value.StringValue = aws.String(attribute.Value)
+		case "Number":
+			if !numberAttributePattern.MatchString(attribute.Value) {
+				return nil, &attributeTypeError{Attribute: name, Reason: fmt.Sprintf("value %q is not a number", attribute.Value)}
+			}
+			value.StringValue = aws.String(attribute.Value)
+		case "Binary":
+			decoded, err := base64.StdEncoding.DecodeString(attribute.Value)
+			if err != nil {
+				return nil, &attributeTypeError{Attribute: name, Reason: "value is not valid base64"}
+			}
+			value.BinaryValue = decoded
+		default:
+			return nil, &attributeTypeError{Attribute: name, Reason: fmt.Sprintf("unsupported data type %q (use String, Number or Binary)", attribute.DataType)}
+		}
+		converted[name] = value
+	}
+	return converted, nil
+}
+
+// writeAttributeTypeError answers 422 naming the attribute that failed type
+// validation.
+func writeAttributeTypeError(w http.ResponseWriter, r *http.Request, err *attributeTypeError) {
+	writeJSONStatus(w, r, http.StatusUnprocessableEntity, map[string]interface{}{
+		"error":     err.Error(),
+		"attribute": err.Attribute,
+	})
+}
@@ -0,0 +1,100 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_SendMessage_AttributeTypes(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name              string
+		attributes        string
+		expectedStatus    int
+		expectedAttribute string
+	}{
+		{
+			name:           "valid attributes of each type",
+			attributes:     `{"source": "web", "label": {"dataType": "String", "value": "a"}, "count": {"dataType": "Number", "value": "-1.5e3"}, "id": {"dataType": "Number.int", "value": "42"}, "blob": {"dataType": "Binary", "value": "aGVsbG8="}}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "invalid number",
+			attributes:        `{"count": {"dataType": "Number", "value": "twelve"}}`,
+			expectedStatus:    http.StatusUnprocessableEntity,
+			expectedAttribute: "count",
+		},
+		{
+			name:              "invalid binary",
+			attributes:        `{"blob": {"dataType": "Binary", "value": "not base64!"}}`,
+			expectedStatus:    http.StatusUnprocessableEntity,
+			expectedAttribute: "blob",
+		},
+		{
+			name:              "unknown data type",
+			attributes:        `{"flag": {"dataType": "Boolean", "value": "true"}}`,
+			expectedStatus:    http.StatusUnprocessableEntity,
+			expectedAttribute: "flag",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			handler := &SQSHandler{Client: mockClient}
+
+			body := `{"body": "hello", "attributes": ` + tt.attributes + `}`
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", strings.NewReader(body))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+
+			handler.SendMessage(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+
+			if tt.expectedStatus != http.StatusOK {
+				var response struct {
+					Attribute string `json:"attribute"`
+				}
+				if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if response.Attribute != tt.expectedAttribute {
+					t.Errorf("expected attribute %q, got %q", tt.expectedAttribute, response.Attribute)
+				}
+				if len(mockClient.SendMessageCalls) != 0 {
+					t.Errorf("expected no send, got %d", len(mockClient.SendMessageCalls))
+				}
+				return
+			}
+
+			if len(mockClient.SendMessageCalls) != 1 {
+				t.Fatalf("expected 1 send, got %d", len(mockClient.SendMessageCalls))
+			}
+			sent := mockClient.SendMessageCalls[0].MessageAttributes
+			if got := aws.ToString(sent["source"].DataType); got != "String" {
+				t.Errorf("plain attribute: expected String, got %q", got)
+			}
+			if got := aws.ToString(sent["count"].StringValue); got != "-1.5e3" {
+				t.Errorf("number attribute: expected -1.5e3, got %q", got)
+			}
+			if got := aws.ToString(sent["id"].DataType); got != "Number.int" {
+				t.Errorf("custom number type: expected Number.int, got %q", got)
+			}
+			blob := sent["blob"]
+			if string(blob.BinaryValue) != "hello" || blob.StringValue != nil {
+				t.Errorf("binary attribute: expected decoded BinaryValue, got %q / %v", blob.BinaryValue, blob.StringValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package sqs
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// filterByAttributePresence keeps messages carrying every name in hasAttrs
+// and none in missingAttrs, checking both system Attributes and
+// MessageAttributes regardless of value. received supplies the message
+// attributes, which the converted messages don't keep.
+func filterByAttributePresence(messages []internal_types.Message, received []types.Message, hasAttrs, missingAttrs []string) []internal_types.Message {
+	messageAttributes := make(map[string]map[string]types.MessageAttributeValue, len(received))
+	for _, msg := range received {
+		messageAttributes[aws.ToString(msg.MessageId)] = msg.MessageAttributes
+	}
+
+	hasAttribute := func(msg internal_types.Message, name string) bool {
+		if _, ok := msg.Attributes[name]; ok {
+			return true
+		}
+		_, ok := messageAttributes[msg.MessageId][name]
+		return ok
+	}
+
+	filtered := []internal_types.Message{}
+	for _, msg := range messages {
+		keep := true
+		for _, name := range hasAttrs {
+			keep = keep && hasAttribute(msg, name)
+		}
+		for _, name := range missingAttrs {
+			keep = keep && !hasAttribute(msg, name)
+		}
+		if keep {
+			filtered = append(filtered, msg)
+		}
+	}
+	return filtered
+}
+
+// filterByOriginalQueue keeps messages whose OriginalQueue message attribute,
+// set on dead-lettered messages by the app that moved them, names
+// originalQueue. Either side may be a queue name or URL.
+func filterByOriginalQueue(messages []internal_types.Message, received []types.Message, originalQueue string) []internal_types.Message {
+	want := queueNameFromURL(normalizeQueueURL(originalQueue))
+	originals := make(map[string]string, len(received))
+	for _, msg := range received {
+		if value, ok := msg.MessageAttributes["OriginalQueue"]; ok {
+			originals[aws.ToString(msg.MessageId)] = queueNameFromURL(aws.ToString(value.StringValue))
+		}
+	}
+
+	filtered := []internal_types.Message{}
+	for _, msg := range messages {
+		if original, ok := originals[msg.MessageId]; ok && original == want {
+			filtered = append(filtered, msg)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,113 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_GetMessages_AttributePresence(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "has message attribute", query: "hasAttr=Priority", expected: "ord-001,ord-002,ord-003"},
+		{name: "missing message attribute", query: "missingAttr=Priority", expected: "unprioritized"},
+		{name: "has system attribute", query: "hasAttr=SentTimestamp&missingAttr=Priority", expected: "unprioritized"},
+		{name: "combined with no match", query: "hasAttr=Priority&missingAttr=Priority", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := demo.NewDemoSQSClient()
+			if _, err := client.SendMessage(context.Background(), &awssqs.SendMessageInput{
+				QueueUrl:    aws.String(queueURL),
+				MessageBody: aws.String("unprioritized"),
+			}); err != nil {
+				t.Fatalf("failed to send: %v", err)
+			}
+
+			handler := &SQSHandler{Client: client, isDemo: true}
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?limit=10&"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			var messages []internal_types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			got := []string{}
+			for _, message := range messages {
+				if message.Body == "unprioritized" {
+					got = append(got, message.Body)
+				} else {
+					got = append(got, message.MessageId)
+				}
+			}
+			sort.Strings(got)
+			if joined := strings.Join(got, ","); joined != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, joined)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessages_OriginalQueue(t *testing.T) {
+	const dlqURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue"
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "by queue name", query: "originalQueue=demo-orders-queue", expected: "dlq-001"},
+		{name: "by queue URL", query: "originalQueue=https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue", expected: "dlq-001"},
+		{name: "combined with a presence filter", query: "originalQueue=demo-payments-queue&hasAttr=FailureCount", expected: "dlq-002"},
+		{name: "combined filters excluding all", query: "originalQueue=demo-orders-queue&missingAttr=ErrorType", expected: ""},
+		{name: "unknown source", query: "originalQueue=demo-analytics-queue", expected: ""},
+		{name: "no filter", query: "", expected: "dlq-001,dlq-002,dlq-003"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?limit=10&"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": dlqURL})
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			var messages []internal_types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			got := []string{}
+			for _, message := range messages {
+				got = append(got, message.MessageId)
+			}
+			sort.Strings(got)
+			if joined := strings.Join(got, ","); joined != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, joined)
+			}
+		})
+	}
+}
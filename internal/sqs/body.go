@@ -0,0 +1,69 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/gorilla/mux"
+)
+
+// GetMessageBody handles HTTP requests for a single message body, supporting
+// Range requests so the UI can fetch very large bodies in chunks. The message
+// is looked up once without being consumed; http.ServeContent answers with
+// Accept-Ranges, 206 + Content-Range for satisfiable ranges and 416 otherwise.
+//
+// ?consume=true makes this a destructive read for triage: once the message is
+// found and its body prepared, it is deleted via its receipt handle before the
+// body is written. If the delete fails nothing is returned, so the message is
+// never lost unseen. Consuming cannot be combined with a Range request.
+func (h *SQSHandler) GetMessageBody(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+	messageID := vars["messageId"]
+
+	consume := r.URL.Query().Get("consume") == "true"
+	if consume && r.Header.Get("Range") != "" {
+		http.Error(w, "consume=true cannot be combined with a Range request", http.StatusBadRequest)
+		return
+	}
+
+	found, err := h.lookupMessages(r.Context(), queueURL, []string{messageID})
+	if err != nil {
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+
+	msg, ok := found[messageID]
+	if !ok {
+		http.Error(w, "message not found", http.StatusNotFound)
+		return
+	}
+
+	body := aws.ToString(msg.Body)
+	log.Printf("GetMessageBody: Serving %d byte body of message %s (Range: %q)", len(body), messageID, r.Header.Get("Range"))
+
+	if consume {
+		if _, err := h.Client.DeleteMessage(r.Context(), &sqs.DeleteMessageInput{
+			QueueUrl:      aws.String(queueURL),
+			ReceiptHandle: msg.ReceiptHandle,
+		}); err != nil {
+			log.Printf("GetMessageBody: Error consuming message %s: %v", messageID, err)
+			writeSQSError(w, r, err, queueURL)
+			return
+		}
+		log.Printf("GetMessageBody: Consumed message %s from queue %s", messageID, queueURL)
+		w.Header().Set("X-Message-Consumed", "true")
+	}
+
+	w.Header().Set("Content-Type", inferContentType(body)+"; charset=utf-8")
+	// Advertise range support on every response, including 416s
+	w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(w, r, "", time.Time{}, strings.NewReader(body))
+}
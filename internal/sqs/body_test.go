@@ -0,0 +1,185 @@
+package sqs
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_GetMessageBody(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	const body = "0123456789abcdefghij"
+
+	tests := []struct {
+		name           string
+		messageID      string
+		rangeHeader    string
+		expectedStatus int
+		expectedBody   string
+		expectedRange  string
+	}{
+		{
+			name:           "full body",
+			messageID:      "large-msg",
+			expectedStatus: http.StatusOK,
+			expectedBody:   body,
+		},
+		{
+			name:           "satisfiable range",
+			messageID:      "large-msg",
+			rangeHeader:    "bytes=5-9",
+			expectedStatus: http.StatusPartialContent,
+			expectedBody:   "56789",
+			expectedRange:  "bytes 5-9/20",
+		},
+		{
+			name:           "suffix range",
+			messageID:      "large-msg",
+			rangeHeader:    "bytes=-4",
+			expectedStatus: http.StatusPartialContent,
+			expectedBody:   "ghij",
+			expectedRange:  "bytes 16-19/20",
+		},
+		{
+			name:           "unsatisfiable range",
+			messageID:      "large-msg",
+			rangeHeader:    "bytes=50-60",
+			expectedStatus: http.StatusRequestedRangeNotSatisfiable,
+			expectedRange:  "bytes */20",
+		},
+		{
+			name:           "unknown message",
+			messageID:      "missing-msg",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			mockClient.AddMessage(queueURL, "large-msg", body)
+			handler := &SQSHandler{Client: mockClient}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages/{messageId}/body", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL, "messageId": tt.messageID})
+			if tt.rangeHeader != "" {
+				req.Header.Set("Range", tt.rangeHeader)
+			}
+			rr := httptest.NewRecorder()
+			handler.GetMessageBody(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus == http.StatusNotFound {
+				return
+			}
+			if got := rr.Header().Get("Accept-Ranges"); got != "bytes" {
+				t.Errorf("expected Accept-Ranges bytes, got %q", got)
+			}
+			if got := rr.Header().Get("Content-Range"); got != tt.expectedRange {
+				t.Errorf("expected Content-Range %q, got %q", tt.expectedRange, got)
+			}
+			if tt.expectedBody != "" && rr.Body.String() != tt.expectedBody {
+				t.Errorf("expected body %q, got %q", tt.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessageBody_Consume(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue"
+
+	bodyReq := func(messageID, query string) *http.Request {
+		req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages/{messageId}/body"+query, nil)
+		return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL, "messageId": messageID})
+	}
+
+	t.Run("consume removes the demo message after the fetch", func(t *testing.T) {
+		handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+		rr := httptest.NewRecorder()
+		handler.GetMessageBody(rr, bodyReq("dlq-001", "?consume=true"))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), `"orderId": "99999"`) {
+			t.Errorf("expected the message body, got %q", rr.Body.String())
+		}
+		if rr.Header().Get("X-Message-Consumed") != "true" {
+			t.Error("expected X-Message-Consumed header")
+		}
+
+		rr = httptest.NewRecorder()
+		handler.GetMessageBody(rr, bodyReq("dlq-001", ""))
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected consumed message to be gone, got %d", rr.Code)
+		}
+	})
+
+	t.Run("plain fetch does not delete", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.AddMessage(queueURL, "msg-1", "body")
+		handler := &SQSHandler{Client: mockClient}
+
+		handler.GetMessageBody(httptest.NewRecorder(), bodyReq("msg-1", ""))
+
+		if len(mockClient.DeleteMessageCalls) != 0 {
+			t.Errorf("expected no deletes, got %d", len(mockClient.DeleteMessageCalls))
+		}
+	})
+
+	t.Run("failed fetch does not delete", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.AddMessage(queueURL, "msg-1", "body")
+		mockClient.SetError("ReceiveMessage", errors.New("ServiceUnavailable"))
+		handler := &SQSHandler{Client: mockClient}
+
+		rr := httptest.NewRecorder()
+		handler.GetMessageBody(rr, bodyReq("msg-1", "?consume=true"))
+
+		if rr.Code != http.StatusInternalServerError {
+			t.Errorf("expected 500, got %d", rr.Code)
+		}
+		if len(mockClient.DeleteMessageCalls) != 0 {
+			t.Errorf("expected no deletes after a failed fetch, got %d", len(mockClient.DeleteMessageCalls))
+		}
+	})
+
+	t.Run("failed delete returns no body", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.AddMessage(queueURL, "msg-1", "secret body")
+		mockClient.SetError("DeleteMessage", errors.New("AccessDenied"))
+		handler := &SQSHandler{Client: mockClient}
+
+		rr := httptest.NewRecorder()
+		handler.GetMessageBody(rr, bodyReq("msg-1", "?consume=true"))
+
+		if rr.Code != http.StatusInternalServerError || strings.Contains(rr.Body.String(), "secret body") {
+			t.Errorf("expected a 500 without the body, got %d: %q", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("consume with range is rejected", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.AddMessage(queueURL, "msg-1", "body")
+		handler := &SQSHandler{Client: mockClient}
+
+		req := bodyReq("msg-1", "?consume=true")
+		req.Header.Set("Range", "bytes=0-1")
+		rr := httptest.NewRecorder()
+		handler.GetMessageBody(rr, req)
+
+		if rr.Code != http.StatusBadRequest || len(mockClient.DeleteMessageCalls) != 0 {
+			t.Errorf("expected 400 and no delete, got %d with %d deletes", rr.Code, len(mockClient.DeleteMessageCalls))
+		}
+	})
+}
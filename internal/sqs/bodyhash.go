@@ -0,0 +1,43 @@
+package sqs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// canonicalizeJSON returns body in a canonical JSON form, with object keys
+// sorted and insignificant whitespace removed, so semantically identical
+// bodies compare byte-for-byte. Numbers keep their literal form (1 and 1.0
+// differ). It reports false when body is not a single JSON value.
+func canonicalizeJSON(body string) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(body)))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return nil, false
+	}
+
+	// Encoding a decoded map writes object keys in sorted order
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, false
+	}
+	return bytes.TrimSuffix(canonical.Bytes(), []byte("\n")), true
+}
+
+// hashBody hashes a message body so that JSON bodies differing only in key
+// order or whitespace hash alike. Non-JSON bodies hash as-is.
+func hashBody(body string) string {
+	normalized, ok := canonicalizeJSON(body)
+	if !ok {
+		normalized = []byte(body)
+	}
+
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,51 @@
+package sqs
+
+import "testing"
+
+func TestCanonicalizeJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+		ok       bool
+	}{
+		{name: "keys sorted at every level", body: `{"b":{"y":1,"x":2},"a":[3,1]}`, expected: `{"a":[3,1],"b":{"x":2,"y":1}}`, ok: true},
+		{name: "whitespace removed", body: " {\"a\" :\n 1 }\n", expected: `{"a":1}`, ok: true},
+		{name: "numbers keep their literal form", body: `{"n":1.50}`, expected: `{"n":1.50}`, ok: true},
+		{name: "html is not escaped", body: `{"html":"<b>&</b>"}`, expected: `{"html":"<b>&</b>"}`, ok: true},
+		{name: "scalar", body: ` "text" `, expected: `"text"`, ok: true},
+		{name: "not JSON", body: "hello", ok: false},
+		{name: "trailing data", body: `{"a":1} {"b":2}`, ok: false},
+		{name: "empty", body: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canonical, ok := canonicalizeJSON(tt.body)
+			if ok != tt.ok {
+				t.Fatalf("expected ok=%v, got %v", tt.ok, ok)
+			}
+			if ok && string(canonical) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, canonical)
+			}
+		})
+	}
+}
+
+func TestHashBody(t *testing.T) {
+	if hashBody(`{"a":1,"b":[1,2]}`) != hashBody(" {\"b\": [1, 2], \"a\": 1}\n") {
+		t.Error("expected key order and whitespace to be ignored")
+	}
+	if hashBody(`{"a":1}`) == hashBody(`{"a":1.0}`) {
+		t.Error("expected numbers to keep their literal form")
+	}
+	if hashBody(`{"a":[1,2]}`) == hashBody(`{"a":[2,1]}`) {
+		t.Error("expected array order to matter")
+	}
+	if hashBody("hello") == hashBody(" hello") {
+		t.Error("expected non-JSON bodies to hash as-is")
+	}
+	if hashBody("hello") != hashBody("hello") {
+		t.Error("expected non-JSON bodies to hash deterministically")
+	}
+}
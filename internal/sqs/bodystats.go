@@ -0,0 +1,63 @@
+package sqs
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// bodySizeBuckets are the upper bounds, in bytes, of the body size histogram
+// buckets; the last bucket has no upper bound.
+var bodySizeBuckets = []struct {
+	label string
+	limit int
+}{
+	{label: "<1KB", limit: 1024},
+	{label: "1-10KB", limit: 10 * 1024},
+	{label: "10-100KB", limit: 100 * 1024},
+	{label: ">100KB"},
+}
+
+// bodySizeBucket is one histogram bucket of sampled body sizes.
+type bodySizeBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// bodySizeStats summarizes the body sizes of a message sample.
+type bodySizeStats struct {
+	SampleSize   int              `json:"sampleSize"`
+	AverageBytes float64          `json:"averageBytes"`
+	MaxBytes     int              `json:"maxBytes"`
+	Histogram    []bodySizeBucket `json:"histogram"`
+}
+
+// bodySizeStatistics buckets the body sizes of messages, in bytes, and
+// computes their average and maximum. Every bucket is reported, in size
+// order, even when empty.
+func bodySizeStatistics(messages []types.Message) bodySizeStats {
+	stats := bodySizeStats{
+		SampleSize: len(messages),
+		Histogram:  make([]bodySizeBucket, len(bodySizeBuckets)),
+	}
+	for i, bucket := range bodySizeBuckets {
+		stats.Histogram[i].Label = bucket.label
+	}
+
+	total := 0
+	for _, msg := range messages {
+		size := len(aws.ToString(msg.Body))
+		total += size
+		stats.MaxBytes = max(stats.MaxBytes, size)
+
+		for i, bucket := range bodySizeBuckets {
+			if bucket.limit == 0 || size < bucket.limit {
+				stats.Histogram[i].Count++
+				break
+			}
+		}
+	}
+	if len(messages) > 0 {
+		stats.AverageBytes = float64(total) / float64(len(messages))
+	}
+	return stats
+}
@@ -0,0 +1,93 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_GetQueueStatistics_BodyStats(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue"
+	ctx := context.Background()
+
+	// Replace the demo queue's messages with bodies of known sizes
+	client := demo.NewDemoSQSClient()
+	existing, err := client.ReceiveMessage(ctx, &awssqs.ReceiveMessageInput{QueueUrl: aws.String(queueURL), MaxNumberOfMessages: 1000})
+	if err != nil {
+		t.Fatalf("failed to receive demo messages: %v", err)
+	}
+	for _, msg := range existing.Messages {
+		client.DeleteMessage(ctx, &awssqs.DeleteMessageInput{QueueUrl: aws.String(queueURL), ReceiptHandle: msg.ReceiptHandle})
+	}
+	for _, size := range []int{100, 900, 2000, 50000, 200000} {
+		client.SendMessage(ctx, &awssqs.SendMessageInput{QueueUrl: aws.String(queueURL), MessageBody: aws.String(strings.Repeat("x", size))})
+	}
+
+	tests := []struct {
+		name      string
+		query     string
+		expectSet bool
+	}{
+		{name: "included on request", query: "?includeBodyStats=true", expectSet: true},
+		{name: "omitted by default", query: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: client, isDemo: true}
+
+			req := httptest.NewRequest("GET", "/api/queues/x/statistics"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetQueueStatistics(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			var resp struct {
+				BodyStatistics *bodySizeStats `json:"bodyStatistics"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if !tt.expectSet {
+				if resp.BodyStatistics != nil {
+					t.Errorf("expected no body statistics, got %+v", resp.BodyStatistics)
+				}
+				return
+			}
+			stats := resp.BodyStatistics
+			if stats == nil {
+				t.Fatal("expected body statistics")
+			}
+			if stats.SampleSize != 5 || stats.MaxBytes != 200000 || stats.AverageBytes != 50600 {
+				t.Errorf("expected 5 sampled, max 200000 and average 50600, got %+v", stats)
+			}
+			expected := []bodySizeBucket{{Label: "<1KB", Count: 2}, {Label: "1-10KB", Count: 1}, {Label: "10-100KB", Count: 1}, {Label: ">100KB", Count: 1}}
+			for i, bucket := range stats.Histogram {
+				if i >= len(expected) || bucket != expected[i] {
+					t.Fatalf("expected histogram %+v, got %+v", expected, stats.Histogram)
+				}
+			}
+		})
+	}
+}
+
+func TestBodySizeStatistics_Empty(t *testing.T) {
+	stats := bodySizeStatistics(nil)
+	if stats.SampleSize != 0 || stats.AverageBytes != 0 || stats.MaxBytes != 0 {
+		t.Errorf("expected zero statistics, got %+v", stats)
+	}
+	if len(stats.Histogram) != len(bodySizeBuckets) {
+		t.Errorf("expected every bucket reported, got %+v", stats.Histogram)
+	}
+}
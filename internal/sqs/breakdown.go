@@ -0,0 +1,54 @@
+package sqs
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// defaultGroupAttribute is the message attribute broken down in DLQ
+	// statistics when ?groupAttribute= is not given.
+	defaultGroupAttribute = "ErrorType"
+	// defaultGroupTop caps how many distinct values the breakdown returns.
+	defaultGroupTop = 10
+	// defaultDLQSampleSize is how many DLQ messages feed the statistics when
+	// ?sampleSize= is not given.
+	defaultDLQSampleSize = 10
+	// maxDLQSampleSize caps ?sampleSize= so statistics stay cheap.
+	maxDLQSampleSize = 100
+)
+
+// attributeValueCount is one entry of a message attribute breakdown.
+type attributeValueCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// attributeBreakdown counts the distinct values of a string message attribute
+// across messages, returning the top entries by count (ties by value) and the
+// number of distinct values seen.
+func attributeBreakdown(messages []types.Message, attribute string, top int) ([]attributeValueCount, int) {
+	counts := make(map[string]int)
+	for _, msg := range messages {
+		if value, ok := msg.MessageAttributes[attribute]; ok && value.StringValue != nil {
+			counts[*value.StringValue]++
+		}
+	}
+
+	values := make([]attributeValueCount, 0, len(counts))
+	for value, count := range counts {
+		values = append(values, attributeValueCount{Value: value, Count: count})
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if values[i].Count != values[j].Count {
+			return values[i].Count > values[j].Count
+		}
+		return values[i].Value < values[j].Value
+	})
+
+	if top > 0 && len(values) > top {
+		values = values[:top]
+	}
+	return values, len(counts)
+}
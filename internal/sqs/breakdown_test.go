@@ -0,0 +1,195 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_GetQueueStatistics_AttributeBreakdown(t *testing.T) {
+	const dlqURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue"
+
+	tests := []struct {
+		name              string
+		query             string
+		expectedAttribute string
+		expectedValues    []attributeValueCount
+		expectedDistinct  int
+	}{
+		{
+			name:              "defaults to ErrorType",
+			expectedAttribute: "ErrorType",
+			expectedValues: []attributeValueCount{
+				{Value: "ValidationError", Count: 2},
+				{Value: "GatewayTimeout", Count: 1},
+			},
+			expectedDistinct: 2,
+		},
+		{
+			name:              "groups by a chosen attribute",
+			query:             "?groupAttribute=OriginalQueue",
+			expectedAttribute: "OriginalQueue",
+			expectedValues: []attributeValueCount{
+				{Value: "demo-notifications-queue", Count: 1},
+				{Value: "demo-orders-queue", Count: 1},
+				{Value: "demo-payments-queue", Count: 1},
+			},
+			expectedDistinct: 3,
+		},
+		{
+			name:              "top-N limits the values",
+			query:             "?groupAttribute=OriginalQueue&groupTop=1",
+			expectedAttribute: "OriginalQueue",
+			expectedValues: []attributeValueCount{
+				{Value: "demo-notifications-queue", Count: 1},
+			},
+			expectedDistinct: 3,
+		},
+		{
+			name:              "missing attribute yields no values",
+			query:             "?groupAttribute=Tenant",
+			expectedAttribute: "Tenant",
+			expectedValues:    []attributeValueCount{},
+			expectedDistinct:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/statistics"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": dlqURL})
+			rr := httptest.NewRecorder()
+			handler.GetQueueStatistics(rr, req)
+
+			var stats struct {
+				DLQStatistics struct {
+					ErrorTypes         map[string]int `json:"errorTypes"`
+					AttributeBreakdown struct {
+						Attribute      string                `json:"attribute"`
+						DistinctValues int                   `json:"distinctValues"`
+						Values         []attributeValueCount `json:"values"`
+					} `json:"attributeBreakdown"`
+				} `json:"dlqStatistics"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			breakdown := stats.DLQStatistics.AttributeBreakdown
+			if breakdown.Attribute != tt.expectedAttribute {
+				t.Errorf("expected attribute %s, got %s", tt.expectedAttribute, breakdown.Attribute)
+			}
+			if breakdown.DistinctValues != tt.expectedDistinct {
+				t.Errorf("expected %d distinct values, got %d", tt.expectedDistinct, breakdown.DistinctValues)
+			}
+			if len(breakdown.Values) != len(tt.expectedValues) {
+				t.Fatalf("expected values %v, got %v", tt.expectedValues, breakdown.Values)
+			}
+			for i, expected := range tt.expectedValues {
+				if breakdown.Values[i] != expected {
+					t.Errorf("value %d: expected %+v, got %+v", i, expected, breakdown.Values[i])
+				}
+			}
+
+			// The existing errorTypes map is still reported
+			if stats.DLQStatistics.ErrorTypes["ValidationError"] != 2 {
+				t.Errorf("expected errorTypes ValidationError=2, got %v", stats.DLQStatistics.ErrorTypes)
+			}
+		})
+	}
+}
+
+// batchingReceiveClient serves a fixed set of messages ten at a time, like
+// live SQS, and counts ReceiveMessage calls.
+type batchingReceiveClient struct {
+	*helpers.MockSQSClient
+	messages []sqstypes.Message
+	receives int
+}
+
+func (c *batchingReceiveClient) ReceiveMessage(ctx context.Context, params *awssqs.ReceiveMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error) {
+	start := min(c.receives*10, len(c.messages))
+	end := min(start+int(params.MaxNumberOfMessages), len(c.messages))
+	c.receives++
+	return &awssqs.ReceiveMessageOutput{Messages: c.messages[start:end]}, nil
+}
+
+func TestSQSHandler_GetQueueStatistics_SampleSize(t *testing.T) {
+	const dlqURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	tests := []struct {
+		name              string
+		query             string
+		expectedStatus    int
+		expectedReceives  int
+		expectedSampled   int
+		expectedRequested int
+	}{
+		{name: "default samples one batch", expectedStatus: http.StatusOK, expectedReceives: 1, expectedSampled: 10, expectedRequested: 10},
+		{name: "larger sample accumulates batches", query: "?sampleSize=35", expectedStatus: http.StatusOK, expectedReceives: 4, expectedSampled: 35, expectedRequested: 35},
+		{name: "sample capped and limited by queue", query: "?sampleSize=500", expectedStatus: http.StatusOK, expectedReceives: 6, expectedSampled: 45, expectedRequested: maxDLQSampleSize},
+		{name: "invalid sample size", query: "?sampleSize=0", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &batchingReceiveClient{MockSQSClient: helpers.NewMockSQSClient()}
+			for i := 0; i < 45; i++ {
+				client.messages = append(client.messages, sqstypes.Message{
+					MessageId:  aws.String(fmt.Sprintf("msg-%d", i)),
+					Body:       aws.String("failed"),
+					Attributes: map[string]string{"ApproximateReceiveCount": "3"},
+				})
+			}
+			handler := &SQSHandler{Client: client}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/statistics"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": dlqURL})
+			rr := httptest.NewRecorder()
+			handler.GetQueueStatistics(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var stats struct {
+				DLQStatistics struct {
+					SampleSize          int `json:"sampleSize"`
+					RequestedSampleSize int `json:"requestedSampleSize"`
+					QueueDepth          int `json:"queueDepth"`
+				} `json:"dlqStatistics"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if client.receives != tt.expectedReceives {
+				t.Errorf("expected %d ReceiveMessage calls, got %d", tt.expectedReceives, client.receives)
+			}
+			if stats.DLQStatistics.SampleSize != tt.expectedSampled {
+				t.Errorf("expected sampleSize %d, got %d", tt.expectedSampled, stats.DLQStatistics.SampleSize)
+			}
+			if stats.DLQStatistics.RequestedSampleSize != tt.expectedRequested {
+				t.Errorf("expected requestedSampleSize %d, got %d", tt.expectedRequested, stats.DLQStatistics.RequestedSampleSize)
+			}
+			if stats.DLQStatistics.QueueDepth != 5 {
+				t.Errorf("expected queueDepth 5, got %d", stats.DLQStatistics.QueueDepth)
+			}
+		})
+	}
+}
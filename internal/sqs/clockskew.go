@@ -0,0 +1,58 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// clockSkewTolerance is how far in the future a SentTimestamp may be before
+// the message counts as evidence of clock skew between this server and AWS.
+const clockSkewTolerance = 5 * time.Second
+
+// clock returns the handler's current time, used for every age computed
+// from message timestamps.
+func (h *SQSHandler) clock() time.Time {
+	if h.now != nil {
+		return h.now()
+	}
+	return time.Now()
+}
+
+// Now returns the handler's current time (see clock), for message ages
+// computed outside this package, such as on the WebSocket stream.
+func (h *SQSHandler) Now() time.Time {
+	return h.clock()
+}
+
+// SetMessageAges sets ageSeconds from SentTimestamp as of now, clamping the
+// negative ages a skewed clock produces to 0. It reports clock skew when
+// most messages with a SentTimestamp were sent more than clockSkewTolerance
+// in the future.
+func SetMessageAges(messages []internal_types.Message, now time.Time) (skewDetected bool) {
+	nowMs := now.UnixMilli()
+	timestamped, future := 0, 0
+	for i := range messages {
+		sent, err := strconv.ParseInt(messages[i].Attributes["SentTimestamp"], 10, 64)
+		if err != nil || sent <= 0 {
+			continue
+		}
+		timestamped++
+		if sent-nowMs > clockSkewTolerance.Milliseconds() {
+			future++
+		}
+		age := max(nowMs-sent, 0) / 1000
+		messages[i].AgeSeconds = &age
+	}
+	return future > 0 && future*2 > timestamped
+}
+
+// writeClockSkewWarning flags a detected skew on the response and in the log
+// so operators can fix the server clock.
+func writeClockSkewWarning(header http.Header, queueURL string) {
+	header.Set("X-Clock-Skew-Detected", "true")
+	log.Printf("Warning: Most messages from %s were sent in the future; the server clock may be behind AWS", queueURL)
+}
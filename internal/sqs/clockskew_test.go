@@ -0,0 +1,144 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSetMessageAges(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	sentAt := func(offset time.Duration) internal_types.Message {
+		return internal_types.Message{Attributes: map[string]string{
+			"SentTimestamp": strconv.FormatInt(now.Add(offset).UnixMilli(), 10),
+		}}
+	}
+
+	tests := []struct {
+		name         string
+		messages     []internal_types.Message
+		expectedAges []int64
+		expectedSkew bool
+	}{
+		{
+			name:         "past messages",
+			messages:     []internal_types.Message{sentAt(-90 * time.Second), sentAt(-1500 * time.Millisecond)},
+			expectedAges: []int64{90, 1},
+		},
+		{
+			name:         "slightly future message is clamped without skew",
+			messages:     []internal_types.Message{sentAt(2 * time.Second), sentAt(-10 * time.Second)},
+			expectedAges: []int64{0, 10},
+		},
+		{
+			name:         "most messages far in the future",
+			messages:     []internal_types.Message{sentAt(time.Minute), sentAt(2 * time.Minute), sentAt(-time.Second)},
+			expectedAges: []int64{0, 0, 1},
+			expectedSkew: true,
+		},
+		{
+			name:         "a minority in the future is not skew",
+			messages:     []internal_types.Message{sentAt(time.Minute), sentAt(-time.Second), sentAt(-2 * time.Second)},
+			expectedAges: []int64{0, 1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if skew := SetMessageAges(tt.messages, now); skew != tt.expectedSkew {
+				t.Errorf("expected skew %v, got %v", tt.expectedSkew, skew)
+			}
+			for i, msg := range tt.messages {
+				if msg.AgeSeconds == nil || *msg.AgeSeconds != tt.expectedAges[i] {
+					t.Errorf("message %d: expected age %d, got %v", i, tt.expectedAges[i], msg.AgeSeconds)
+				}
+			}
+		})
+	}
+
+	noTimestamp := []internal_types.Message{{Attributes: map[string]string{}}}
+	if SetMessageAges(noTimestamp, now) || noTimestamp[0].AgeSeconds != nil {
+		t.Error("expected no age or skew without a SentTimestamp")
+	}
+}
+
+func TestSQSHandler_GetMessages_ClockSkew(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue(queueURL)
+	mock.AddMessageWithTimestamp(queueURL, "first", "ahead", "1640995200000")
+	mock.AddMessageWithTimestamp(queueURL, "second", "ahead", "1640995260000")
+
+	// A server clock an hour behind sees the messages as sent in the future
+	sent := time.UnixMilli(1640995200000)
+	handler := &SQSHandler{Client: mock, now: func() time.Time { return sent.Add(-time.Hour) }}
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+	rr := httptest.NewRecorder()
+	handler.GetMessages(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr.Header().Get("X-Clock-Skew-Detected") != "true" {
+		t.Error("expected the clock skew warning header")
+	}
+	var messages []internal_types.Message
+	if err := json.Unmarshal(rr.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+	for _, msg := range messages {
+		if msg.AgeSeconds == nil || *msg.AgeSeconds != 0 {
+			t.Errorf("expected future messages clamped to age 0, got %v", msg.AgeSeconds)
+		}
+	}
+
+	// The enveloped response carries the flag in the body as well
+	envelopeReq := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?envelope=true", nil)
+	envelopeReq = mux.SetURLVars(envelopeReq, map[string]string{"queueUrl": queueURL})
+	rr = httptest.NewRecorder()
+	handler.GetMessages(rr, envelopeReq)
+	var envelope struct {
+		Messages          []internal_types.Message `json:"messages"`
+		ClockSkewDetected bool                     `json:"clockSkewDetected"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("failed to decode envelope: %v", err)
+	}
+	if !envelope.ClockSkewDetected || len(envelope.Messages) != 2 {
+		t.Errorf("expected both messages and clockSkewDetected in the envelope, got %+v", envelope)
+	}
+
+	// With the clock in step there is no warning and ages are real
+	handler.now = func() time.Time { return sent.Add(2 * time.Minute) }
+	rr = httptest.NewRecorder()
+	handler.GetMessages(rr, req)
+	if rr.Header().Get("X-Clock-Skew-Detected") != "" {
+		t.Error("expected no clock skew warning with an accurate clock")
+	}
+	messages = nil
+	if err := json.Unmarshal(rr.Body.Bytes(), &messages); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	ages := map[string]int64{}
+	for _, msg := range messages {
+		if msg.AgeSeconds != nil {
+			ages[msg.MessageId] = *msg.AgeSeconds
+		}
+	}
+	if ages["first"] != 120 || ages["second"] != 60 {
+		t.Errorf("expected ages 120 and 60, got %v", ages)
+	}
+}
@@ -0,0 +1,160 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// defaultCompareSampleSize is how many messages are sampled per queue when
+	// the request does not say.
+	defaultCompareSampleSize = 100
+	// maxCompareSampleSize caps sampleSize so a comparison stays cheap.
+	maxCompareSampleSize = 1000
+)
+
+// QueueSample summarizes the messages sampled from one side of a comparison.
+type QueueSample struct {
+	QueueURL       string `json:"queueUrl"`
+	Sampled        int    `json:"sampled"`
+	DistinctBodies int    `json:"distinctBodies"`
+}
+
+// QueueComparison reports how the distinct message bodies of two queue
+// samples overlap.
+type QueueComparison struct {
+	QueueA  QueueSample `json:"queueA"`
+	QueueB  QueueSample `json:"queueB"`
+	Shared  int         `json:"shared"`
+	OnlyInA int         `json:"onlyInA"`
+	OnlyInB int         `json:"onlyInB"`
+	Note    string      `json:"note"`
+}
+
+const compareNote = "Counts are over non-consuming samples; on live SQS a sample may miss messages, so small differences are expected."
+
+// CompareQueues handles HTTP requests to compare the message contents of two
+// queues, e.g. a staging and a prod queue that should receive equivalent
+// traffic. Both queues are sampled without consuming messages and bodies are
+// matched by a hash of their normalized JSON (non-JSON bodies by their raw
+// bytes).
+func (h *SQSHandler) CompareQueues(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		QueueURLA  string `json:"queueUrlA"`
+		QueueURLB  string `json:"queueUrlB"`
+		SampleSize int    `json:"sampleSize"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if payload.QueueURLA == "" || payload.QueueURLB == "" {
+		http.Error(w, "queueUrlA and queueUrlB are required", http.StatusBadRequest)
+		return
+	}
+
+	sampleSize := payload.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = defaultCompareSampleSize
+	}
+	if sampleSize > maxCompareSampleSize {
+		sampleSize = maxCompareSampleSize
+	}
+
+	queueURLA := normalizeQueueURL(payload.QueueURLA)
+	queueURLB := normalizeQueueURL(payload.QueueURLB)
+
+	messagesA, err := h.sampleMessages(r.Context(), queueURLA, sampleSize)
+	if err != nil {
+		writeSQSError(w, r, err, queueURLA)
+		return
+	}
+	messagesB, err := h.sampleMessages(r.Context(), queueURLB, sampleSize)
+	if err != nil {
+		writeSQSError(w, r, err, queueURLB)
+		return
+	}
+
+	hashesA := bodyHashes(messagesA)
+	hashesB := bodyHashes(messagesB)
+
+	comparison := QueueComparison{
+		QueueA: QueueSample{QueueURL: queueURLA, Sampled: len(messagesA), DistinctBodies: len(hashesA)},
+		QueueB: QueueSample{QueueURL: queueURLB, Sampled: len(messagesB), DistinctBodies: len(hashesB)},
+		Note:   compareNote,
+	}
+	for hash := range hashesA {
+		if hashesB[hash] {
+			comparison.Shared++
+		} else {
+			comparison.OnlyInA++
+		}
+	}
+	comparison.OnlyInB = len(hashesB) - comparison.Shared
+
+	log.Printf("CompareQueues: %s vs %s: %d shared, %d only in A, %d only in B",
+		queueURLA, queueURLB, comparison.Shared, comparison.OnlyInA, comparison.OnlyInB)
+
+	writeJSON(w, r, comparison)
+}
+
+// sampleMessages receives up to limit distinct messages without consuming
+// them: each receive's messages are made visible again at once (see
+// ReleaseMessages). Live SQS returns at most 10
+// messages per call, so it keeps receiving until the limit is reached, a
+// receive turns up nothing new, or the attempt budget runs out.
+func (h *SQSHandler) sampleMessages(ctx context.Context, queueURL string, limit int) ([]types.Message, error) {
+	maxReceive := int32(10)
+	if h.isDemo {
+		maxReceive = int32(limit)
+	}
+	attempts := (limit+9)/10 + lookupReceiveAttempts
+
+	seen := make(map[string]bool, limit)
+	sampled := make([]types.Message, 0, limit)
+	for attempt := 0; attempt < attempts && len(sampled) < limit; attempt++ {
+		result, err := h.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(queueURL),
+			MaxNumberOfMessages:   maxReceive,
+			AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+			MessageAttributeNames: []string{"All"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+		ReleaseMessages(ctx, h.Client, queueURL, result.Messages)
+
+		added := 0
+		for _, msg := range result.Messages {
+			id := aws.ToString(msg.MessageId)
+			if seen[id] || len(sampled) >= limit {
+				continue
+			}
+			seen[id] = true
+			sampled = append(sampled, msg)
+			added++
+		}
+		if added == 0 {
+			break
+		}
+	}
+
+	return sampled, nil
+}
+
+// bodyHashes returns the set of normalized body hashes of messages.
+func bodyHashes(messages []types.Message) map[string]bool {
+	hashes := make(map[string]bool, len(messages))
+	for _, msg := range messages {
+		hashes[hashBody(aws.ToString(msg.Body))] = true
+	}
+	return hashes
+}
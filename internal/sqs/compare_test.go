@@ -0,0 +1,126 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestSQSHandler_CompareQueues(t *testing.T) {
+	const (
+		stagingURL = "https://sqs.us-east-1.amazonaws.com/123456789012/staging-orders"
+		prodURL    = "https://sqs.us-east-1.amazonaws.com/123456789012/prod-orders"
+	)
+
+	client := demo.NewDemoSQSClient()
+	send := func(queueURL string, bodies ...string) {
+		for _, body := range bodies {
+			client.SendMessage(context.Background(), &awssqs.SendMessageInput{
+				QueueUrl: aws.String(queueURL), MessageBody: aws.String(body),
+			})
+		}
+	}
+	// Two shared bodies (one differing only in key order and whitespace), a
+	// duplicate in staging, two staging-only bodies and one prod-only body
+	send(stagingURL, `{"id":1,"total":10}`, `{"id":2,"total":20}`, `{"id":2,"total":20}`, `{"id":3}`, "plain text")
+	send(prodURL, `{ "total": 10, "id": 1 }`, `{"id":2,"total":20}`, `{"id":4}`)
+
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedSampled int
+		expected        QueueComparison
+	}{
+		{
+			name:            "full samples",
+			body:            `{"queueUrlA":"` + stagingURL + `","queueUrlB":"` + prodURL + `"}`,
+			expectedSampled: 5,
+			expected:        QueueComparison{Shared: 2, OnlyInA: 2, OnlyInB: 1},
+		},
+		{
+			name:            "sample size caps the first queue",
+			body:            `{"queueUrlA":"` + stagingURL + `","queueUrlB":"` + prodURL + `","sampleSize":2}`,
+			expectedSampled: 2,
+			expected:        QueueComparison{Shared: 2, OnlyInA: 0, OnlyInB: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/queues/compare", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			handler.CompareQueues(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			var got QueueComparison
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got.QueueA.Sampled != tt.expectedSampled {
+				t.Errorf("expected %d sampled from A, got %d", tt.expectedSampled, got.QueueA.Sampled)
+			}
+			if got.Shared != tt.expected.Shared || got.OnlyInA != tt.expected.OnlyInA || got.OnlyInB != tt.expected.OnlyInB {
+				t.Errorf("expected shared/onlyInA/onlyInB %d/%d/%d, got %d/%d/%d",
+					tt.expected.Shared, tt.expected.OnlyInA, tt.expected.OnlyInB,
+					got.Shared, got.OnlyInA, got.OnlyInB)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_CompareQueues_BadRequest(t *testing.T) {
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+	for _, body := range []string{`not json`, `{"queueUrlA":"https://sqs.us-east-1.amazonaws.com/123456789012/a"}`} {
+		req := httptest.NewRequest("POST", "/api/queues/compare", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+		handler.CompareQueues(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, rr.Code)
+		}
+	}
+}
+
+func TestSQSHandler_SampleMessages_ReleasesMessages(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue(queueURL)
+	for _, id := range []string{"msg-1", "msg-2", "msg-3"} {
+		mock.AddMessage(queueURL, id, "body "+id)
+	}
+	handler := &SQSHandler{Client: mock}
+
+	sampled, err := handler.sampleMessages(context.Background(), queueURL, 3)
+	if err != nil {
+		t.Fatalf("sampleMessages failed: %v", err)
+	}
+	if len(sampled) != 3 {
+		t.Fatalf("expected 3 sampled messages, got %d", len(sampled))
+	}
+
+	released := make(map[string]bool)
+	for _, call := range mock.ChangeMessageVisibilityCalls {
+		if call.VisibilityTimeout == 0 {
+			released[call.ReceiptHandle] = true
+		}
+	}
+	for _, msg := range sampled {
+		if handle := aws.ToString(msg.ReceiptHandle); !released[handle] {
+			t.Errorf("expected %s to be made visible again", handle)
+		}
+	}
+}
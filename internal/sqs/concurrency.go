@@ -0,0 +1,64 @@
+package sqs
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// defaultAWSMaxConcurrency bounds concurrent per-queue AWS calls when
+// AWS_MAX_CONCURRENCY is not set.
+const defaultAWSMaxConcurrency = 10
+
+// awsCallSlots is a process-wide semaphore bounding the per-queue
+// GetQueueAttributes and ListQueueTags calls made while listing queues, across
+// all in-flight requests, so many clients listing a large account at once
+// stay within AWS rate limits.
+var awsCallSlots = make(chan struct{}, awsMaxConcurrencyFromEnv())
+
+// awsMaxConcurrencyFromEnv returns AWS_MAX_CONCURRENCY, falling back to
+// defaultAWSMaxConcurrency when unset or invalid.
+func awsMaxConcurrencyFromEnv() int {
+	if value := os.Getenv("AWS_MAX_CONCURRENCY"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+		log.Printf("Invalid AWS_MAX_CONCURRENCY %q, using %d", value, defaultAWSMaxConcurrency)
+	}
+	return defaultAWSMaxConcurrency
+}
+
+// acquireAWSCall blocks until a call slot is free or ctx is done. The
+// returned release must be called once the AWS call completes.
+func acquireAWSCall(ctx context.Context) (func(), error) {
+	slots := awsCallSlots
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// listQueueTags returns the tags of queueURL, holding an AWS call slot for
+// the duration of the call.
+func (h *SQSHandler) listQueueTags(ctx context.Context, queueURL string) (map[string]string, error) {
+	release, err := acquireAWSCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	result, err := h.Client.ListQueueTags(ctx, &sqs.ListQueueTagsInput{
+		QueueUrl: aws.String(queueURL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	noteRequestID(ctx, result.ResultMetadata)
+	return result.Tags, nil
+}
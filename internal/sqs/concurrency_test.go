@@ -0,0 +1,104 @@
+package sqs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+)
+
+// slowAttributeClient delays per-queue calls on the demo client and records
+// the peak number of them in flight at once.
+type slowAttributeClient struct {
+	*demo.DemoSQSClient
+	inFlight    atomic.Int32
+	maxInFlight atomic.Int32
+}
+
+func (c *slowAttributeClient) track() func() {
+	current := c.inFlight.Add(1)
+	for {
+		peak := c.maxInFlight.Load()
+		if current <= peak || c.maxInFlight.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return func() { c.inFlight.Add(-1) }
+}
+
+func (c *slowAttributeClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	defer c.track()()
+	return c.DemoSQSClient.GetQueueAttributes(ctx, params, optFns...)
+}
+
+func (c *slowAttributeClient) ListQueueTags(ctx context.Context, params *awssqs.ListQueueTagsInput, optFns ...func(*awssqs.Options)) (*awssqs.ListQueueTagsOutput, error) {
+	defer c.track()()
+	return c.DemoSQSClient.ListQueueTags(ctx, params, optFns...)
+}
+
+func TestSQSHandler_ListQueues_GlobalConcurrencyBound(t *testing.T) {
+	t.Setenv("DISABLE_TAG_FILTER", "")
+	t.Setenv("FILTER_ENV", "")
+
+	tests := []struct {
+		name  string
+		bound int
+	}{
+		{name: "serialized", bound: 1},
+		{name: "bounded at two", bound: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := awsCallSlots
+			awsCallSlots = make(chan struct{}, tt.bound)
+			defer func() { awsCallSlots = previous }()
+
+			client := &slowAttributeClient{DemoSQSClient: demo.NewDemoSQSClient()}
+			handler := &SQSHandler{Client: client, isDemo: true}
+
+			var wg sync.WaitGroup
+			for i := 0; i < 6; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					rr := httptest.NewRecorder()
+					handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+					if rr.Code != http.StatusOK {
+						t.Errorf("expected 200, got %d", rr.Code)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if peak := client.maxInFlight.Load(); peak > int32(tt.bound) {
+				t.Errorf("expected at most %d concurrent AWS calls, saw %d", tt.bound, peak)
+			}
+		})
+	}
+}
+
+func TestAcquireAWSCall_HonorsContext(t *testing.T) {
+	previous := awsCallSlots
+	awsCallSlots = make(chan struct{}, 1)
+	defer func() { awsCallSlots = previous }()
+
+	release, err := acquireAWSCall(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := acquireAWSCall(ctx); err == nil {
+		t.Error("expected acquire to fail once the context is done")
+	}
+}
@@ -0,0 +1,124 @@
+package sqs
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// StreamPollInterval is how often the WebSocket poller re-receives from a
+// subscribed queue.
+const StreamPollInterval = 5 * time.Second
+
+// TagFilterConfig describes the tag filter ListQueues applies.
+type TagFilterConfig struct {
+	Enabled      bool                `json:"enabled"`
+	RequiredTags map[string][]string `json:"requiredTags,omitempty"`
+}
+
+// WebSocketConfig describes the real-time stream settings.
+type WebSocketConfig struct {
+	PollIntervalSeconds int      `json:"pollIntervalSeconds"`
+	AllowedOrigins      []string `json:"allowedOrigins"`
+}
+
+// LimitsConfig describes the request and concurrency limits in effect.
+type LimitsConfig struct {
+	AWSMaxConcurrency    int   `json:"awsMaxConcurrency"`
+	MaxRequestBodyBytes  int64 `json:"maxRequestBodyBytes"`
+	MessagesDefaultLimit int   `json:"messagesDefaultLimit"`
+	MessagesMaxLimit     int   `json:"messagesMaxLimit"`
+	MaxQueuesReturned    int   `json:"maxQueuesReturned"`
+	StatisticsMaxQueues  int   `json:"statisticsMaxQueues"`
+	DrainConcurrency     int   `json:"drainConcurrency"`
+}
+
+// FeatureFlags reports the boolean settings that switch features on or off.
+type FeatureFlags struct {
+	ReadOnly       bool `json:"readOnly"`
+	MultiTenant    bool `json:"multiTenant"`
+	PrefetchQueues bool `json:"prefetchQueues"`
+	QueueARNFields bool `json:"queueArnFields"`
+}
+
+// EffectiveConfig is a sanitized view of the env-driven settings in effect.
+// It must never carry credentials.
+type EffectiveConfig struct {
+	Mode             string          `json:"mode"`
+	Region           string          `json:"region,omitempty"`
+	SQSEndpoint      string          `json:"sqsEndpoint,omitempty"`
+	TagFilter        TagFilterConfig `json:"tagFilter"`
+	WebSocket        WebSocketConfig `json:"websocket"`
+	StreamFlushEvery int             `json:"streamFlushEvery"`
+	MessageSortOrder string          `json:"messageSortOrder"`
+	DefaultQueue     string          `json:"defaultQueue,omitempty"`
+	MaskAccountIDs   bool            `json:"maskAccountIds"`
+	Limits           LimitsConfig    `json:"limits"`
+	Features         FeatureFlags    `json:"features"`
+	// AuthTokenSet reports whether API_AUTH_TOKEN is set, never its value
+	AuthTokenSet bool `json:"authTokenSet"`
+}
+
+// effectiveConfig assembles the current configuration from the handler state
+// and the environment.
+func (h *SQSHandler) effectiveConfig() EffectiveConfig {
+	disableTagFilter, requiredTags := tagFilterFromEnv()
+	defaultLimit, maxLimit := messagesLimitsFromEnv()
+
+	cfg := EffectiveConfig{
+		Mode: "demo",
+		TagFilter: TagFilterConfig{
+			Enabled: !disableTagFilter,
+		},
+		WebSocket: WebSocketConfig{
+			PollIntervalSeconds: int(StreamPollInterval / time.Second),
+			AllowedOrigins:      []string{},
+		},
+		StreamFlushEvery: streamFlushEvery(),
+		MessageSortOrder: DefaultSortOrder(),
+		DefaultQueue:     defaultQueueFromEnv(),
+		MaskAccountIDs:   maskAccountIDsEnabled(),
+		Limits: LimitsConfig{
+			AWSMaxConcurrency:    cap(awsCallSlots),
+			MaxRequestBodyBytes:  maxRequestBodyBytesFromEnv(),
+			MessagesDefaultLimit: defaultLimit,
+			MessagesMaxLimit:     maxLimit,
+			MaxQueuesReturned:    maxQueuesReturnedFromEnv(),
+			StatisticsMaxQueues:  statisticsMaxQueuesFromEnv(),
+			DrainConcurrency:     drainConcurrencyFromEnv(),
+		},
+		Features: FeatureFlags{
+			ReadOnly:       ReadOnlyEnabled(),
+			MultiTenant:    MultiTenantEnabled(),
+			PrefetchQueues: PrefetchEnabled(),
+			QueueARNFields: queueARNFieldsEnabled(),
+		},
+		AuthTokenSet: os.Getenv("API_AUTH_TOKEN") != "",
+	}
+	if !disableTagFilter {
+		cfg.TagFilter.RequiredTags = requiredTags
+	}
+
+	if !h.isDemo {
+		cfg.Mode = "live"
+		cfg.Region = h.config.Region
+		cfg.SQSEndpoint = os.Getenv("SQS_ENDPOINT_URL")
+	}
+
+	for _, origin := range strings.Split(os.Getenv("ALLOWED_WEBSOCKET_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			cfg.WebSocket.AllowedOrigins = append(cfg.WebSocket.AllowedOrigins, origin)
+		}
+	}
+
+	return cfg
+}
+
+// GetConfig handles HTTP requests for the effective server configuration, so
+// operators can confirm which settings (e.g. tag filters) are active.
+func (h *SQSHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	cfg := h.effectiveConfig()
+
+	writeJSON(w, r, cfg)
+}
@@ -0,0 +1,183 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func getConfig(t *testing.T, handler *SQSHandler) EffectiveConfig {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/api/config", nil)
+	rr := httptest.NewRecorder()
+	handler.GetConfig(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var cfg EffectiveConfig
+	if err := json.NewDecoder(rr.Body).Decode(&cfg); err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+	return cfg
+}
+
+func TestSQSHandler_GetConfig(t *testing.T) {
+	t.Run("reflects DISABLE_TAG_FILTER", func(t *testing.T) {
+		t.Setenv("DISABLE_TAG_FILTER", "true")
+		t.Setenv("FILTER_ENV", "")
+
+		cfg := getConfig(t, &SQSHandler{Client: helpers.NewMockSQSClient(), isDemo: true})
+
+		if cfg.Mode != "demo" {
+			t.Errorf("expected mode demo, got %s", cfg.Mode)
+		}
+		if cfg.TagFilter.Enabled {
+			t.Error("expected tag filter to be reported as disabled")
+		}
+		if len(cfg.TagFilter.RequiredTags) != 0 {
+			t.Errorf("expected no required tags when disabled, got %v", cfg.TagFilter.RequiredTags)
+		}
+	})
+
+	t.Run("reflects custom FILTER_ENV", func(t *testing.T) {
+		t.Setenv("DISABLE_TAG_FILTER", "")
+		t.Setenv("FILTER_ENV", "dev,qa")
+
+		cfg := getConfig(t, &SQSHandler{
+			Client: helpers.NewMockSQSClient(),
+			config: aws.Config{Region: "eu-west-1"},
+		})
+
+		if cfg.Mode != "live" || cfg.Region != "eu-west-1" {
+			t.Errorf("expected live mode in eu-west-1, got %s/%s", cfg.Mode, cfg.Region)
+		}
+		if !cfg.TagFilter.Enabled {
+			t.Fatal("expected tag filter to be reported as enabled")
+		}
+		if got := cfg.TagFilter.RequiredTags["env"]; !reflect.DeepEqual(got, []string{"dev", "qa"}) {
+			t.Errorf("expected env filter [dev qa], got %v", got)
+		}
+		if got := cfg.TagFilter.RequiredTags["product"]; !reflect.DeepEqual(got, []string{"amt"}) {
+			t.Errorf("expected default product filter [amt], got %v", got)
+		}
+	})
+}
+
+func TestSQSHandler_GetConfig_LimitsAndFeatures(t *testing.T) {
+	t.Setenv("API_AUTH_TOKEN", "s3cret-token")
+	t.Setenv("READ_ONLY", "true")
+	t.Setenv("MULTI_TENANT", "")
+	t.Setenv("QUEUE_ARN_FIELDS", "false")
+	t.Setenv("MESSAGES_MAX_LIMIT", "50")
+	t.Setenv("MESSAGES_DEFAULT_LIMIT", "20")
+	t.Setenv("MAX_REQUEST_BODY_BYTES", "1024")
+	t.Setenv("DRAIN_CONCURRENCY", "2")
+	t.Setenv("PREFETCH_QUEUES", "")
+	t.Setenv("MAX_QUEUES_RETURNED", "")
+	t.Setenv("STATISTICS_MAX_QUEUES", "")
+
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient(), isDemo: true}
+	req := httptest.NewRequest("GET", "/api/config", nil)
+	rr := httptest.NewRecorder()
+	handler.GetConfig(rr, req)
+
+	if strings.Contains(rr.Body.String(), "s3cret-token") {
+		t.Fatalf("config leaked API_AUTH_TOKEN: %s", rr.Body.String())
+	}
+	var cfg EffectiveConfig
+	if err := json.NewDecoder(rr.Body).Decode(&cfg); err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+
+	if !cfg.AuthTokenSet {
+		t.Error("expected authTokenSet to be true")
+	}
+	expectedLimits := LimitsConfig{
+		AWSMaxConcurrency:    cap(awsCallSlots),
+		MaxRequestBodyBytes:  1024,
+		MessagesDefaultLimit: 20,
+		MessagesMaxLimit:     50,
+		MaxQueuesReturned:    defaultMaxQueuesReturned,
+		StatisticsMaxQueues:  defaultStatisticsMaxQueues,
+		DrainConcurrency:     2,
+	}
+	if cfg.Limits != expectedLimits {
+		t.Errorf("expected limits %+v, got %+v", expectedLimits, cfg.Limits)
+	}
+	expectedFeatures := FeatureFlags{ReadOnly: true}
+	if cfg.Features != expectedFeatures {
+		t.Errorf("expected features %+v, got %+v", expectedFeatures, cfg.Features)
+	}
+
+	t.Setenv("API_AUTH_TOKEN", "")
+	if cfg := getConfig(t, handler); cfg.AuthTokenSet {
+		t.Error("expected authTokenSet to be false without API_AUTH_TOKEN")
+	}
+}
+
+func TestSQSHandler_DefaultQueue(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	t.Run("config and AWS context echo DEFAULT_QUEUE", func(t *testing.T) {
+		t.Setenv("DEFAULT_QUEUE", " orders-queue ")
+		handler := &SQSHandler{Client: helpers.NewMockSQSClient(), isDemo: true}
+
+		if cfg := getConfig(t, handler); cfg.DefaultQueue != "orders-queue" {
+			t.Errorf("expected config defaultQueue orders-queue, got %q", cfg.DefaultQueue)
+		}
+
+		rr := httptest.NewRecorder()
+		handler.GetAWSContext(rr, httptest.NewRequest("GET", "/api/aws-context", nil))
+		var awsContext struct {
+			DefaultQueue string `json:"defaultQueue"`
+		}
+		if err := json.NewDecoder(rr.Body).Decode(&awsContext); err != nil {
+			t.Fatalf("failed to decode AWS context: %v", err)
+		}
+		if awsContext.DefaultQueue != "orders-queue" {
+			t.Errorf("expected AWS context defaultQueue orders-queue, got %q", awsContext.DefaultQueue)
+		}
+	})
+
+	t.Run("omitted when unset", func(t *testing.T) {
+		t.Setenv("DEFAULT_QUEUE", "")
+		if cfg := getConfig(t, &SQSHandler{Client: helpers.NewMockSQSClient(), isDemo: true}); cfg.DefaultQueue != "" {
+			t.Errorf("expected no defaultQueue, got %q", cfg.DefaultQueue)
+		}
+	})
+
+	tests := []struct {
+		name         string
+		defaultQueue string
+		isDemo       bool
+		expectErr    bool
+	}{
+		{name: "unset", defaultQueue: ""},
+		{name: "name resolves", defaultQueue: "orders-queue"},
+		{name: "URL resolves", defaultQueue: queueURL},
+		{name: "unknown name", defaultQueue: "missing-queue", expectErr: true},
+		{name: "demo mode is not checked", defaultQueue: "missing-queue", isDemo: true},
+	}
+
+	for _, tt := range tests {
+		t.Run("check "+tt.name, func(t *testing.T) {
+			t.Setenv("DEFAULT_QUEUE", tt.defaultQueue)
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			handler := &SQSHandler{Client: mockClient, isDemo: tt.isDemo}
+
+			err := handler.CheckDefaultQueue(context.Background())
+			if (err != nil) != tt.expectErr {
+				t.Errorf("expected error %v, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
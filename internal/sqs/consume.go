@@ -0,0 +1,109 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+const (
+	// defaultConsumeMax is how many messages a consume takes when ?max= is
+	// not given.
+	defaultConsumeMax = 10
+	// maxConsumeMax caps ?max= so one request cannot drain a large queue.
+	maxConsumeMax = 100
+)
+
+// consumeFailure reports a consumed message whose delete failed; it stays on
+// the queue and will be redelivered.
+type consumeFailure struct {
+	MessageID string `json:"messageId"`
+	Error     string `json:"error"`
+}
+
+// ConsumeMessages handles HTTP requests to receive up to ?max= messages and
+// delete them, for scripted consumers. Each message is captured for the
+// response before it is deleted, so a failure part-way through never loses a
+// message; a cancelled request stops before the next delete.
+func (h *SQSHandler) ConsumeMessages(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	limit := defaultConsumeMax
+	if maxParam := r.URL.Query().Get("max"); maxParam != "" {
+		parsed, err := strconv.Atoi(maxParam)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "max must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxConsumeMax)
+	}
+
+	ctx := r.Context()
+	consumed := []internal_types.Message{}
+	failed := []consumeFailure{}
+	seen := make(map[string]bool)
+
+	for len(consumed) < limit && ctx.Err() == nil {
+		result, err := h.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(queueURL),
+			MaxNumberOfMessages:   int32(min(limit-len(consumed), 10)),
+			WaitTimeSeconds:       1,
+			AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+			MessageAttributeNames: []string{"All"},
+		})
+		if err != nil {
+			if len(consumed) > 0 {
+				// Keep what was already deleted rather than dropping it
+				log.Printf("ConsumeMessages: Error receiving from queue %s after %d messages: %v", queueURL, len(consumed), err)
+				break
+			}
+			log.Printf("ConsumeMessages: Error receiving from queue %s: %v", queueURL, err)
+			writeSQSError(w, r, err, queueURL)
+			return
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+
+		fresh := 0
+		for _, msg := range result.Messages {
+			messageID := aws.ToString(msg.MessageId)
+			if seen[messageID] || len(consumed) >= limit || ctx.Err() != nil {
+				continue
+			}
+			seen[messageID] = true
+			fresh++
+
+			consumed = append(consumed, ConvertMessage(msg))
+			if _, err := h.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(queueURL),
+				ReceiptHandle: msg.ReceiptHandle,
+			}); err != nil {
+				log.Printf("ConsumeMessages: Failed to delete consumed message %s: %v", messageID, err)
+				failed = append(failed, consumeFailure{MessageID: messageID, Error: err.Error()})
+			}
+		}
+
+		if fresh == 0 {
+			break
+		}
+	}
+
+	deleted := len(consumed) - len(failed)
+	log.Printf("ConsumeMessages: Consumed %d messages from queue %s (%d deleted)", len(consumed), queueURL, deleted)
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:        statusConsumed,
+		AffectedCount: affected(deleted),
+		Details: map[string]interface{}{
+			"messages": consumed,
+			"failed":   failed,
+		},
+	})
+}
@@ -0,0 +1,165 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+type consumeResponse struct {
+	Status        string `json:"status"`
+	AffectedCount int    `json:"affectedCount"`
+	Details       struct {
+		Messages []internal_types.Message `json:"messages"`
+		Failed   []consumeFailure         `json:"failed"`
+	} `json:"details"`
+}
+
+func consumeReq(queueURL, query string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/consume"+query, nil)
+	return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+}
+
+func TestSQSHandler_ConsumeMessages_Demo(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	queuedIDs := func() map[string]bool {
+		result, err := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+			QueueUrl: aws.String(queueURL), MaxNumberOfMessages: 100,
+		})
+		if err != nil {
+			t.Fatalf("failed to read demo queue: %v", err)
+		}
+		ids := make(map[string]bool, len(result.Messages))
+		for _, msg := range result.Messages {
+			ids[aws.ToString(msg.MessageId)] = true
+		}
+		return ids
+	}
+
+	before := queuedIDs()
+	if len(before) < 3 {
+		t.Fatalf("expected at least 3 demo messages, got %d", len(before))
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ConsumeMessages(rr, consumeReq(queueURL, "?max=2"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp consumeResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != statusConsumed || resp.AffectedCount != 2 || len(resp.Details.Messages) != 2 {
+		t.Fatalf("expected 2 consumed messages, got %+v", resp)
+	}
+
+	after := queuedIDs()
+	if len(after) != len(before)-2 {
+		t.Errorf("expected %d messages left, got %d", len(before)-2, len(after))
+	}
+	for _, msg := range resp.Details.Messages {
+		if !before[msg.MessageId] {
+			t.Errorf("consumed message %s was not on the queue", msg.MessageId)
+		}
+		if after[msg.MessageId] {
+			t.Errorf("consumed message %s is still on the queue", msg.MessageId)
+		}
+		delete(before, msg.MessageId)
+	}
+	for id := range before {
+		if !after[id] {
+			t.Errorf("message %s was removed without being returned", id)
+		}
+	}
+}
+
+func TestSQSHandler_ConsumeMessages_Errors(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	t.Run("invalid max", func(t *testing.T) {
+		for _, query := range []string{"?max=0", "?max=-1", "?max=abc"} {
+			handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+			rr := httptest.NewRecorder()
+			handler.ConsumeMessages(rr, consumeReq(queueURL, query))
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400, got %d", query, rr.Code)
+			}
+		}
+	})
+
+	t.Run("max is capped", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		for i := 0; i < maxConsumeMax+5; i++ {
+			mockClient.AddMessage(queueURL, fmt.Sprintf("msg-%d", i), "body")
+		}
+		handler := &SQSHandler{Client: mockClient}
+
+		rr := httptest.NewRecorder()
+		handler.ConsumeMessages(rr, consumeReq(queueURL, "?max=1000"))
+
+		var resp consumeResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Details.Messages) != maxConsumeMax {
+			t.Errorf("expected %d messages, got %d", maxConsumeMax, len(resp.Details.Messages))
+		}
+	})
+
+	t.Run("failed delete still returns the message", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.AddMessage(queueURL, "msg-1", "body")
+		mockClient.SetError("DeleteMessage", errors.New("AccessDenied"))
+		handler := &SQSHandler{Client: mockClient}
+
+		rr := httptest.NewRecorder()
+		handler.ConsumeMessages(rr, consumeReq(queueURL, ""))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		var resp consumeResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Details.Messages) != 1 || resp.AffectedCount != 0 {
+			t.Errorf("expected the message returned with 0 deleted, got %+v", resp)
+		}
+		if len(resp.Details.Failed) != 1 || resp.Details.Failed[0].MessageID != "msg-1" {
+			t.Errorf("expected msg-1 reported as failed, got %+v", resp.Details.Failed)
+		}
+	})
+
+	t.Run("receive error", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.SetError("ReceiveMessage", errors.New("AWS unavailable"))
+		handler := &SQSHandler{Client: mockClient}
+
+		rr := httptest.NewRecorder()
+		handler.ConsumeMessages(rr, consumeReq(queueURL, ""))
+
+		if rr.Code < 400 {
+			t.Errorf("expected an error status, got %d", rr.Code)
+		}
+		if len(mockClient.DeleteMessageCalls) != 0 {
+			t.Errorf("expected no deletes, got %d", len(mockClient.DeleteMessageCalls))
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package sqs
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// Content types reported on messages so the UI can pick a renderer.
+const (
+	contentTypeJSON  = "application/json"
+	contentTypeXML   = "application/xml"
+	contentTypePlain = "text/plain"
+)
+
+// maxXMLTokens bounds how much of a body is tokenized when checking for XML,
+// keeping inference cheap for large payloads.
+const maxXMLTokens = 1000
+
+// inferContentType guesses a body's content type. It is purely heuristic: a
+// parse is only attempted when the first non-space character suggests JSON or
+// XML, so plain text never pays for a parse.
+func inferContentType(body string) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return contentTypePlain
+	}
+
+	switch trimmed[0] {
+	case '{', '[':
+		if json.Valid([]byte(trimmed)) {
+			return contentTypeJSON
+		}
+	case '<':
+		if looksLikeXML(trimmed) {
+			return contentTypeXML
+		}
+	}
+
+	return contentTypePlain
+}
+
+// looksLikeXML reports whether body tokenizes as XML with at least one element.
+// Bodies longer than maxXMLTokens tokens are accepted once that many tokens
+// have parsed cleanly.
+func looksLikeXML(body string) bool {
+	decoder := xml.NewDecoder(strings.NewReader(body))
+	sawElement := false
+
+	for i := 0; i < maxXMLTokens; i++ {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return sawElement
+		}
+		if err != nil {
+			return false
+		}
+		if _, ok := token.(xml.StartElement); ok {
+			sawElement = true
+		}
+	}
+
+	return sawElement
+}
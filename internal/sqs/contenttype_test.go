@@ -0,0 +1,70 @@
+package sqs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestInferContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{"json object", `{"orderId": "ord-001", "total": 42.5}`, contentTypeJSON},
+		{"json array", ` [1, 2, 3] `, contentTypeJSON},
+		{"xml document", `<?xml version="1.0"?><order id="ord-001"><total>42.5</total></order>`, contentTypeXML},
+		{"xml fragment", `<event type="click"/>`, contentTypeXML},
+		{"plain text", "Order ord-001 shipped", contentTypePlain},
+		{"empty body", "", contentTypePlain},
+		{"broken json", `{"orderId": `, contentTypePlain},
+		{"unclosed xml", `<order><total>42.5</total>`, contentTypePlain},
+		{"angle bracket text", "<3 thanks for the order", contentTypePlain},
+		{"bare json scalar", `"just a string"`, contentTypePlain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := inferContentType(tt.body); got != tt.expected {
+				t.Errorf("inferContentType(%q) = %q, want %q", tt.body, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessages_ContentType(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+	mockClient.AddMessageWithTimestamp(queueURL, "json-msg", `{"orderId": "ord-001"}`, "1640995203000")
+	mockClient.AddMessageWithTimestamp(queueURL, "xml-msg", `<order id="ord-001"/>`, "1640995202000")
+	mockClient.AddMessageWithTimestamp(queueURL, "text-msg", "hello world", "1640995201000")
+
+	handler := &SQSHandler{Client: mockClient}
+	rr := httptest.NewRecorder()
+	handler.GetMessages(rr, getMessagesReq(queueURL, ""))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	expected := map[string]string{
+		"json-msg": contentTypeJSON,
+		"xml-msg":  contentTypeXML,
+		"text-msg": contentTypePlain,
+	}
+
+	msgs := decodeMessages(t, rr)
+	if len(msgs) != len(expected) {
+		t.Fatalf("Expected %d messages, got %d", len(expected), len(msgs))
+	}
+	for _, msg := range msgs {
+		if msg.ContentType != expected[msg.MessageId] {
+			t.Errorf("Message %s: expected contentType %q, got %q", msg.MessageId, expected[msg.MessageId], msg.ContentType)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultSQSPricePerMillion is the standard-queue price in USD per
+	// million requests when SQS_PRICE_PER_MILLION_REQUESTS is not set.
+	defaultSQSPricePerMillion = 0.40
+	// defaultFIFOPricePerMillion is the FIFO-queue price in USD per million
+	// requests when SQS_FIFO_PRICE_PER_MILLION_REQUESTS is not set.
+	defaultFIFOPricePerMillion = 0.50
+	// defaultPollMinutes is the length of the estimated poll session when
+	// ?pollMinutes= is not given.
+	defaultPollMinutes = 60
+)
+
+// requestCounts is the number of SQS API requests an operation makes, each
+// batch call counting as one request.
+type requestCounts struct {
+	Receives int `json:"receives"`
+	Sends    int `json:"sends"`
+	Deletes  int `json:"deletes"`
+	Total    int `json:"total"`
+}
+
+// operationEstimate is the request count and rough cost of one operation.
+type operationEstimate struct {
+	Requests requestCounts `json:"requests"`
+	CostUSD  float64       `json:"costUsd"`
+}
+
+// queueCostEstimate is the estimate for one queue.
+type queueCostEstimate struct {
+	QueueURL string                       `json:"queueUrl"`
+	Depth    int                          `json:"depth"`
+	FIFO     bool                         `json:"fifo"`
+	Estimate map[string]operationEstimate `json:"estimate"`
+}
+
+// batches returns how many calls of batchSize cover count messages.
+func batches(count, batchSize int) int {
+	if count <= 0 {
+		return 0
+	}
+	return (count + batchSize - 1) / batchSize
+}
+
+// drainRequests counts the requests to receive and delete depth messages in
+// batches, plus the final empty receive that ends the drain.
+func drainRequests(depth, batchSize int) requestCounts {
+	counts := requestCounts{
+		Receives: batches(depth, batchSize) + 1,
+		Deletes:  batches(depth, batchSize),
+	}
+	counts.Total = counts.Receives + counts.Deletes
+	return counts
+}
+
+// redriveRequests counts the requests to move depth messages to another
+// queue: a drain plus a batched send of every message.
+func redriveRequests(depth, batchSize int) requestCounts {
+	counts := drainRequests(depth, batchSize)
+	counts.Sends = batches(depth, batchSize)
+	counts.Total += counts.Sends
+	return counts
+}
+
+// pollRequests counts the receives of a WebSocket poll session lasting
+// duration, one per StreamPollInterval regardless of depth.
+func pollRequests(duration time.Duration) requestCounts {
+	receives := int((duration + StreamPollInterval - 1) / StreamPollInterval)
+	return requestCounts{Receives: receives, Total: receives}
+}
+
+// pricePerMillionFromEnv returns the USD price per million requests in the
+// named variable, falling back to fallback when unset or invalid.
+func pricePerMillionFromEnv(name string, fallback float64) float64 {
+	if value := os.Getenv(name); value != "" {
+		if price, err := strconv.ParseFloat(value, 64); err == nil && price >= 0 {
+			return price
+		}
+		log.Printf("Invalid %s %q, using %.2f", name, value, fallback)
+	}
+	return fallback
+}
+
+// requestCost prices a request count at pricePerMillion USD.
+func requestCost(counts requestCounts, pricePerMillion float64) float64 {
+	return float64(counts.Total) * pricePerMillion / 1_000_000
+}
+
+// GetCostEstimate handles HTTP requests to estimate the SQS request cost of
+// draining, redriving and polling queues at their current depth (visible
+// plus delayed messages). The queues are the repeated ?queueUrl= values, or
+// the tag-filtered queue list when none is given. ?batchSize= (1-10, default
+// 10) sets the messages per batch call and ?pollMinutes= the poll session
+// length. Prices come from SQS_PRICE_PER_MILLION_REQUESTS and
+// SQS_FIFO_PRICE_PER_MILLION_REQUESTS; the figures are rough, ignoring free
+// tier and the extra request billed per 64 KiB of payload.
+func (h *SQSHandler) GetCostEstimate(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	batchSize := sqsMaxReceive
+	if sizeParam := query.Get("batchSize"); sizeParam != "" {
+		parsed, err := strconv.Atoi(sizeParam)
+		if err != nil || parsed < 1 || parsed > sqsMaxReceive {
+			http.Error(w, "batchSize must be between 1 and 10", http.StatusBadRequest)
+			return
+		}
+		batchSize = parsed
+	}
+
+	pollMinutes := defaultPollMinutes
+	if minutesParam := query.Get("pollMinutes"); minutesParam != "" {
+		parsed, err := strconv.Atoi(minutesParam)
+		if err != nil || parsed < 0 {
+			http.Error(w, "pollMinutes must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		pollMinutes = parsed
+	}
+
+	ctx := r.Context()
+	queueURLs := query["queueUrl"]
+	for i := range queueURLs {
+		queueURLs[i] = normalizeQueueURL(queueURLs[i])
+	}
+	if len(queueURLs) == 0 {
+		queues, err := h.ListFilteredQueues(ctx, 1000)
+		if err != nil {
+			log.Printf("GetCostEstimate: Error listing queues: %v", err)
+			writeSQSError(w, r, err, "")
+			return
+		}
+		for _, queue := range queues {
+			queueURLs = append(queueURLs, queue.URL)
+		}
+	}
+
+	standardPrice := pricePerMillionFromEnv("SQS_PRICE_PER_MILLION_REQUESTS", defaultSQSPricePerMillion)
+	fifoPrice := pricePerMillionFromEnv("SQS_FIFO_PRICE_PER_MILLION_REQUESTS", defaultFIFOPricePerMillion)
+	poll := pollRequests(time.Duration(pollMinutes) * time.Minute)
+
+	estimates := []queueCostEstimate{}
+	totals := map[string]operationEstimate{}
+	for _, queueURL := range queueURLs {
+		attributes, err := h.queueAttributes(ctx, queueURL)
+		if err != nil {
+			writeSQSError(w, r, err, queueURL)
+			return
+		}
+
+		depth := parseIntSafe(attributes["ApproximateNumberOfMessages"]) + parseIntSafe(attributes["ApproximateNumberOfMessagesDelayed"])
+		fifo := isFIFOQueue(queueURL)
+		price := standardPrice
+		if fifo {
+			price = fifoPrice
+		}
+
+		estimate := queueCostEstimate{
+			QueueURL: queueURL,
+			Depth:    depth,
+			FIFO:     fifo,
+			Estimate: map[string]operationEstimate{},
+		}
+		for operation, counts := range map[string]requestCounts{
+			"drain":   drainRequests(depth, batchSize),
+			"redrive": redriveRequests(depth, batchSize),
+			"poll":    poll,
+		} {
+			operationCost := operationEstimate{Requests: counts, CostUSD: requestCost(counts, price)}
+			estimate.Estimate[operation] = operationCost
+
+			total := totals[operation]
+			total.Requests.Receives += counts.Receives
+			total.Requests.Sends += counts.Sends
+			total.Requests.Deletes += counts.Deletes
+			total.Requests.Total += counts.Total
+			total.CostUSD += operationCost.CostUSD
+			totals[operation] = total
+		}
+		if maskAccountIDsEnabled() {
+			estimate.QueueURL = maskURL(estimate.QueueURL)
+		}
+		estimates = append(estimates, estimate)
+	}
+
+	writeJSON(w, r, map[string]interface{}{
+		"batchSize":   batchSize,
+		"pollMinutes": pollMinutes,
+		"queues":      estimates,
+		"total":       totals,
+	})
+}
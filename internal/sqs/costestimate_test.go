@@ -0,0 +1,125 @@
+package sqs
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestRequestCounts(t *testing.T) {
+	tests := []struct {
+		name            string
+		depth           int
+		batchSize       int
+		expectedDrain   requestCounts
+		expectedRedrive requestCounts
+	}{
+		{
+			name:            "empty queue costs one receive",
+			depth:           0,
+			batchSize:       10,
+			expectedDrain:   requestCounts{Receives: 1, Total: 1},
+			expectedRedrive: requestCounts{Receives: 1, Total: 1},
+		},
+		{
+			name:            "full batches",
+			depth:           1000,
+			batchSize:       10,
+			expectedDrain:   requestCounts{Receives: 101, Deletes: 100, Total: 201},
+			expectedRedrive: requestCounts{Receives: 101, Sends: 100, Deletes: 100, Total: 301},
+		},
+		{
+			name:            "partial last batch",
+			depth:           25,
+			batchSize:       10,
+			expectedDrain:   requestCounts{Receives: 4, Deletes: 3, Total: 7},
+			expectedRedrive: requestCounts{Receives: 4, Sends: 3, Deletes: 3, Total: 10},
+		},
+		{
+			name:            "unbatched",
+			depth:           25,
+			batchSize:       1,
+			expectedDrain:   requestCounts{Receives: 26, Deletes: 25, Total: 51},
+			expectedRedrive: requestCounts{Receives: 26, Sends: 25, Deletes: 25, Total: 76},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := drainRequests(tt.depth, tt.batchSize); got != tt.expectedDrain {
+				t.Errorf("drain: expected %+v, got %+v", tt.expectedDrain, got)
+			}
+			if got := redriveRequests(tt.depth, tt.batchSize); got != tt.expectedRedrive {
+				t.Errorf("redrive: expected %+v, got %+v", tt.expectedRedrive, got)
+			}
+		})
+	}
+
+	if got := pollRequests(time.Hour); got.Receives != 720 || got.Total != 720 {
+		t.Errorf("poll: expected 720 receives an hour, got %+v", got)
+	}
+}
+
+func TestSQSHandler_GetCostEstimate(t *testing.T) {
+	const standardURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	const fifoURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo"
+
+	t.Setenv("SQS_PRICE_PER_MILLION_REQUESTS", "")
+	t.Setenv("SQS_FIFO_PRICE_PER_MILLION_REQUESTS", "1")
+
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+	query := url.Values{"queueUrl": {standardURL, fifoURL}, "batchSize": {"2"}, "pollMinutes": {"10"}}
+	rr := httptest.NewRecorder()
+	handler.GetCostEstimate(rr, httptest.NewRequest("GET", "/api/cost-estimate?"+query.Encode(), nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var response struct {
+		Queues []queueCostEstimate          `json:"queues"`
+		Total  map[string]operationEstimate `json:"total"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Queues) != 2 {
+		t.Fatalf("expected 2 queues, got %d", len(response.Queues))
+	}
+
+	// The mock reports a depth of 5: 3 batches of 2 plus the empty receive
+	standard := response.Queues[0]
+	if standard.Depth != 5 || standard.FIFO {
+		t.Errorf("expected a standard queue of depth 5, got %+v", standard)
+	}
+	if drain := standard.Estimate["drain"]; drain.Requests.Total != 7 || math.Abs(drain.CostUSD-7*0.40/1e6) > 1e-12 {
+		t.Errorf("expected 7 drain requests at the default price, got %+v", drain)
+	}
+	if poll := standard.Estimate["poll"]; poll.Requests.Receives != 120 {
+		t.Errorf("expected 120 poll receives in 10 minutes, got %+v", poll)
+	}
+
+	fifo := response.Queues[1]
+	if redrive := fifo.Estimate["redrive"]; !fifo.FIFO || redrive.Requests.Total != 10 || math.Abs(redrive.CostUSD-10/1e6) > 1e-12 {
+		t.Errorf("expected 10 FIFO redrive requests at the FIFO price, got %+v", fifo)
+	}
+	if total := response.Total["redrive"]; total.Requests.Total != 20 || total.Requests.Sends != 6 {
+		t.Errorf("expected 20 redrive requests in total, got %+v", total)
+	}
+}
+
+func TestSQSHandler_GetCostEstimate_InvalidParameters(t *testing.T) {
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+	for _, query := range []string{"?batchSize=0", "?batchSize=11", "?pollMinutes=-1"} {
+		rr := httptest.NewRecorder()
+		handler.GetCostEstimate(rr, httptest.NewRequest("GET", "/api/cost-estimate"+query, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, rr.Code)
+		}
+	}
+}
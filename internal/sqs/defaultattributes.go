@@ -0,0 +1,85 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// defaultMessageAttributes parses DEFAULT_MESSAGE_ATTRIBUTES, a JSON object
+// of attributes in the SendMessage form (plain strings or {"dataType",
+// "value"}), e.g. {"Source": "sqs-ui"}. It returns nil when unset.
+func defaultMessageAttributes() (map[string]sendAttribute, error) {
+	value := os.Getenv("DEFAULT_MESSAGE_ATTRIBUTES")
+	if value == "" {
+		return nil, nil
+	}
+	var defaults map[string]sendAttribute
+	if err := json.Unmarshal([]byte(value), &defaults); err != nil {
+		return nil, fmt.Errorf("DEFAULT_MESSAGE_ATTRIBUTES must be a JSON object of attributes: %w", err)
+	}
+	return defaults, nil
+}
+
+// ValidateDefaultMessageAttributes reports a malformed
+// DEFAULT_MESSAGE_ATTRIBUTES, or a value not matching its data type, so
+// startup can reject it rather than failing every send.
+func ValidateDefaultMessageAttributes() error {
+	defaults, err := defaultMessageAttributes()
+	if err != nil {
+		return err
+	}
+	if _, typeErr := typedMessageAttributes(defaults); typeErr != nil {
+		return fmt.Errorf("DEFAULT_MESSAGE_ATTRIBUTES: %w", typeErr)
+	}
+	return nil
+}
+
+// withDefaultAttributes returns attributes with the DEFAULT_MESSAGE_ATTRIBUTES
+// added for every name the request did not set itself, so a request
+// overrides a default key by key. A malformed setting adds nothing.
+func withDefaultAttributes(attributes map[string]sendAttribute) map[string]sendAttribute {
+	defaults, err := defaultMessageAttributes()
+	if err != nil {
+		log.Printf("Ignoring %v", err)
+		return attributes
+	}
+	if len(defaults) == 0 {
+		return attributes
+	}
+
+	merged := make(map[string]sendAttribute, len(defaults)+len(attributes))
+	for name, value := range defaults {
+		merged[name] = value
+	}
+	for name, value := range attributes {
+		merged[name] = value
+	}
+	return merged
+}
+
+// withDefaultAttributeValues is withDefaultAttributes for sends that already
+// hold typed attributes, such as batch entries and edited resends.
+func withDefaultAttributeValues(attributes map[string]types.MessageAttributeValue) map[string]types.MessageAttributeValue {
+	defaults, err := defaultMessageAttributes()
+	if err != nil {
+		log.Printf("Ignoring %v", err)
+		return attributes
+	}
+	typed, typeErr := typedMessageAttributes(defaults)
+	if typeErr != nil {
+		log.Printf("Ignoring DEFAULT_MESSAGE_ATTRIBUTES: %v", typeErr)
+		return attributes
+	}
+	if len(typed) == 0 {
+		return attributes
+	}
+
+	for name, value := range attributes {
+		typed[name] = value
+	}
+	return typed
+}
@@ -0,0 +1,114 @@
+package sqs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_SendMessage_DefaultAttributes(t *testing.T) {
+	t.Setenv("DEFAULT_MESSAGE_ATTRIBUTES", `{"Source": "sqs-ui", "SentBy": "ops", "Revision": {"dataType": "Number", "value": "3"}}`)
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name       string
+		attributes string
+		expected   map[string]string
+	}{
+		{
+			name:     "no attributes get the defaults",
+			expected: map[string]string{"Source": "sqs-ui", "SentBy": "ops", "Revision": "3"},
+		},
+		{
+			name:       "a request attribute overrides just its key",
+			attributes: `, "attributes": {"SentBy": "alice", "Ticket": "OPS-1"}`,
+			expected:   map[string]string{"Source": "sqs-ui", "SentBy": "alice", "Revision": "3", "Ticket": "OPS-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			handler := &SQSHandler{Client: mockClient}
+
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", strings.NewReader(`{"body": "hello"`+tt.attributes+`}`))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.SendMessage(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			sent := mockClient.SendMessageCalls[0].MessageAttributes
+			if len(sent) != len(tt.expected) {
+				t.Errorf("expected %d attributes, got %d", len(tt.expected), len(sent))
+			}
+			for name, value := range tt.expected {
+				if got := aws.ToString(sent[name].StringValue); got != value {
+					t.Errorf("attribute %s: expected %q, got %q", name, value, got)
+				}
+			}
+			if got := aws.ToString(sent["Revision"].DataType); got != "Number" {
+				t.Errorf("expected the default's data type Number, got %q", got)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_ImportMessages_DefaultAttributes(t *testing.T) {
+	t.Setenv("DEFAULT_MESSAGE_ATTRIBUTES", `{"Source": "sqs-ui", "SentBy": "ops"}`)
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	mockClient := helpers.NewMockSQSClient()
+	handler := &SQSHandler{Client: mockClient}
+
+	content := `{"body": "first"}` + "\n" + `{"body": "second", "attributes": {"SentBy": "alice"}}`
+	rr := httptest.NewRecorder()
+	handler.ImportMessages(rr, importReq(t, queueURL, content))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(mockClient.SendMessageBatchCalls) != 1 {
+		t.Fatalf("expected 1 batch, got %d", len(mockClient.SendMessageBatchCalls))
+	}
+	expected := []map[string]string{
+		{"Source": "sqs-ui", "SentBy": "ops"},
+		{"Source": "sqs-ui", "SentBy": "alice"},
+	}
+	entries := mockClient.SendMessageBatchCalls[0].Entries
+	for i, entry := range entries {
+		if len(entry.MessageAttributes) != len(expected[i]) {
+			t.Errorf("entry %d: expected %d attributes, got %d", i, len(expected[i]), len(entry.MessageAttributes))
+		}
+		for name, value := range expected[i] {
+			if got := aws.ToString(entry.MessageAttributes[name].StringValue); got != value {
+				t.Errorf("entry %d attribute %s: expected %q, got %q", i, name, value, got)
+			}
+		}
+	}
+}
+
+func TestValidateDefaultMessageAttributes(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{"", false},
+		{`{"Source": "sqs-ui"}`, false},
+		{`["Source"]`, true},
+		{`{"Count": {"dataType": "Number", "value": "many"}}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("DEFAULT_MESSAGE_ATTRIBUTES", tt.value)
+		if err := ValidateDefaultMessageAttributes(); (err != nil) != tt.wantErr {
+			t.Errorf("%q: expected error %v, got %v", tt.value, tt.wantErr, err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// defaultQueueFromEnv returns DEFAULT_QUEUE, the queue name or URL the UI
+// selects on load, or "" when unset.
+func defaultQueueFromEnv() string {
+	return normalizeQueueURL(os.Getenv("DEFAULT_QUEUE"))
+}
+
+// queueNameFromURL returns the last path segment of a queue URL, or the
+// input unchanged when it is already a bare name.
+func queueNameFromURL(queueURL string) string {
+	return queueURL[strings.LastIndex(queueURL, "/")+1:]
+}
+
+// CheckDefaultQueue verifies in live mode that DEFAULT_QUEUE names a queue
+// ListQueues can see. It returns nil when the variable is unset or in demo
+// mode; callers should only warn on error, since the UI falls back to no
+// selection.
+func (h *SQSHandler) CheckDefaultQueue(ctx context.Context) error {
+	defaultQueue := defaultQueueFromEnv()
+	if defaultQueue == "" || h.isDemo {
+		return nil
+	}
+
+	name := queueNameFromURL(defaultQueue)
+	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{
+		QueueNamePrefix: aws.String(name),
+	})
+	if err != nil {
+		return fmt.Errorf("DEFAULT_QUEUE %q could not be checked: %w", defaultQueue, err)
+	}
+
+	for _, queueURL := range result.QueueUrls {
+		if queueURL == defaultQueue || queueNameFromURL(queueURL) == defaultQueue {
+			return nil
+		}
+	}
+	return fmt.Errorf("DEFAULT_QUEUE %q does not match a listable queue", defaultQueue)
+}
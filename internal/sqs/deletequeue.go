@@ -0,0 +1,62 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// queueDeletionNote explains SQS's eventual consistency after DeleteQueue.
+const queueDeletionNote = "SQS can take up to 60 seconds to delete a queue; it may still be listed or accept messages until then, and its name cannot be reused for 60 seconds."
+
+// DeleteQueue handles HTTP requests to delete a queue and all its messages.
+// It requires ?confirm=true so a stray request cannot drop a queue, and
+// answers 404 when the queue does not exist. Once deleted, the queue is
+// dropped from the caches along with its alarms and UI metadata, so a queue
+// later created under the same name starts clean.
+func (h *SQSHandler) DeleteQueue(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if r.URL.Query().Get("confirm") != "true" {
+		http.Error(w, "deleting a queue requires confirm=true", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Client.DeleteQueue(context.Background(), &sqs.DeleteQueueInput{
+		QueueUrl: aws.String(queueURL),
+	})
+	if err != nil {
+		log.Printf("DeleteQueue: Error deleting queue %s: %v", queueURL, err)
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+	setRequestID(w, result.ResultMetadata)
+
+	h.forgetDeletedQueue(queueURL)
+	if removed := h.alarms.removeQueue(queueURL); removed > 0 {
+		log.Printf("DeleteQueue: Dropped %d alarms on queue %s", removed, queueURL)
+	}
+	if h.uiMetadata.get(queueURL) != nil {
+		if err := h.uiMetadata.put(queueURL, json.RawMessage("{}")); err != nil {
+			log.Printf("DeleteQueue: Error clearing UI metadata of queue %s: %v", queueURL, err)
+		}
+	}
+
+	log.Printf("DeleteQueue: Deleted queue %s", queueURL)
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status: statusQueueDeleted,
+		Details: map[string]string{
+			"queueUrl": queueURL,
+			"note":     queueDeletionNote,
+		},
+	})
+}
@@ -0,0 +1,111 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_DeleteQueue(t *testing.T) {
+	const demoQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	tests := []struct {
+		name           string
+		queueURL       string
+		query          string
+		expectedStatus int
+		expectDeleted  bool
+	}{
+		{name: "missing confirm", queueURL: demoQueueURL, expectedStatus: http.StatusBadRequest},
+		{name: "confirm not true", queueURL: demoQueueURL, query: "?confirm=yes", expectedStatus: http.StatusBadRequest},
+		{name: "confirmed deletion", queueURL: demoQueueURL, query: "?confirm=true", expectedStatus: http.StatusOK, expectDeleted: true},
+		{name: "unknown queue", queueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/missing", query: "?confirm=true", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := demo.NewDemoSQSClient()
+			handler := &SQSHandler{Client: client, isDemo: true}
+
+			req := httptest.NewRequest("DELETE", "/api/queues/{queueUrl}"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": tt.queueURL})
+			rr := httptest.NewRecorder()
+
+			handler.DeleteQueue(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+
+			listed, err := client.ListQueues(context.Background(), &awssqs.ListQueuesInput{})
+			if err != nil {
+				t.Fatalf("ListQueues failed: %v", err)
+			}
+			if got := slices.Contains(listed.QueueUrls, demoQueueURL); got == tt.expectDeleted {
+				t.Errorf("expected queue listed = %v, got %v", !tt.expectDeleted, got)
+			}
+
+			if !tt.expectDeleted {
+				return
+			}
+			var result types.OperationResult
+			if err := json.NewDecoder(rr.Body).Decode(&result); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if result.Status != statusQueueDeleted {
+				t.Errorf("expected status %q, got %q", statusQueueDeleted, result.Status)
+			}
+
+			received, err := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+				QueueUrl:            aws.String(demoQueueURL),
+				MaxNumberOfMessages: 10,
+			})
+			if err != nil {
+				t.Fatalf("ReceiveMessage failed: %v", err)
+			}
+			if len(received.Messages) != 0 {
+				t.Errorf("expected the deleted queue's messages dropped, got %d", len(received.Messages))
+			}
+		})
+	}
+}
+
+func TestSQSHandler_DeleteQueue_DropsQueueState(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	const otherURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue"
+
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+	for _, url := range []string{queueURL, otherURL} {
+		handler.alarms.add(Alarm{QueueURL: url, Metric: alarmMetricMessages, Threshold: 10})
+		if err := handler.uiMetadata.put(url, json.RawMessage(`{"color":"red"}`)); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("DELETE", "/api/queues/{queueUrl}?confirm=true", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+	rr := httptest.NewRecorder()
+	handler.DeleteQueue(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if alarms := handler.alarms.list(queueURL); len(alarms) != 0 {
+		t.Errorf("expected the deleted queue's alarms dropped, got %+v", alarms)
+	}
+	if metadata := handler.uiMetadata.get(queueURL); metadata != nil {
+		t.Errorf("expected the deleted queue's UI metadata dropped, got %s", metadata)
+	}
+	if len(handler.alarms.list(otherURL)) != 1 || handler.uiMetadata.get(otherURL) == nil {
+		t.Error("expected other queues' alarms and UI metadata kept")
+	}
+}
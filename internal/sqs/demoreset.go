@@ -0,0 +1,33 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// demoResetter is implemented by the demo client.
+type demoResetter interface {
+	Reset()
+}
+
+// ResetDemo handles HTTP requests to restore the demo queues and messages to
+// their seeded state, undoing sends, deletes and moves made while
+// experimenting. It is 404 outside demo mode. Cached queue attributes are
+// dropped so counts reflect the reset immediately.
+func (h *SQSHandler) ResetDemo(w http.ResponseWriter, r *http.Request) {
+	client, ok := h.Client.(demoResetter)
+	if !h.isDemo || !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	client.Reset()
+	if h.attributeCache != nil {
+		h.attributeCache.clear()
+	}
+
+	log.Printf("ResetDemo: Demo data reset to its seeded state")
+	writeOperationResult(w, r, internal_types.OperationResult{Status: statusDemoReset})
+}
@@ -0,0 +1,92 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_ResetDemo(t *testing.T) {
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+	handler.EnableAttributeCache()
+	ctx := context.Background()
+
+	depths := func() map[string]string {
+		t.Helper()
+		listed, err := client.ListQueues(ctx, &awssqs.ListQueuesInput{})
+		if err != nil {
+			t.Fatalf("ListQueues failed: %v", err)
+		}
+		counts := make(map[string]string, len(listed.QueueUrls))
+		for _, queueURL := range listed.QueueUrls {
+			attributes, err := handler.queueAttributes(ctx, queueURL)
+			if err != nil {
+				t.Fatalf("queueAttributes failed for %s: %v", queueURL, err)
+			}
+			counts[queueURL] = attributes["ApproximateNumberOfMessages"]
+		}
+		return counts
+	}
+	seeded := depths()
+
+	const ordersURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", bytes.NewBufferString(`{"body":"experiment"}`))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": ordersURL})
+		handler.SendMessage(httptest.NewRecorder(), req)
+	}
+	if _, err := client.DeleteMessage(ctx, &awssqs.DeleteMessageInput{
+		QueueUrl:      aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue"),
+		ReceiptHandle: aws.String("receipt-dlq-001"),
+	}); err != nil {
+		t.Fatalf("DeleteMessage failed: %v", err)
+	}
+	handler.attributeCache.clear()
+	if changed := depths(); changed[ordersURL] == seeded[ordersURL] {
+		t.Fatalf("expected the sends to change the orders queue depth, still %s", changed[ordersURL])
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ResetDemo(rr, httptest.NewRequest("POST", "/api/demo/reset", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	restored := depths()
+	if len(restored) != len(seeded) {
+		t.Fatalf("expected %d queues after reset, got %d", len(seeded), len(restored))
+	}
+	for queueURL, count := range seeded {
+		if restored[queueURL] != count {
+			t.Errorf("expected %s messages in %s after reset, got %s", count, queueURL, restored[queueURL])
+		}
+	}
+
+	// Sent message IDs are not reused after a reset
+	sent, err := client.SendMessage(ctx, &awssqs.SendMessageInput{QueueUrl: aws.String(ordersURL), MessageBody: aws.String("after")})
+	if err != nil {
+		t.Fatalf("SendMessage failed: %v", err)
+	}
+	if id := aws.ToString(sent.MessageId); id != "demo-msg-4" {
+		t.Errorf("expected message IDs to keep counting after reset, got %s", id)
+	}
+}
+
+func TestSQSHandler_ResetDemo_NotDemoMode(t *testing.T) {
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+
+	rr := httptest.NewRecorder()
+	handler.ResetDemo(rr, httptest.NewRequest("POST", "/api/demo/reset", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 outside demo mode, got %d", rr.Code)
+	}
+}
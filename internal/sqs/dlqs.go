@@ -0,0 +1,221 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// defaultDLQListLimit is how many queues GET /api/dlqs lists before picking
+// out the dead-letter queues, unless ?limit= says otherwise.
+const defaultDLQListLimit = 100
+
+// isDeadLetterQueue reports whether a queue is a DLQ: it has a
+// RedriveAllowPolicy, or its name ends in -dlq or -DLQ.
+func isDeadLetterQueue(queueName string, attributes map[string]string) bool {
+	return strings.HasSuffix(queueName, "-dlq") ||
+		strings.HasSuffix(queueName, "-DLQ") ||
+		attributes["RedriveAllowPolicy"] != ""
+}
+
+// deadLetterTargetARN returns the DLQ ARN named by a queue's RedrivePolicy,
+// or "" when it has none.
+func deadLetterTargetARN(attributes map[string]string) string {
+	var policy struct {
+		DeadLetterTargetARN string `json:"deadLetterTargetArn"`
+	}
+	if err := json.Unmarshal([]byte(attributes["RedrivePolicy"]), &policy); err != nil {
+		return ""
+	}
+	return policy.DeadLetterTargetARN
+}
+
+// dlqSourceQueue is a queue that redrives its failed messages to a DLQ.
+type dlqSourceQueue struct {
+	Name       string `json:"name"`
+	URL        string `json:"url"`
+	DisplayURL string `json:"displayUrl,omitempty"`
+}
+
+// deadLetterQueue is one DLQ listed by ListDeadLetterQueues.
+type deadLetterQueue struct {
+	Name                string           `json:"name"`
+	URL                 string           `json:"url"`
+	DisplayURL          string           `json:"displayUrl,omitempty"`
+	ApproximateMessages int              `json:"approximateMessages"`
+	ApproximateInFlight int              `json:"approximateInFlight"`
+	SourceQueues        []dlqSourceQueue `json:"sourceQueues"`
+}
+
+// deadLetterQueues picks the DLQs out of queues, each with the listed queues
+// whose RedrivePolicy targets it.
+func deadLetterQueues(queues []internal_types.Queue) []deadLetterQueue {
+	dlqs := []deadLetterQueue{}
+	for _, queue := range queues {
+		if !isDeadLetterQueue(queue.Name, queue.Attributes) {
+			continue
+		}
+
+		dlq := deadLetterQueue{
+			Name:                queue.Name,
+			URL:                 queue.URL,
+			DisplayURL:          queue.DisplayURL,
+			ApproximateMessages: parseIntSafe(queue.Attributes["ApproximateNumberOfMessages"]),
+			ApproximateInFlight: parseIntSafe(queue.Attributes["ApproximateNumberOfMessagesNotVisible"]),
+			SourceQueues:        []dlqSourceQueue{},
+		}
+		if arn := queue.Attributes["QueueArn"]; arn != "" {
+			for _, source := range queues {
+				if source.URL != queue.URL && deadLetterTargetARN(source.Attributes) == arn {
+					dlq.SourceQueues = append(dlq.SourceQueues, dlqSourceQueue{
+						Name:       source.Name,
+						URL:        source.URL,
+						DisplayURL: source.DisplayURL,
+					})
+				}
+			}
+		}
+		dlqs = append(dlqs, dlq)
+	}
+	return dlqs
+}
+
+// ListDeadLetterQueues handles HTTP requests for the dead-letter queues among
+// the tag-filtered queue list, each with its depth and the listed queues that
+// redrive to it. Detection matches GetQueueStatistics' isDLQ.
+func (h *SQSHandler) ListDeadLetterQueues(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+
+	limit := int32(defaultDLQListLimit)
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = int32(parsedLimit)
+		}
+	}
+
+	disableTagFilter, requiredTags, err := tagFilterForRequest(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{
+		MaxResults: aws.Int32(limit),
+	})
+	if err != nil {
+		log.Printf("ListDeadLetterQueues: Error fetching queues: %v", err)
+		writeSQSError(w, r, err, "")
+		return
+	}
+	setRequestID(w, result.ResultMetadata)
+
+	dlqs := deadLetterQueues(h.filterQueues(ctx, result.QueueUrls, disableTagFilter, requiredTags))
+	log.Printf("ListDeadLetterQueues: Found %d DLQs among %d queues", len(dlqs), len(result.QueueUrls))
+
+	writeJSON(w, r, dlqs)
+}
+
+// maxSourceQueuePages bounds how many ListDeadLetterSourceQueues pages
+// GetDeadLetterSources follows.
+const maxSourceQueuePages = 10
+
+// nativeDeadLetterSources lists the queues redriving to queueURL with the
+// SQS ListDeadLetterSourceQueues API.
+func (h *SQSHandler) nativeDeadLetterSources(ctx context.Context, queueURL string) ([]string, error) {
+	sources := []string{}
+	var nextToken *string
+	for page := 0; page < maxSourceQueuePages; page++ {
+		result, err := h.Client.ListDeadLetterSourceQueues(ctx, &sqs.ListDeadLetterSourceQueuesInput{
+			QueueUrl:   aws.String(queueURL),
+			MaxResults: aws.Int32(1000),
+			NextToken:  nextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+		sources = append(sources, result.QueueUrls...)
+		if nextToken = result.NextToken; nextToken == nil {
+			break
+		}
+	}
+	return sources, nil
+}
+
+// scannedDeadLetterSources finds the queues redriving to queueURL by reading
+// every listed queue's RedrivePolicy.
+func (h *SQSHandler) scannedDeadLetterSources(ctx context.Context, queueURL string) ([]string, error) {
+	attributes, err := h.queueAttributes(ctx, queueURL)
+	if err != nil {
+		return nil, err
+	}
+	arn := attributes["QueueArn"]
+
+	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{MaxResults: aws.Int32(1000)})
+	if err != nil {
+		return nil, err
+	}
+	noteRequestID(ctx, result.ResultMetadata)
+
+	sources := []string{}
+	for _, candidate := range result.QueueUrls {
+		if candidate == queueURL {
+			continue
+		}
+		candidateAttributes, err := h.queueAttributes(ctx, candidate)
+		if err != nil {
+			log.Printf("GetDeadLetterSources: Skipping queue %s: %v", candidate, err)
+			continue
+		}
+		if arn != "" && deadLetterTargetARN(candidateAttributes) == arn {
+			sources = append(sources, candidate)
+		}
+	}
+	return sources, nil
+}
+
+// GetDeadLetterSources handles HTTP requests for the queues whose
+// RedrivePolicy targets a queue. It asks SQS via ListDeadLetterSourceQueues
+// and only falls back to scanning RedrivePolicies when that call fails; the
+// response's "method" says which was used.
+func (h *SQSHandler) GetDeadLetterSources(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+	ctx := r.Context()
+
+	method := "native"
+	sourceURLs, err := h.nativeDeadLetterSources(ctx, queueURL)
+	if err != nil && !isQueueNotFound(err) {
+		log.Printf("GetDeadLetterSources: ListDeadLetterSourceQueues failed for %s, scanning redrive policies: %v", queueURL, err)
+		method = "scan"
+		sourceURLs, err = h.scannedDeadLetterSources(ctx, queueURL)
+	}
+	if err != nil {
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+
+	sources := make([]dlqSourceQueue, 0, len(sourceURLs))
+	for _, sourceURL := range sourceURLs {
+		source := dlqSourceQueue{Name: queueNameFromURL(sourceURL), URL: sourceURL}
+		if maskAccountIDsEnabled() {
+			source.DisplayURL = maskURL(sourceURL)
+		}
+		sources = append(sources, source)
+	}
+
+	writeJSON(w, r, map[string]interface{}{
+		"queueUrl": queueURL,
+		"sources":  sources,
+		"method":   method,
+	})
+}
@@ -0,0 +1,165 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_ListDeadLetterQueues(t *testing.T) {
+	const dlqURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue"
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedSources string
+	}{
+		{name: "default tag filter", expectedSources: "demo-orders-queue,demo-payments-queue"},
+		{name: "tag filter disabled", query: "?tagFilter=disabled", expectedSources: "demo-orders-queue,demo-notifications-queue,demo-payments-queue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := demo.NewDemoSQSClient()
+			handler := &SQSHandler{Client: client, isDemo: true}
+
+			depth, err := client.GetQueueAttributes(context.Background(), &awssqs.GetQueueAttributesInput{QueueUrl: aws.String(dlqURL)})
+			if err != nil {
+				t.Fatalf("GetQueueAttributes failed: %v", err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ListDeadLetterQueues(rr, httptest.NewRequest("GET", "/api/dlqs"+tt.query, nil))
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			var dlqs []deadLetterQueue
+			if err := json.NewDecoder(rr.Body).Decode(&dlqs); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(dlqs) != 1 {
+				t.Fatalf("expected only the deadletter queue, got %+v", dlqs)
+			}
+
+			dlq := dlqs[0]
+			if dlq.Name != "demo-deadletter-queue" || dlq.URL != dlqURL {
+				t.Errorf("unexpected DLQ %s (%s)", dlq.Name, dlq.URL)
+			}
+			if expected := parseIntSafe(depth.Attributes["ApproximateNumberOfMessages"]); dlq.ApproximateMessages != expected || expected == 0 {
+				t.Errorf("expected depth %d, got %d", expected, dlq.ApproximateMessages)
+			}
+
+			var sources []string
+			for _, source := range dlq.SourceQueues {
+				sources = append(sources, source.Name)
+			}
+			if got := strings.Join(sources, ","); got != tt.expectedSources {
+				t.Errorf("expected sources %s, got %s", tt.expectedSources, got)
+			}
+		})
+	}
+}
+
+func TestIsDeadLetterQueue(t *testing.T) {
+	tests := []struct {
+		name       string
+		queueName  string
+		attributes map[string]string
+		expected   bool
+	}{
+		{name: "redrive allow policy", queueName: "failures", attributes: map[string]string{"RedriveAllowPolicy": `{"redrivePermission":"allowAll"}`}, expected: true},
+		{name: "lowercase suffix", queueName: "orders-dlq", expected: true},
+		{name: "uppercase suffix", queueName: "orders-DLQ", expected: true},
+		{name: "source queue", queueName: "orders", attributes: map[string]string{"RedrivePolicy": `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:orders-dlq"}`}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDeadLetterQueue(tt.queueName, tt.attributes); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// failingSourcesClient makes the native ListDeadLetterSourceQueues call fail.
+type failingSourcesClient struct {
+	*demo.DemoSQSClient
+}
+
+func (c *failingSourcesClient) ListDeadLetterSourceQueues(ctx context.Context, params *awssqs.ListDeadLetterSourceQueuesInput, optFns ...func(*awssqs.Options)) (*awssqs.ListDeadLetterSourceQueuesOutput, error) {
+	return nil, errors.New("access denied")
+}
+
+func TestSQSHandler_GetDeadLetterSources(t *testing.T) {
+	const base = "https://sqs.us-east-1.amazonaws.com/123456789012/"
+
+	tests := []struct {
+		name            string
+		queueURL        string
+		nativeFails     bool
+		expectedStatus  int
+		expectedSources string
+		expectedMethod  string
+	}{
+		{name: "native sources of the DLQ", queueURL: base + "demo-deadletter-queue", expectedStatus: http.StatusOK, expectedSources: "demo-orders-queue,demo-notifications-queue,demo-payments-queue", expectedMethod: "native"},
+		{name: "queue without sources", queueURL: base + "demo-analytics-queue", expectedStatus: http.StatusOK, expectedSources: "", expectedMethod: "native"},
+		{name: "scan fallback when native fails", queueURL: base + "demo-deadletter-queue", nativeFails: true, expectedStatus: http.StatusOK, expectedSources: "demo-orders-queue,demo-notifications-queue,demo-payments-queue", expectedMethod: "scan"},
+		{name: "unknown queue", queueURL: base + "missing", expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var client SQSClientInterface = demo.NewDemoSQSClient()
+			if tt.nativeFails {
+				client = &failingSourcesClient{DemoSQSClient: client.(*demo.DemoSQSClient)}
+			}
+			handler := &SQSHandler{Client: client, isDemo: true}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/sources", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": tt.queueURL})
+			rr := httptest.NewRecorder()
+
+			handler.GetDeadLetterSources(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Sources []dlqSourceQueue `json:"sources"`
+				Method  string           `json:"method"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response.Sources == nil {
+				t.Fatal("expected sources to be a list, got null")
+			}
+			var names []string
+			for _, source := range response.Sources {
+				names = append(names, source.Name)
+			}
+			if got := strings.Join(names, ","); got != tt.expectedSources {
+				t.Errorf("expected sources %q, got %q", tt.expectedSources, got)
+			}
+			if response.Method != tt.expectedMethod {
+				t.Errorf("expected method %q, got %q", tt.expectedMethod, response.Method)
+			}
+		})
+	}
+}
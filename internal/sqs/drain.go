@@ -0,0 +1,212 @@
+package sqs
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// defaultDrainConcurrency is how many receives a drain keeps in flight
+	// when DRAIN_CONCURRENCY is not set.
+	defaultDrainConcurrency = 4
+	// defaultExportMax is how many messages an export takes when ?max= is
+	// not given.
+	defaultExportMax = 1000
+	// maxDrainMessages bounds a single drain so a busy queue cannot keep the
+	// drain loop running indefinitely.
+	maxDrainMessages = 10000
+)
+
+// drainConcurrencyFromEnv returns DRAIN_CONCURRENCY, falling back to
+// defaultDrainConcurrency when unset or invalid.
+func drainConcurrencyFromEnv() int {
+	if value := os.Getenv("DRAIN_CONCURRENCY"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+		log.Printf("Invalid DRAIN_CONCURRENCY %q, using %d", value, defaultDrainConcurrency)
+	}
+	return defaultDrainConcurrency
+}
+
+// drainMessages receives up to maxTotal distinct messages from a queue with
+// up to concurrency receives in flight, returning them in receive order (see
+// drainBatches). A concurrency of 1 is the serial drain.
+func (h *SQSHandler) drainMessages(ctx context.Context, queueURL string, maxTotal, concurrency int) ([]types.Message, error) {
+	var drained []types.Message
+	err := h.drainBatches(ctx, queueURL, maxTotal, concurrency, func(batch []types.Message) error {
+		drained = append(drained, batch...)
+		return nil
+	})
+	return drained, err
+}
+
+// drainBatches receives up to maxTotal distinct messages from a queue with up
+// to concurrency receives in flight, handing each receive's new messages to
+// onBatch as they arrive. onBatch calls do not overlap. Received messages stay
+// hidden for the queue's visibility timeout, so concurrent receives return
+// disjoint batches; the drain ends once a receive yields nothing new, the cap
+// is reached or ctx is done. The first receive or onBatch error stops the
+// other workers and is returned.
+func (h *SQSHandler) drainBatches(ctx context.Context, queueURL string, maxTotal, concurrency int, onBatch func([]types.Message) error) error {
+	maxReceive := int32(sqsMaxReceive)
+	if h.isDemo {
+		maxReceive = 1000
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		claimed  int
+		seen     = make(map[string]bool)
+		firstErr error
+		wg       sync.WaitGroup
+	)
+
+	// claim hands on a batch, reporting whether the worker should go on
+	claim := func(messages []types.Message) bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fresh := make([]types.Message, 0, len(messages))
+		for _, msg := range messages {
+			messageID := aws.ToString(msg.MessageId)
+			if seen[messageID] || claimed+len(fresh) >= maxTotal {
+				continue
+			}
+			seen[messageID] = true
+			fresh = append(fresh, msg)
+		}
+		claimed += len(fresh)
+		if len(fresh) > 0 && firstErr == nil {
+			if err := onBatch(fresh); err != nil {
+				firstErr = err
+				cancel()
+				return false
+			}
+		}
+		if len(fresh) == 0 || claimed >= maxTotal {
+			cancel()
+			return false
+		}
+		return true
+	}
+
+	for range max(1, concurrency) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				result, err := h.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+					QueueUrl:              aws.String(queueURL),
+					MaxNumberOfMessages:   maxReceive,
+					WaitTimeSeconds:       1,
+					AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+					MessageAttributeNames: []string{"All"},
+				})
+				if err != nil {
+					mu.Lock()
+					// Receives cut short by the drain finishing are not failures
+					if firstErr == nil && ctx.Err() == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+					cancel()
+					return
+				}
+				noteRequestID(ctx, result.ResultMetadata)
+				if !claim(result.Messages) {
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// ExportMessages handles HTTP requests to drain up to ?max= messages
+// (default 1000, at most 10000) from a queue without deleting them. Batches
+// are received concurrently (DRAIN_CONCURRENCY) and streamed as a JSON list
+// in receive order as they arrive. The exported messages are made visible
+// again once the export ends, so an export changes nothing on the queue
+// beyond each message's receive count.
+func (h *SQSHandler) ExportMessages(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	limit := defaultExportMax
+	if maxParam := r.URL.Query().Get("max"); maxParam != "" {
+		parsed, err := strconv.Atoi(maxParam)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "max must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, maxDrainMessages)
+	}
+
+	// Only the receipt handles are kept, for the release below
+	var (
+		list     *jsonListWriter
+		received []types.Message
+	)
+	err := h.drainBatches(r.Context(), queueURL, limit, drainConcurrencyFromEnv(), func(batch []types.Message) error {
+		for _, msg := range batch {
+			received = append(received, types.Message{MessageId: msg.MessageId, ReceiptHandle: msg.ReceiptHandle})
+		}
+		if list == nil {
+			var err error
+			if list, err = newJSONListWriter(w, r); err != nil {
+				return err
+			}
+		}
+		for _, msg := range batch {
+			message := ConvertMessage(msg)
+			if maskAccountIDsEnabled() {
+				message = maskMessage(message)
+			}
+			if err := list.Add(message); err != nil {
+				return err
+			}
+		}
+		return list.Flush()
+	})
+	// Released even when the client went away, so nothing stays hidden
+	ReleaseMessages(context.WithoutCancel(r.Context()), h.Client, queueURL, received)
+
+	switch {
+	case err != nil && list == nil:
+		log.Printf("ExportMessages: Error draining queue %s: %v", queueURL, err)
+		writeSQSError(w, r, err, queueURL)
+		return
+	case err != nil:
+		// The list is already committed; it ends short of the cap
+		log.Printf("ExportMessages: Error after exporting %d messages from queue %s: %v", len(received), queueURL, err)
+	default:
+		log.Printf("ExportMessages: Exported %d messages from queue %s", len(received), queueURL)
+	}
+
+	if list == nil {
+		list, err = newJSONListWriter(w, r)
+		if err != nil {
+			log.Printf("ExportMessages: Error streaming messages for queue %s: %v", queueURL, err)
+			return
+		}
+	}
+	if err := list.Close(); err != nil {
+		log.Printf("ExportMessages: Error streaming messages for queue %s: %v", queueURL, err)
+	}
+}
@@ -0,0 +1,229 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+const drainQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+// hidingClient wraps the demo client with SQS visibility semantics: a
+// received message is not returned again, so concurrent receives see
+// disjoint batches as they would against a real queue.
+type hidingClient struct {
+	*demo.DemoSQSClient
+	mu     sync.Mutex
+	hidden map[string]bool
+}
+
+func (c *hidingClient) ReceiveMessage(ctx context.Context, params *awssqs.ReceiveMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error) {
+	all := *params
+	all.MaxNumberOfMessages = 1000
+	result, err := c.DemoSQSClient.ReceiveMessage(ctx, &all, optFns...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	visible := []sqstypes.Message{}
+	for _, msg := range result.Messages {
+		if len(visible) == int(params.MaxNumberOfMessages) {
+			break
+		}
+		if c.hidden[aws.ToString(msg.MessageId)] {
+			continue
+		}
+		c.hidden[aws.ToString(msg.MessageId)] = true
+		visible = append(visible, msg)
+	}
+	return &awssqs.ReceiveMessageOutput{Messages: visible}, nil
+}
+
+// newDrainHandler returns a handler over a demo queue holding count extra
+// messages, with DEMO_LATENCY_MS applied to every call.
+func newDrainHandler(tb testing.TB, count int, latencyMs string) *SQSHandler {
+	tb.Setenv("DEMO_LATENCY_MS", latencyMs)
+	tb.Setenv("DEMO_ERROR_RATE", "")
+	client := &hidingClient{DemoSQSClient: demo.NewDemoSQSClient(), hidden: make(map[string]bool)}
+	for i := range count {
+		if _, err := client.DemoSQSClient.SendMessage(context.Background(), &awssqs.SendMessageInput{
+			QueueUrl:    aws.String(drainQueueURL),
+			MessageBody: aws.String(fmt.Sprintf(`{"n": %d}`, i)),
+		}); err != nil {
+			tb.Fatalf("failed to seed message: %v", err)
+		}
+	}
+	return &SQSHandler{Client: client}
+}
+
+func drainedIDs(messages []sqstypes.Message) []string {
+	ids := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		ids = append(ids, aws.ToString(msg.MessageId))
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+func TestSQSHandler_DrainMessages_ConcurrentMatchesSerial(t *testing.T) {
+	serial, err := newDrainHandler(t, 95, "").drainMessages(context.Background(), drainQueueURL, maxDrainMessages, 1)
+	if err != nil {
+		t.Fatalf("serial drain failed: %v", err)
+	}
+	concurrent, err := newDrainHandler(t, 95, "").drainMessages(context.Background(), drainQueueURL, maxDrainMessages, 8)
+	if err != nil {
+		t.Fatalf("concurrent drain failed: %v", err)
+	}
+
+	if len(serial) < 95 {
+		t.Fatalf("expected the serial drain to empty the queue, got %d messages", len(serial))
+	}
+	if !slices.Equal(drainedIDs(serial), drainedIDs(concurrent)) {
+		t.Errorf("expected the same messages, serial drained %d and concurrent %d", len(serial), len(concurrent))
+	}
+}
+
+func TestSQSHandler_DrainMessages_Limits(t *testing.T) {
+	t.Run("stops at max total", func(t *testing.T) {
+		drained, err := newDrainHandler(t, 95, "").drainMessages(context.Background(), drainQueueURL, 25, 4)
+		if err != nil {
+			t.Fatalf("drain failed: %v", err)
+		}
+		if len(drained) != 25 {
+			t.Errorf("expected 25 messages, got %d", len(drained))
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		drained, err := newDrainHandler(t, 95, "").drainMessages(ctx, drainQueueURL, maxDrainMessages, 4)
+		if err != nil || len(drained) != 0 {
+			t.Errorf("expected an empty drain, got %d messages and %v", len(drained), err)
+		}
+	})
+
+	t.Run("receive error", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.SetError("ReceiveMessage", errors.New("AccessDenied"))
+		handler := &SQSHandler{Client: mockClient}
+		if _, err := handler.drainMessages(context.Background(), drainQueueURL, maxDrainMessages, 4); err == nil {
+			t.Error("expected the receive error")
+		}
+	})
+}
+
+func TestSQSHandler_ExportMessages(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{name: "capped by max", query: "?max=30", expectedStatus: http.StatusOK, expectedCount: 30},
+		{name: "invalid max", query: "?max=0", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newDrainHandler(t, 50, "")
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/export"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": drainQueueURL})
+			rr := httptest.NewRecorder()
+
+			handler.ExportMessages(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var messages []types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(messages) != tt.expectedCount {
+				t.Errorf("expected %d messages, got %d", tt.expectedCount, len(messages))
+			}
+		})
+	}
+}
+
+func TestSQSHandler_ExportMessages_ReleasesExported(t *testing.T) {
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue(drainQueueURL)
+	for i := range 5 {
+		mock.AddMessage(drainQueueURL, fmt.Sprintf("msg-%d", i), "body")
+	}
+	handler := &SQSHandler{Client: mock}
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/export", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": drainQueueURL})
+	rr := httptest.NewRecorder()
+	handler.ExportMessages(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var messages []types.Message
+	if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	calls := mock.ChangeMessageVisibilityInputs()
+	if len(messages) != 5 || len(calls) != 5 {
+		t.Fatalf("expected 5 exported and released messages, got %d exported and %d released", len(messages), len(calls))
+	}
+	for _, call := range calls {
+		if call.QueueURL != drainQueueURL || call.VisibilityTimeout != 0 {
+			t.Errorf("expected an immediate release on the exported queue, got %+v", call)
+		}
+	}
+}
+
+func TestSQSHandler_ExportMessages_ReceiveError(t *testing.T) {
+	mock := helpers.NewMockSQSClient()
+	mock.SetError("ReceiveMessage", &sqstypes.QueueDoesNotExist{})
+	handler := &SQSHandler{Client: mock}
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/export", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": drainQueueURL})
+	rr := httptest.NewRecorder()
+	handler.ExportMessages(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 before anything is streamed, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func BenchmarkDrainMessages(b *testing.B) {
+	for _, concurrency := range []int{1, 4} {
+		b.Run(fmt.Sprintf("concurrency=%d", concurrency), func(b *testing.B) {
+			for range b.N {
+				b.StopTimer()
+				handler := newDrainHandler(b, 200, "5")
+				b.StartTimer()
+				if _, err := handler.drainMessages(context.Background(), drainQueueURL, maxDrainMessages, concurrency); err != nil {
+					b.Fatalf("drain failed: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+// EditResendMessage handles HTTP requests to fix a message in place: the
+// original, found by "messageId", is sent again with the given "body" and/or
+// "attributes" to its own queue or to "targetQueueUrl", then deleted with the
+// receipt handle in the path. Attributes not given keep their original
+// values, as do the trace header and FIFO message group. The original must be
+// visible to be found, otherwise 404 and nothing is sent.
+func (h *SQSHandler) EditResendMessage(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+	receiptHandle := mux.Vars(r)["receiptHandle"]
+
+	var payload struct {
+		MessageID string  `json:"messageId"`
+		Body      *string `json:"body"`
+		// Attributes are plain strings or {"dataType", "value"} objects
+		Attributes             map[string]sendAttribute `json:"attributes"`
+		TargetQueueURL         string                   `json:"targetQueueUrl"`
+		MessageDeduplicationID string                   `json:"messageDeduplicationId"`
+	}
+	if !decodeLimitedJSON(w, r, &payload) {
+		return
+	}
+	if payload.MessageID == "" {
+		http.Error(w, "messageId required", http.StatusBadRequest)
+		return
+	}
+	if payload.Body == nil && len(payload.Attributes) == 0 {
+		http.Error(w, "body or attributes required", http.StatusBadRequest)
+		return
+	}
+
+	targetURL := queueURL
+	if payload.TargetQueueURL != "" {
+		targetURL = normalizeQueueURL(payload.TargetQueueURL)
+		if targetURL != queueURL && !retryTargetAllowed(targetURL) {
+			log.Printf("EditResendMessage: Refusing target %s, not allowed by RETRY_TARGET_ALLOW", targetURL)
+			http.Error(w, "targetQueueUrl is not allowed by RETRY_TARGET_ALLOW", http.StatusForbidden)
+			return
+		}
+	}
+
+	edited, typeErr := typedMessageAttributes(payload.Attributes)
+	if typeErr != nil {
+		log.Printf("EditResendMessage: Refusing message for queue %s: %v", targetURL, typeErr)
+		writeAttributeTypeError(w, r, typeErr)
+		return
+	}
+
+	ctx := r.Context()
+	found, err := h.lookupMessages(ctx, queueURL, []string{payload.MessageID})
+	if err != nil {
+		log.Printf("EditResendMessage: Error looking up message %s: %v", payload.MessageID, err)
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+	original, ok := found[payload.MessageID]
+	if !ok {
+		http.Error(w, fmt.Sprintf("message %s not found; it may be in flight or already deleted", payload.MessageID), http.StatusNotFound)
+		return
+	}
+
+	input := editedSendInput(original, targetURL, payload.Body, edited)
+	if size := sqsMessageSize(aws.ToString(input.MessageBody), input.MessageAttributes); size.exceedsLimit() {
+		log.Printf("EditResendMessage: Refusing %d byte message for queue %s", size.TotalBytes, targetURL)
+		writeMessageTooLarge(w, r, size, nil)
+		return
+	}
+	// A FIFO resend may need its own deduplication ID
+	if payload.MessageDeduplicationID != "" && input.MessageDeduplicationId != nil {
+		input.MessageDeduplicationId = aws.String(payload.MessageDeduplicationID)
+	}
+
+	result, err := h.Client.SendMessage(ctx, input)
+	if err != nil {
+		log.Printf("EditResendMessage: Error sending to queue %s: %v", targetURL, err)
+		writeSQSError(w, r, err, targetURL)
+		return
+	}
+	setRequestID(w, result.ResultMetadata)
+
+	// The edited copy is out, so a failed delete is reported rather than
+	// failing the request
+	originalDeleted := h.deleteOriginal(ctx, queueURL, receiptHandle)
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:    statusResent,
+		MessageId: aws.ToString(result.MessageId),
+		Details: map[string]interface{}{
+			"originalMessageId": payload.MessageID,
+			"originalDeleted":   originalDeleted,
+		},
+	})
+}
+
+// editedSendInput builds the send of original to targetURL with body, when
+// given, and the edited attributes laid over the original ones and any
+// DEFAULT_MESSAGE_ATTRIBUTES the message lacks. FIFO targets
+// keep the message's group and use its ID for deduplication.
+func editedSendInput(original types.Message, targetURL string, body *string, edited map[string]types.MessageAttributeValue) *sqs.SendMessageInput {
+	attributes := make(map[string]types.MessageAttributeValue, len(original.MessageAttributes)+len(edited))
+	for name, value := range original.MessageAttributes {
+		attributes[name] = value
+	}
+	for name, value := range edited {
+		attributes[name] = value
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:                aws.String(targetURL),
+		MessageBody:             original.Body,
+		MessageAttributes:       withDefaultAttributeValues(attributes),
+		MessageSystemAttributes: traceHeaderAttributes(original.Attributes[string(types.MessageSystemAttributeNameAWSTraceHeader)]),
+	}
+	if body != nil {
+		input.MessageBody = aws.String(*body)
+	}
+	if isFIFOQueue(targetURL) {
+		groupID := original.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)]
+		if groupID == "" {
+			groupID = "edited"
+		}
+		input.MessageGroupId = aws.String(groupID)
+		input.MessageDeduplicationId = original.MessageId
+	}
+	return input
+}
+
+// deleteOriginal deletes the edited message's original, reporting whether
+// it succeeded.
+func (h *SQSHandler) deleteOriginal(ctx context.Context, queueURL, receiptHandle string) bool {
+	if _, err := h.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: aws.String(receiptHandle),
+	}); err != nil {
+		log.Printf("EditResendMessage: Warning - failed to delete original from %s: %v", queueURL, err)
+		return false
+	}
+	return true
+}
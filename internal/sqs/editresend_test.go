@@ -0,0 +1,112 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+func editResend(handler *SQSHandler, queueURL, receiptHandle string, payload map[string]interface{}) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(payload)
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages/{receiptHandle}/edit-resend", bytes.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL, "receiptHandle": receiptHandle})
+	rr := httptest.NewRecorder()
+	handler.EditResendMessage(rr, req)
+	return rr
+}
+
+func TestSQSHandler_EditResendMessage(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	const fixedBody = `{"orderId": "12345", "status": "fixed"}`
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	rr := editResend(handler, queueURL, "receipt-ord-001", map[string]interface{}{
+		"messageId":  "ord-001",
+		"body":       fixedBody,
+		"attributes": map[string]interface{}{"Source": "support-fix"},
+	})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var result internal_types.OperationResult
+	if err := json.NewDecoder(rr.Body).Decode(&result); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if result.Status != statusResent || result.MessageId == "" {
+		t.Errorf("expected a resent result with the new message ID, got %+v", result)
+	}
+	if details, _ := result.Details.(map[string]interface{}); details["originalDeleted"] != true {
+		t.Errorf("expected the original deleted, got %v", result.Details)
+	}
+
+	received, err := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: 10,
+	})
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+	resent := false
+	for _, msg := range received.Messages {
+		switch aws.ToString(msg.MessageId) {
+		case "ord-001":
+			t.Error("expected the original removed from the queue")
+		case result.MessageId:
+			if got := aws.ToString(msg.Body); got != fixedBody {
+				t.Errorf("expected the edited body %s, got %s", fixedBody, got)
+			}
+			if got := aws.ToString(msg.MessageAttributes["Source"].StringValue); got != "support-fix" {
+				t.Errorf("expected the edited Source attribute, got %q", got)
+			}
+			if got := aws.ToString(msg.MessageAttributes["Priority"].StringValue); got != "high" {
+				t.Errorf("expected the unspecified Priority attribute kept, got %q", got)
+			}
+			resent = true
+		}
+	}
+	if !resent {
+		t.Fatal("expected the edited message in the queue")
+	}
+}
+
+func TestSQSHandler_EditResendMessage_Errors(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	tests := []struct {
+		name           string
+		payload        map[string]interface{}
+		expectedStatus int
+	}{
+		{"missing messageId", map[string]interface{}{"body": "x"}, http.StatusBadRequest},
+		{"nothing edited", map[string]interface{}{"messageId": "ord-001"}, http.StatusBadRequest},
+		{"unknown message", map[string]interface{}{"messageId": "gone", "body": "x"}, http.StatusNotFound},
+		{"invalid attribute", map[string]interface{}{"messageId": "ord-001", "attributes": map[string]interface{}{"Count": map[string]string{"dataType": "Number", "value": "many"}}}, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := demo.NewDemoSQSClient()
+			handler := &SQSHandler{Client: client, isDemo: true}
+			if rr := editResend(handler, queueURL, "receipt-ord-001", tt.payload); rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+
+			// Nothing was sent or deleted
+			attrs, _ := client.GetQueueAttributes(context.Background(), &awssqs.GetQueueAttributesInput{QueueUrl: aws.String(queueURL)})
+			if attrs.Attributes["ApproximateNumberOfMessages"] != "3" {
+				t.Errorf("expected the queue untouched, got %s messages", attrs.Attributes["ApproximateNumberOfMessages"])
+			}
+		})
+	}
+}
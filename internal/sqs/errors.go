@@ -0,0 +1,147 @@
+package sqs
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+)
+
+// nonExistentQueueCode is the code SQS returns for a missing queue over the
+// query protocol; the SDK surfaces it as a generic API error rather than
+// types.QueueDoesNotExist.
+const nonExistentQueueCode = "AWS.SimpleQueueService.NonExistentQueue"
+
+// isQueueNotFound reports whether err means the queue no longer exists. Only
+// the specific not-found codes match, so throttling and other transient
+// failures are never mistaken for a deleted queue.
+func isQueueNotFound(err error) bool {
+	var notFound *types.QueueDoesNotExist
+	if errors.As(err, &notFound) {
+		return true
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		code := apiErr.ErrorCode()
+		return code == nonExistentQueueCode || code == "QueueDoesNotExist"
+	}
+
+	return false
+}
+
+// accessDeniedCodes are the codes SQS returns when the credentials may not
+// act on the queue.
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+}
+
+// IsFatalPollError reports whether a receive error will not go away by
+// retrying: the queue is gone or access to it is denied. Throttling and
+// other transient errors are not fatal.
+func IsFatalPollError(err error) bool {
+	if isQueueNotFound(err) {
+		return true
+	}
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && accessDeniedCodes[apiErr.ErrorCode()]
+}
+
+// sqsErrorMapping is the HTTP status and user-facing hint for an SQS error code.
+type sqsErrorMapping struct {
+	status int
+	hint   string
+}
+
+// sqsErrorStatuses maps SQS error codes (both the query-protocol and the
+// modelled names) to HTTP statuses. Codes not listed stay 500.
+var sqsErrorStatuses = map[string]sqsErrorMapping{
+	"OverLimit": {http.StatusTooManyRequests,
+		"The queue has reached a limit (e.g. too many in-flight messages). Wait for consumers to catch up, then retry."},
+	"RequestThrottled": {http.StatusTooManyRequests,
+		"SQS is throttling requests. Retry after a short delay."},
+	"ThrottlingException": {http.StatusTooManyRequests,
+		"SQS is throttling requests. Retry after a short delay."},
+	"KmsThrottled": {http.StatusTooManyRequests,
+		"KMS is throttling encryption requests for this queue. Retry after a short delay."},
+	"QueueDoesNotExist": {http.StatusNotFound,
+		"The queue does not exist or was deleted."},
+	nonExistentQueueCode: {http.StatusNotFound,
+		"The queue does not exist or was deleted."},
+	"InvalidMessageContents": {http.StatusBadRequest,
+		"The message contains characters outside the allowed Unicode set."},
+	"AWS.SimpleQueueService.InvalidBatchEntryId": {http.StatusBadRequest,
+		"A batch entry ID is invalid."},
+	"AWS.SimpleQueueService.PurgeQueueInProgress": {http.StatusConflict,
+		"A purge is already in progress for this queue."},
+	"PurgeQueueInProgress": {http.StatusConflict,
+		"A purge is already in progress for this queue."},
+}
+
+// fifoParameterCodes are the parameter errors SQS returns when a FIFO send
+// lacks a MessageGroupId or a deduplication ID (without content-based
+// deduplication). On FIFO queues they signal a conflict with the queue's
+// configuration rather than a malformed request.
+var fifoParameterCodes = map[string]bool{
+	"MissingParameter":      true,
+	"InvalidParameterValue": true,
+}
+
+// isFIFOParameterError reports whether code and message describe a missing or
+// invalid FIFO send parameter; other parameter errors on FIFO queues, such as
+// an oversized delay, stay unmapped.
+func isFIFOParameterError(code, message string) bool {
+	return fifoParameterCodes[code] &&
+		(strings.Contains(message, "MessageGroupId") || strings.Contains(message, "MessageDeduplicationId"))
+}
+
+const fifoParameterHint = "FIFO queues require a MessageGroupId, and a MessageDeduplicationId unless content-based deduplication is enabled."
+
+// sqsErrorResponse is the JSON body written for mapped SQS errors.
+type sqsErrorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+	// RequestID is the AWS request ID of the failed call, when known
+	RequestID string `json:"requestId,omitempty"`
+}
+
+// writeSQSError writes err as a descriptive JSON error with the status its SQS
+// code maps to (see sqsErrorStatuses), or 500 for unmapped codes; errors that
+// are not SQS API errors, such as network failures, get a plain 500. The AWS
+// request ID, when the error carries one, is set in X-Amz-Request-Id.
+func writeSQSError(w http.ResponseWriter, r *http.Request, err error, queueURL string) {
+	requestID := setErrorRequestID(w, err)
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	code := apiErr.ErrorCode()
+	mapping, ok := sqsErrorStatuses[code]
+	if !ok && isFIFOQueue(queueURL) && isFIFOParameterError(code, apiErr.ErrorMessage()) {
+		mapping, ok = sqsErrorMapping{http.StatusConflict, fifoParameterHint}, true
+	}
+	if !ok {
+		// Unmapped SQS errors still get the JSON body, so the request ID
+		// reaches the client
+		mapping = sqsErrorMapping{status: http.StatusInternalServerError}
+	}
+
+	message := apiErr.ErrorMessage()
+	if message == "" {
+		message = err.Error()
+	}
+
+	writeJSONStatus(w, r, mapping.status, sqsErrorResponse{
+		Error:     code,
+		Message:   message,
+		Hint:      mapping.hint,
+		RequestID: requestID,
+	})
+}
@@ -0,0 +1,259 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/aws/smithy-go"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestIsQueueNotFound(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"typed QueueDoesNotExist", &sqstypes.QueueDoesNotExist{}, true},
+		{"wrapped QueueDoesNotExist", fmt.Errorf("get attributes: %w", &sqstypes.QueueDoesNotExist{}), true},
+		{"query protocol code", &smithy.GenericAPIError{Code: "AWS.SimpleQueueService.NonExistentQueue"}, true},
+		{"throttling", &smithy.GenericAPIError{Code: "ThrottlingException"}, false},
+		{"plain error", errors.New("connection reset"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isQueueNotFound(tt.err); got != tt.expected {
+				t.Errorf("isQueueNotFound(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsFatalPollError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"queue does not exist", &sqstypes.QueueDoesNotExist{}, true},
+		{"query protocol not found", &smithy.GenericAPIError{Code: "AWS.SimpleQueueService.NonExistentQueue"}, true},
+		{"access denied", &smithy.GenericAPIError{Code: "AccessDenied"}, true},
+		{"wrapped access denied", fmt.Errorf("receive: %w", &smithy.GenericAPIError{Code: "AccessDeniedException"}), true},
+		{"throttling", &smithy.GenericAPIError{Code: "ThrottlingException"}, false},
+		{"plain error", errors.New("connection reset"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsFatalPollError(tt.err); got != tt.expected {
+				t.Errorf("IsFatalPollError(%v) = %v, want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+// perQueueErrorClient fails GetQueueAttributes for selected queue URLs.
+type perQueueErrorClient struct {
+	*helpers.MockSQSClient
+	attributeErrors map[string]error
+}
+
+func (c *perQueueErrorClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	if err, ok := c.attributeErrors[aws.ToString(params.QueueUrl)]; ok {
+		return nil, err
+	}
+	return c.MockSQSClient.GetQueueAttributes(ctx, params, optFns...)
+}
+
+func TestSQSHandler_ListQueues_DropsDeletedQueues(t *testing.T) {
+	const (
+		liveURL      = "https://sqs.us-east-1.amazonaws.com/123456789012/evict-live-queue"
+		deletedURL   = "https://sqs.us-east-1.amazonaws.com/123456789012/evict-deleted-queue"
+		throttledURL = "https://sqs.us-east-1.amazonaws.com/123456789012/evict-throttled-queue"
+	)
+
+	for _, disabled := range []string{"true", ""} {
+		t.Run("DISABLE_TAG_FILTER="+disabled, func(t *testing.T) {
+			t.Setenv("DISABLE_TAG_FILTER", disabled)
+
+			mockClient := helpers.NewMockSQSClient()
+			for _, url := range []string{liveURL, deletedURL, throttledURL} {
+				mockClient.AddQueue(url)
+			}
+			client := &perQueueErrorClient{
+				MockSQSClient: mockClient,
+				attributeErrors: map[string]error{
+					deletedURL:   &sqstypes.QueueDoesNotExist{Message: aws.String("The specified queue does not exist.")},
+					throttledURL: &smithy.GenericAPIError{Code: "ThrottlingException"},
+				},
+			}
+
+			// Simulate the deleted queue having been cached by an earlier listing
+			queueIdentities.derive(deletedURL, "arn:aws:sqs:us-east-1:123456789012:evict-deleted-queue")
+
+			handler := &SQSHandler{Client: client}
+			rr := httptest.NewRecorder()
+			handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+			var queues []types.Queue
+			if err := json.NewDecoder(rr.Body).Decode(&queues); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			urls := map[string]bool{}
+			for _, q := range queues {
+				urls[q.URL] = true
+			}
+			if urls[deletedURL] {
+				t.Error("expected the deleted queue to be excluded")
+			}
+			if !urls[liveURL] || !urls[throttledURL] {
+				t.Errorf("expected live and throttled queues to remain, got %v", urls)
+			}
+			if queueIdentities.contains(deletedURL) {
+				t.Error("expected the deleted queue to be evicted from the cache")
+			}
+		})
+	}
+}
+
+func TestSQSHandler_SendMessage_ErrorMapping(t *testing.T) {
+	const (
+		standardURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+		fifoURL     = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue.fifo"
+	)
+
+	tests := []struct {
+		name           string
+		queueURL       string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "OverLimit is 429",
+			queueURL:       standardURL,
+			err:            &smithy.GenericAPIError{Code: "OverLimit", Message: "Too many messages in flight"},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedCode:   "OverLimit",
+		},
+		{
+			name:           "missing queue is 404",
+			queueURL:       standardURL,
+			err:            &sqstypes.QueueDoesNotExist{Message: aws.String("The specified queue does not exist.")},
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "QueueDoesNotExist",
+		},
+		{
+			name:           "FIFO missing group ID is 409",
+			queueURL:       fifoURL,
+			err:            &smithy.GenericAPIError{Code: "MissingParameter", Message: "The request must contain the parameter MessageGroupId."},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "MissingParameter",
+		},
+		{
+			name:           "FIFO invalid deduplication ID is 409",
+			queueURL:       fifoURL,
+			err:            &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "Value for parameter MessageDeduplicationId is invalid."},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   "InvalidParameterValue",
+		},
+		{
+			name:           "unrelated FIFO parameter error stays 500",
+			queueURL:       fifoURL,
+			err:            &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "Value 1000 for parameter DelaySeconds is invalid."},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "parameter error on a standard queue stays 500",
+			queueURL:       standardURL,
+			err:            &smithy.GenericAPIError{Code: "MissingParameter"},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "non-API error stays 500",
+			queueURL:       standardURL,
+			err:            errors.New("connection reset"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.SetError("SendMessage", tt.err)
+			handler := &SQSHandler{Client: mockClient}
+
+			// FIFO IDs are set so SQS's own rejection, not the pre-check, is mapped
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", strings.NewReader(`{"body": "hello", "messageGroupId": "g1", "messageDeduplicationId": "d1"}`))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": tt.queueURL})
+			rr := httptest.NewRecorder()
+			handler.SendMessage(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedCode == "" {
+				return
+			}
+
+			var response sqsErrorResponse
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode error response: %v", err)
+			}
+			if response.Error != tt.expectedCode {
+				t.Errorf("expected error code %s, got %s", tt.expectedCode, response.Error)
+			}
+			if response.Message == "" || response.Hint == "" {
+				t.Errorf("expected a message and hint, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_RetryMessage_ErrorMapping(t *testing.T) {
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "missing target is 404", err: &sqstypes.QueueDoesNotExist{Message: aws.String("The specified queue does not exist.")}, expectedStatus: http.StatusNotFound},
+		{name: "throttled retry is 429", err: &smithy.GenericAPIError{Code: "RequestThrottled", Message: "Rate exceeded"}, expectedStatus: http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.SetError("SendMessage", tt.err)
+			handler := &SQSHandler{Client: mockClient}
+
+			body := `{"message": {"messageId": "dlq-001", "body": "hello", "receiptHandle": "receipt-dlq-001"}, "targetQueueUrl": "` + targetURL + `"}`
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/retry", strings.NewReader(body))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"})
+			rr := httptest.NewRecorder()
+			handler.RetryMessage(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if len(mockClient.DeleteMessageCalls) != 0 {
+				t.Error("expected the source message to stay when the retry failed")
+			}
+		})
+	}
+}
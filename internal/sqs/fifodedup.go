@@ -0,0 +1,43 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+)
+
+// checkFIFOSendParameters refuses a FIFO send SQS would reject, with the same
+// 409 and hint writeSQSError gives SQS's own rejection: a MessageGroupId is
+// always required, and a MessageDeduplicationId unless the queue has
+// ContentBasedDeduplication enabled. Only a send without a deduplication ID
+// reads the queue's attributes; if they can't be read the send goes ahead and
+// SQS decides. It reports whether the send may go ahead.
+func (h *SQSHandler) checkFIFOSendParameters(w http.ResponseWriter, r *http.Request, queueURL, groupID, dedupID string) bool {
+	if !isFIFOQueue(queueURL) {
+		return true
+	}
+
+	var message string
+	switch {
+	case groupID == "":
+		message = "messageGroupId is required for FIFO queues"
+	case dedupID == "":
+		attributes, err := h.queueAttributes(r.Context(), queueURL)
+		if err != nil {
+			log.Printf("checkFIFOSendParameters: Could not read attributes of queue %s: %v", queueURL, err)
+			return true
+		}
+		if attributes["ContentBasedDeduplication"] == "true" {
+			return true
+		}
+		message = "messageDeduplicationId is required unless the queue has content-based deduplication enabled"
+	default:
+		return true
+	}
+
+	writeJSONStatus(w, r, http.StatusConflict, sqsErrorResponse{
+		Error:   "MissingParameter",
+		Message: message,
+		Hint:    fifoParameterHint,
+	})
+	return false
+}
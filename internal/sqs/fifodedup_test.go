@@ -0,0 +1,96 @@
+package sqs
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_SendMessage_ContentBasedDeduplication(t *testing.T) {
+	const (
+		contentDedupURL  = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-audit.fifo"
+		explicitDedupURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-events.fifo"
+		standardURL      = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	)
+
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+	send := func(queueURL, payload string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", bytes.NewBufferString(payload))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+		rr := httptest.NewRecorder()
+		handler.SendMessage(rr, req)
+		return rr
+	}
+
+	tests := []struct {
+		name           string
+		queueURL       string
+		payload        string
+		expectedStatus int
+	}{
+		{"content-based dedup without dedup id", contentDedupURL, `{"body":"login","messageGroupId":"user-1"}`, http.StatusOK},
+		{"explicit dedup without dedup id", explicitDedupURL, `{"body":"created","messageGroupId":"cust-9"}`, http.StatusConflict},
+		{"explicit dedup with dedup id", explicitDedupURL, `{"body":"created","messageGroupId":"cust-9","messageDeduplicationId":"evt-9"}`, http.StatusOK},
+		{"content-based dedup without group id", contentDedupURL, `{"body":"login"}`, http.StatusConflict},
+		{"standard queue", standardURL, `{"body":"order"}`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := send(tt.queueURL, tt.payload)
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusConflict {
+				return
+			}
+			var errResp sqsErrorResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &errResp); err != nil {
+				t.Fatalf("failed to decode error: %v", err)
+			}
+			if errResp.Error != "MissingParameter" || errResp.Hint != fifoParameterHint {
+				t.Errorf("unexpected error response %+v", errResp)
+			}
+		})
+	}
+
+	// The body hash deduplicates a repeated send
+	messageID := func(rr *httptest.ResponseRecorder) string {
+		var result internal_types.OperationResult
+		if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+			t.Fatalf("failed to decode result: %v", err)
+		}
+		return result.MessageId
+	}
+	first := messageID(send(contentDedupURL, `{"body":"logout","messageGroupId":"user-1"}`))
+	if again := messageID(send(contentDedupURL, `{"body":"logout","messageGroupId":"user-1"}`)); again != first {
+		t.Errorf("expected the repeated body to be deduplicated to %s, got %s", first, again)
+	}
+}
+
+func TestSQSHandler_GetQueueStatistics_ContentBasedDeduplication(t *testing.T) {
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/statistics", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/demo-audit.fifo"})
+	rr := httptest.NewRecorder()
+	handler.GetQueueStatistics(rr, req)
+
+	var stats struct {
+		FIFO struct {
+			ContentBasedDeduplication bool `json:"contentBasedDeduplication"`
+		} `json:"fifo"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to decode statistics: %v", err)
+	}
+	if !stats.FIFO.ContentBasedDeduplication {
+		t.Errorf("expected contentBasedDeduplication for demo-audit.fifo, got %s", rr.Body.String())
+	}
+}
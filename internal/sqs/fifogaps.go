@@ -0,0 +1,76 @@
+package sqs
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// sequenceGap is a jump between consecutive SequenceNumbers of received
+// messages in one FIFO message group, hinting at skipped or stuck messages.
+// Sequence numbers are large decimals, so they and the count of missing
+// numbers are reported as strings.
+type sequenceGap struct {
+	MessageGroupID       string `json:"messageGroupId"`
+	AfterMessageID       string `json:"afterMessageId"`
+	AfterSequenceNumber  string `json:"afterSequenceNumber"`
+	BeforeMessageID      string `json:"beforeMessageId"`
+	BeforeSequenceNumber string `json:"beforeSequenceNumber"`
+	Missing              string `json:"missing"`
+}
+
+// sequencedMessage is a message with its parsed SequenceNumber.
+type sequencedMessage struct {
+	id       string
+	sequence *big.Int
+}
+
+// detectSequenceGaps sorts the messages of each message group by
+// SequenceNumber and reports every non-contiguous jump. Messages without a
+// group or a valid SequenceNumber are ignored. Gaps are ordered by group,
+// then sequence.
+func detectSequenceGaps(messages []internal_types.Message) []sequenceGap {
+	groups := make(map[string][]sequencedMessage)
+	for _, msg := range messages {
+		groupID := msg.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)]
+		sequence, ok := new(big.Int).SetString(msg.Attributes[string(types.MessageSystemAttributeNameSequenceNumber)], 10)
+		if groupID == "" || !ok {
+			continue
+		}
+		groups[groupID] = append(groups[groupID], sequencedMessage{id: msg.MessageId, sequence: sequence})
+	}
+
+	groupIDs := make([]string, 0, len(groups))
+	for groupID := range groups {
+		groupIDs = append(groupIDs, groupID)
+	}
+	sort.Strings(groupIDs)
+
+	gaps := []sequenceGap{}
+	one := big.NewInt(1)
+	for _, groupID := range groupIDs {
+		sequenced := groups[groupID]
+		sort.Slice(sequenced, func(i, j int) bool {
+			return sequenced[i].sequence.Cmp(sequenced[j].sequence) < 0
+		})
+		for i := 1; i < len(sequenced); i++ {
+			previous, next := sequenced[i-1], sequenced[i]
+			missing := new(big.Int).Sub(next.sequence, previous.sequence)
+			missing.Sub(missing, one)
+			if missing.Sign() <= 0 {
+				continue
+			}
+			gaps = append(gaps, sequenceGap{
+				MessageGroupID:       groupID,
+				AfterMessageID:       previous.id,
+				AfterSequenceNumber:  previous.sequence.String(),
+				BeforeMessageID:      next.id,
+				BeforeSequenceNumber: next.sequence.String(),
+				Missing:              missing.String(),
+			})
+		}
+	}
+	return gaps
+}
@@ -0,0 +1,112 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func fifoMessage(id, groupID, sequence string) internal_types.Message {
+	return internal_types.Message{
+		MessageId:  id,
+		Attributes: map[string]string{"MessageGroupId": groupID, "SequenceNumber": sequence},
+	}
+}
+
+func TestDetectSequenceGaps(t *testing.T) {
+	messages := []internal_types.Message{
+		// Out of order, with sequences past uint64 range
+		fifoMessage("evt-003", "cust-001", "18849496460467696133"),
+		fifoMessage("evt-001", "cust-001", "18849496460467696128"),
+		fifoMessage("evt-002", "cust-001", "18849496460467696129"),
+		fifoMessage("evt-101", "cust-002", "7"),
+		fifoMessage("evt-102", "cust-002", "8"),
+		fifoMessage("evt-201", "cust-000", "1"),
+		fifoMessage("evt-202", "cust-000", "3"),
+		fifoMessage("evt-203", "cust-000", "3"),
+		fifoMessage("no-sequence", "cust-000", "not-a-number"),
+		{MessageId: "standard", Attributes: map[string]string{}},
+	}
+
+	expected := []sequenceGap{
+		{MessageGroupID: "cust-000", AfterMessageID: "evt-201", AfterSequenceNumber: "1", BeforeMessageID: "evt-202", BeforeSequenceNumber: "3", Missing: "1"},
+		{MessageGroupID: "cust-001", AfterMessageID: "evt-002", AfterSequenceNumber: "18849496460467696129", BeforeMessageID: "evt-003", BeforeSequenceNumber: "18849496460467696133", Missing: "3"},
+	}
+	if got := detectSequenceGaps(messages); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected gaps %+v, got %+v", expected, got)
+	}
+
+	if got := detectSequenceGaps(nil); got == nil || len(got) != 0 {
+		t.Errorf("expected an empty gap list, got %#v", got)
+	}
+}
+
+func TestSQSHandler_GetMessages_DetectGaps(t *testing.T) {
+	const fifoURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-events.fifo"
+
+	// The demo FIFO queue's events with a deliberate gap after evt-002
+	client := &batchingReceiveClient{MockSQSClient: helpers.NewMockSQSClient()}
+	for _, msg := range []struct{ id, sequence string }{
+		{"evt-001", "18849496460467696128"},
+		{"evt-002", "18849496460467696129"},
+		{"evt-005", "18849496460467696132"},
+	} {
+		client.messages = append(client.messages, sqstypes.Message{
+			MessageId:     aws.String(msg.id),
+			Body:          aws.String(`{"eventType":"OrderCreated"}`),
+			ReceiptHandle: aws.String("receipt-" + msg.id),
+			Attributes: map[string]string{
+				"MessageGroupId": "cust-001",
+				"SequenceNumber": msg.sequence,
+				"SentTimestamp":  "1700000000000",
+			},
+		})
+	}
+	handler := &SQSHandler{Client: client}
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?detectGaps=true", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": fifoURL})
+	rr := httptest.NewRecorder()
+	handler.GetMessages(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var response struct {
+		Messages []internal_types.Message `json:"messages"`
+		Gaps     []sequenceGap            `json:"gaps"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Messages) != 3 {
+		t.Errorf("expected 3 messages, got %d", len(response.Messages))
+	}
+	expected := []sequenceGap{{
+		MessageGroupID:       "cust-001",
+		AfterMessageID:       "evt-002",
+		AfterSequenceNumber:  "18849496460467696129",
+		BeforeMessageID:      "evt-005",
+		BeforeSequenceNumber: "18849496460467696132",
+		Missing:              "2",
+	}}
+	if !reflect.DeepEqual(response.Gaps, expected) {
+		t.Errorf("expected gaps %+v, got %+v", expected, response.Gaps)
+	}
+
+	req = httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?detectGaps=true", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"})
+	rr = httptest.NewRecorder()
+	handler.GetMessages(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for a standard queue, got %d", rr.Code)
+	}
+}
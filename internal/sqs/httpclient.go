@@ -0,0 +1,60 @@
+package sqs
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+// secondsFromEnv returns the named variable as a duration in seconds, and
+// false when it is unset or not a positive integer.
+func secondsFromEnv(name string) (time.Duration, bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, using the SDK default", name, value)
+		return 0, false
+	}
+	return time.Duration(n) * time.Second, true
+}
+
+// awsHTTPClientFromEnv builds the SDK's HTTP client with the timeouts from
+// AWS_HTTP_TIMEOUT_SECONDS (whole request), AWS_HTTP_DIAL_TIMEOUT_SECONDS and
+// AWS_HTTP_TLS_HANDSHAKE_TIMEOUT_SECONDS. Unset values keep the SDK defaults.
+func awsHTTPClientFromEnv() *awshttp.BuildableClient {
+	client := awshttp.NewBuildableClient()
+
+	if timeout, ok := secondsFromEnv("AWS_HTTP_TIMEOUT_SECONDS"); ok {
+		client = client.WithTimeout(timeout)
+	}
+	if timeout, ok := secondsFromEnv("AWS_HTTP_DIAL_TIMEOUT_SECONDS"); ok {
+		client = client.WithDialerOptions(func(d *net.Dialer) {
+			d.Timeout = timeout
+		})
+	}
+	if timeout, ok := secondsFromEnv("AWS_HTTP_TLS_HANDSHAKE_TIMEOUT_SECONDS"); ok {
+		client = client.WithTransportOptions(func(tr *http.Transport) {
+			tr.TLSHandshakeTimeout = timeout
+		})
+	}
+
+	return client
+}
+
+// loadAWSConfig loads the default AWS config with the env-configured HTTP
+// client. Extra options are applied after it.
+func loadAWSConfig(ctx context.Context, optFns ...func(*config.LoadOptions) error) (aws.Config, error) {
+	opts := append([]func(*config.LoadOptions) error{config.WithHTTPClient(awsHTTPClientFromEnv())}, optFns...)
+	return config.LoadDefaultConfig(ctx, opts...)
+}
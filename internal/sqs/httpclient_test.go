@@ -0,0 +1,74 @@
+package sqs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+)
+
+func TestLoadAWSConfig_HTTPTimeouts(t *testing.T) {
+	defaults := awshttp.NewBuildableClient()
+
+	tests := []struct {
+		name            string
+		env             map[string]string
+		expectedTimeout time.Duration
+		expectedDial    time.Duration
+		expectedTLS     time.Duration
+	}{
+		{
+			name:            "sdk defaults when unset",
+			expectedTimeout: defaults.GetTimeout(),
+			expectedDial:    defaults.GetDialer().Timeout,
+			expectedTLS:     defaults.GetTransport().TLSHandshakeTimeout,
+		},
+		{
+			name: "all timeouts configured",
+			env: map[string]string{
+				"AWS_HTTP_TIMEOUT_SECONDS":               "20",
+				"AWS_HTTP_DIAL_TIMEOUT_SECONDS":          "3",
+				"AWS_HTTP_TLS_HANDSHAKE_TIMEOUT_SECONDS": "4",
+			},
+			expectedTimeout: 20 * time.Second,
+			expectedDial:    3 * time.Second,
+			expectedTLS:     4 * time.Second,
+		},
+		{
+			name:            "invalid value keeps the default",
+			env:             map[string]string{"AWS_HTTP_TIMEOUT_SECONDS": "soon", "AWS_HTTP_DIAL_TIMEOUT_SECONDS": "5"},
+			expectedTimeout: defaults.GetTimeout(),
+			expectedDial:    5 * time.Second,
+			expectedTLS:     defaults.GetTransport().TLSHandshakeTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"AWS_HTTP_TIMEOUT_SECONDS", "AWS_HTTP_DIAL_TIMEOUT_SECONDS", "AWS_HTTP_TLS_HANDSHAKE_TIMEOUT_SECONDS"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			cfg, err := loadAWSConfig(context.Background(), config.WithRegion("us-east-1"))
+			if err != nil {
+				t.Fatalf("loadAWSConfig failed: %v", err)
+			}
+
+			client, ok := cfg.HTTPClient.(*awshttp.BuildableClient)
+			if !ok {
+				t.Fatalf("expected *awshttp.BuildableClient, got %T", cfg.HTTPClient)
+			}
+			if got := client.GetTimeout(); got != tt.expectedTimeout {
+				t.Errorf("timeout: expected %v, got %v", tt.expectedTimeout, got)
+			}
+			if got := client.GetDialer().Timeout; got != tt.expectedDial {
+				t.Errorf("dial timeout: expected %v, got %v", tt.expectedDial, got)
+			}
+			if got := client.GetTransport().TLSHandshakeTimeout; got != tt.expectedTLS {
+				t.Errorf("TLS handshake timeout: expected %v, got %v", tt.expectedTLS, got)
+			}
+		})
+	}
+}
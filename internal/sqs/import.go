@@ -0,0 +1,249 @@
+package sqs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+const (
+	// maxImportFileBytes caps the uploaded NDJSON file.
+	maxImportFileBytes = 5 << 20
+	// maxImportMessages caps the number of messages in one import.
+	maxImportMessages = 5000
+	// maxImportLineBytes allows for a 256 KiB SQS body plus JSON escaping.
+	maxImportLineBytes = 1 << 20
+	// sendBatchSize is the SQS SendMessageBatch entry limit.
+	sendBatchSize = 10
+)
+
+// importLine is one JSON Lines record. Attributes become String message
+// attributes and traceHeader the AWSTraceHeader system attribute; the group
+// and deduplication IDs only apply to FIFO queues.
+type importLine struct {
+	Body                   *string           `json:"body"`
+	Attributes             map[string]string `json:"attributes"`
+	TraceHeader            string            `json:"traceHeader"`
+	MessageGroupID         string            `json:"messageGroupId"`
+	MessageDeduplicationID string            `json:"messageDeduplicationId"`
+}
+
+// importFailure reports a line that was not sent and why.
+type importFailure struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// importEntry is a validated line waiting to be sent.
+type importEntry struct {
+	line  int
+	entry types.SendMessageBatchRequestEntry
+}
+
+// ImportMessages handles HTTP requests to send messages from an uploaded JSON
+// Lines file (multipart field "file"). Each line is {"body", "attributes",
+// "traceHeader"}; valid lines are sent in file order via SendMessageBatch,
+// and invalid lines or rejected entries are reported by line number. With ?dedupe=true, lines
+// repeating an earlier message are skipped and reported too.
+func (h *SQSHandler) ImportMessages(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// Leave headroom for the multipart envelope around the file itself
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileBytes+64<<10)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, fmt.Sprintf("import file exceeds %d bytes", maxImportFileBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "multipart field \"file\" is required: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	if header.Size > maxImportFileBytes {
+		http.Error(w, fmt.Sprintf("import file exceeds %d bytes", maxImportFileBytes), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	entries, failed, err := parseImportLines(bufio.NewScanner(file), isFIFOQueue(queueURL))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if r.URL.Query().Get("dedupe") == "true" {
+		var duplicates []importFailure
+		entries, duplicates = dedupeImportEntries(entries)
+		failed = append(failed, duplicates...)
+	}
+
+	sent, sendFailures := h.sendImportEntries(r.Context(), queueURL, entries)
+	failed = append(failed, sendFailures...)
+
+	log.Printf("ImportMessages: Sent %d of %d messages to queue %s", sent, sent+len(failed), queueURL)
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:        statusImported,
+		AffectedCount: affected(sent),
+		Details: map[string]interface{}{
+			"sent":   sent,
+			"failed": failed,
+		},
+	})
+}
+
+// parseImportLines validates every non-blank line. Invalid lines are returned
+// as failures; exceeding maxImportMessages or maxImportLineBytes aborts the
+// whole import.
+func parseImportLines(scanner *bufio.Scanner, fifo bool) ([]importEntry, []importFailure, error) {
+	scanner.Buffer(make([]byte, 0, 64<<10), maxImportLineBytes)
+
+	var entries []importEntry
+	failed := []importFailure{}
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if len(entries)+len(failed) >= maxImportMessages {
+			return nil, nil, fmt.Errorf("import exceeds %d messages", maxImportMessages)
+		}
+
+		var line importLine
+		if err := json.Unmarshal([]byte(text), &line); err != nil {
+			failed = append(failed, importFailure{Line: lineNumber, Error: "invalid JSON: " + err.Error()})
+			continue
+		}
+		if line.Body == nil || *line.Body == "" {
+			failed = append(failed, importFailure{Line: lineNumber, Error: "missing body"})
+			continue
+		}
+
+		entry := types.SendMessageBatchRequestEntry{
+			Id:                      aws.String(strconv.Itoa(lineNumber)),
+			MessageBody:             line.Body,
+			MessageAttributes:       withDefaultAttributeValues(stringMessageAttributes(line.Attributes)),
+			MessageSystemAttributes: traceHeaderAttributes(line.TraceHeader),
+		}
+		if size := sqsMessageSize(*line.Body, entry.MessageAttributes); size.exceedsLimit() {
+			failed = append(failed, importFailure{Line: lineNumber, Error: tooLargeMessage(size)})
+			continue
+		}
+		if fifo {
+			if line.MessageGroupID != "" {
+				entry.MessageGroupId = aws.String(line.MessageGroupID)
+			}
+			if line.MessageDeduplicationID != "" {
+				entry.MessageDeduplicationId = aws.String(line.MessageDeduplicationID)
+			}
+		}
+		entries = append(entries, importEntry{line: lineNumber, entry: entry})
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, nil, fmt.Errorf("line %d exceeds %d bytes", lineNumber+1, maxImportLineBytes)
+		}
+		return nil, nil, err
+	}
+
+	return entries, failed, nil
+}
+
+// dedupeImportEntries drops entries repeating an earlier entry's body,
+// attributes and message group. Bodies are compared with hashBody, so JSON
+// bodies differing only in key order or whitespace count as duplicates. The
+// dropped lines are returned as failures.
+func dedupeImportEntries(entries []importEntry) ([]importEntry, []importFailure) {
+	kept := make([]importEntry, 0, len(entries))
+	var duplicates []importFailure
+	firstLine := make(map[string]int, len(entries))
+
+	for _, e := range entries {
+		attributes := make(map[string]string, len(e.entry.MessageAttributes))
+		for name, value := range e.entry.MessageAttributes {
+			attributes[name] = aws.ToString(value.StringValue)
+		}
+		// json.Marshal sorts the attribute names, keeping the key stable
+		key, _ := json.Marshal(struct {
+			Body       string
+			Attributes map[string]string
+			GroupID    string
+		}{hashBody(aws.ToString(e.entry.MessageBody)), attributes, aws.ToString(e.entry.MessageGroupId)})
+
+		if line, seen := firstLine[string(key)]; seen {
+			duplicates = append(duplicates, importFailure{Line: e.line, Error: fmt.Sprintf("duplicate of line %d", line)})
+			continue
+		}
+		firstLine[string(key)] = e.line
+		kept = append(kept, e)
+	}
+
+	return kept, duplicates
+}
+
+// sendImportEntries sends entries in batches split by sendBatches, stopping
+// early if the request is cancelled. Unsent entries are reported as failures.
+func (h *SQSHandler) sendImportEntries(ctx context.Context, queueURL string, entries []importEntry) (int, []importFailure) {
+	sent := 0
+	var failed []importFailure
+
+	for _, chunk := range sendBatches(entries, func(e importEntry) types.SendMessageBatchRequestEntry { return e.entry }) {
+
+		lines := make(map[string]int, len(chunk))
+		batch := make([]types.SendMessageBatchRequestEntry, 0, len(chunk))
+		for _, e := range chunk {
+			lines[aws.ToString(e.entry.Id)] = e.line
+			batch = append(batch, e.entry)
+		}
+
+		if ctx.Err() != nil {
+			for _, e := range chunk {
+				failed = append(failed, importFailure{Line: e.line, Error: "import cancelled"})
+			}
+			continue
+		}
+
+		result, err := h.Client.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+			QueueUrl: aws.String(queueURL),
+			Entries:  batch,
+		})
+		if err != nil {
+			log.Printf("ImportMessages: Error sending batch to queue %s: %v", queueURL, err)
+			for _, e := range chunk {
+				failed = append(failed, importFailure{Line: e.line, Error: err.Error()})
+			}
+			continue
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+
+		sent += len(result.Successful)
+		for _, f := range result.Failed {
+			failed = append(failed, importFailure{
+				Line:  lines[aws.ToString(f.Id)],
+				Error: fmt.Sprintf("%s: %s", aws.ToString(f.Code), aws.ToString(f.Message)),
+			})
+		}
+	}
+
+	return sent, failed
+}
@@ -0,0 +1,303 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func importReq(t *testing.T, queueURL, content string) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", "messages.ndjson")
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write([]byte(content))
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/import", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+}
+
+type importResponse struct {
+	Status        string `json:"status"`
+	AffectedCount int    `json:"affectedCount"`
+	Details       struct {
+		Sent   int             `json:"sent"`
+		Failed []importFailure `json:"failed"`
+	} `json:"details"`
+}
+
+func TestSQSHandler_ImportMessages_Demo(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue"
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	before, _ := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl: aws.String(queueURL), MaxNumberOfMessages: 100,
+	})
+	existing := len(before.Messages)
+
+	// 11 valid lines span two batches; line 3 is malformed, line 5 has no body
+	var lines []string
+	for i := 1; i <= 13; i++ {
+		switch i {
+		case 3:
+			lines = append(lines, `{"body": `)
+		case 5:
+			lines = append(lines, `{"attributes": {"source": "import"}}`)
+		default:
+			lines = append(lines, fmt.Sprintf(`{"body": "event-%d", "attributes": {"source": "import"}}`, i))
+		}
+	}
+	lines = append(lines, "")
+
+	rr := httptest.NewRecorder()
+	handler.ImportMessages(rr, importReq(t, queueURL, strings.Join(lines, "\n")))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp importResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "imported" || resp.AffectedCount != 11 || resp.Details.Sent != 11 {
+		t.Errorf("expected 11 imported, got %+v", resp)
+	}
+	if len(resp.Details.Failed) != 2 || resp.Details.Failed[0].Line != 3 || resp.Details.Failed[1].Line != 5 {
+		t.Fatalf("expected failures on lines 3 and 5, got %+v", resp.Details.Failed)
+	}
+	if resp.Details.Failed[1].Error != "missing body" {
+		t.Errorf("unexpected failure reason %q", resp.Details.Failed[1].Error)
+	}
+
+	after, _ := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl: aws.String(queueURL), MaxNumberOfMessages: 100,
+	})
+	imported := after.Messages[existing:]
+	if len(imported) != 11 {
+		t.Fatalf("expected 11 imported messages in the queue, got %d", len(imported))
+	}
+
+	var bodies []string
+	for _, msg := range imported {
+		bodies = append(bodies, aws.ToString(msg.Body))
+		if source := msg.MessageAttributes["source"]; aws.ToString(source.StringValue) != "import" {
+			t.Errorf("expected source attribute on %s", aws.ToString(msg.Body))
+		}
+	}
+	expected := "event-1,event-2,event-4,event-6,event-7,event-8,event-9,event-10,event-11,event-12,event-13"
+	if got := strings.Join(bodies, ","); got != expected {
+		t.Errorf("expected messages in file order %s, got %s", expected, got)
+	}
+}
+
+func TestSQSHandler_BatchSend_TraceHeader(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue"
+	const traceHeader = "Root=1-65a1b2c3-0123456789abcdef01234567;Parent=0123456789abcdef;Sampled=1"
+
+	sends := map[string]func(*SQSHandler) *httptest.ResponseRecorder{
+		"import": func(handler *SQSHandler) *httptest.ResponseRecorder {
+			content := `{"body": "traced", "traceHeader": "` + traceHeader + `"}` + "\n" + `{"body": "untraced"}`
+			rr := httptest.NewRecorder()
+			handler.ImportMessages(rr, importReq(t, queueURL, content))
+			return rr
+		},
+		"template": func(handler *SQSHandler) *httptest.ResponseRecorder {
+			body := `{"template": "traced-{{.Index}}", "count": 2, "traceHeader": "` + traceHeader + `"}`
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages/template", strings.NewReader(body))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.SendTemplateMessages(rr, req)
+			return rr
+		},
+	}
+
+	for name, send := range sends {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("MESSAGES_MAX_LIMIT", "100")
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+			if rr := send(handler); rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?limit=100", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+
+			var messages []internal_types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			traced := 0
+			for _, msg := range messages {
+				switch {
+				case strings.HasPrefix(msg.Body, "traced"):
+					traced++
+					if msg.TraceHeader != traceHeader {
+						t.Errorf("%s: expected trace header %q, got %q", msg.Body, traceHeader, msg.TraceHeader)
+					}
+				case msg.Body == "untraced" && msg.TraceHeader != "":
+					t.Errorf("expected no trace header on untraced message, got %q", msg.TraceHeader)
+				}
+			}
+			if traced == 0 {
+				t.Error("expected traced messages on receive")
+			}
+		})
+	}
+}
+
+func TestSQSHandler_ImportMessages_Batching(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	mockClient := helpers.NewMockSQSClient()
+	handler := &SQSHandler{Client: mockClient}
+
+	var lines []string
+	for i := 0; i < 25; i++ {
+		lines = append(lines, fmt.Sprintf(`{"body": "m%d"}`, i))
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ImportMessages(rr, importReq(t, queueURL, strings.Join(lines, "\n")))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+
+	var sizes []int
+	for _, call := range mockClient.SendMessageBatchCalls {
+		sizes = append(sizes, len(call.Entries))
+	}
+	if fmt.Sprint(sizes) != "[10 10 5]" {
+		t.Errorf("expected batches of [10 10 5], got %v", sizes)
+	}
+}
+
+func TestSQSHandler_ImportMessages_Dedupe(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	// Line 2 repeats line 1 with different key order and spacing; line 3
+	// differs only by an attribute and line 4 is not JSON, so both are kept
+	content := strings.Join([]string{
+		`{"body": "{\"id\":1,\"kind\":\"order\"}"}`,
+		`{"body": "{ \"kind\": \"order\", \"id\": 1 }"}`,
+		`{"body": "{\"id\":1,\"kind\":\"order\"}", "attributes": {"source": "replay"}}`,
+		`{"body": "plain text"}`,
+		`{"body": "plain text"}`,
+	}, "\n")
+
+	for _, tt := range []struct {
+		query        string
+		expectedSent int
+		expectedDups []importFailure
+	}{
+		{query: "", expectedSent: 5},
+		{query: "dedupe=true", expectedSent: 3, expectedDups: []importFailure{
+			{Line: 2, Error: "duplicate of line 1"},
+			{Line: 5, Error: "duplicate of line 4"},
+		}},
+	} {
+		t.Run("query="+tt.query, func(t *testing.T) {
+			handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+
+			req := importReq(t, queueURL, content)
+			req.URL.RawQuery = tt.query
+			rr := httptest.NewRecorder()
+			handler.ImportMessages(rr, req)
+
+			var resp importResponse
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Details.Sent != tt.expectedSent {
+				t.Errorf("expected %d sent, got %d", tt.expectedSent, resp.Details.Sent)
+			}
+			if fmt.Sprint(resp.Details.Failed) != fmt.Sprint(tt.expectedDups) {
+				t.Errorf("expected failures %+v, got %+v", tt.expectedDups, resp.Details.Failed)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_ImportMessages_Errors(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	t.Run("batch error fails every entry", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.SetError("SendMessageBatch", errors.New("AccessDenied"))
+		handler := &SQSHandler{Client: mockClient}
+
+		rr := httptest.NewRecorder()
+		handler.ImportMessages(rr, importReq(t, queueURL, "{\"body\": \"a\"}\n{\"body\": \"b\"}"))
+
+		var resp importResponse
+		json.NewDecoder(rr.Body).Decode(&resp)
+		if resp.Details.Sent != 0 || len(resp.Details.Failed) != 2 || resp.Details.Failed[0].Error != "AccessDenied" {
+			t.Errorf("unexpected summary %+v", resp)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/import", strings.NewReader("{}"))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+
+		rr := httptest.NewRecorder()
+		handler.ImportMessages(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("too many messages", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		handler := &SQSHandler{Client: mockClient}
+
+		content := strings.Repeat("{\"body\": \"x\"}\n", maxImportMessages+1)
+		rr := httptest.NewRecorder()
+		handler.ImportMessages(rr, importReq(t, queueURL, content))
+
+		if rr.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected 413, got %d", rr.Code)
+		}
+		if len(mockClient.SendMessageBatchCalls) != 0 {
+			t.Errorf("expected nothing sent, got %d batches", len(mockClient.SendMessageBatchCalls))
+		}
+	})
+
+	t.Run("file too large", func(t *testing.T) {
+		handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+
+		rr := httptest.NewRecorder()
+		handler.ImportMessages(rr, importReq(t, queueURL, strings.Repeat("x", maxImportFileBytes+1)))
+
+		if rr.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("expected 413, got %d", rr.Code)
+		}
+	})
+}
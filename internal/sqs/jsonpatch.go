@@ -0,0 +1,332 @@
+package sqs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// patchOperation is one RFC 6902 JSON Patch operation.
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// errBodyNotJSON is returned when a patch is given for a non-JSON body.
+var errBodyNotJSON = errors.New("message body is not JSON")
+
+// applyJSONPatch applies the operations to the JSON body in order, returning
+// the patched body. Any failing operation fails the whole patch.
+func applyJSONPatch(body string, operations []patchOperation) (string, error) {
+	doc, err := decodeJSONValue([]byte(body))
+	if err != nil {
+		return "", errBodyNotJSON
+	}
+
+	for i, operation := range operations {
+		doc, err = applyPatchOperation(doc, operation)
+		if err != nil {
+			return "", fmt.Errorf("patch operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// checkJSONPatch validates operations without a document: every op must be
+// supported, its pointers well formed and its value, where one is needed,
+// valid JSON. A patch that passes can still fail against a particular body.
+func checkJSONPatch(operations []patchOperation) error {
+	for i, operation := range operations {
+		err := func() error {
+			if _, err := parseJSONPointer(operation.Path); err != nil {
+				return err
+			}
+			switch operation.Op {
+			case "add", "replace", "test":
+				if len(operation.Value) == 0 {
+					return errors.New("missing value")
+				}
+				if _, err := decodeJSONValue(operation.Value); err != nil {
+					return fmt.Errorf("invalid value: %w", err)
+				}
+			case "remove":
+			case "move", "copy":
+				if _, err := parseJSONPointer(operation.From); err != nil {
+					return fmt.Errorf("from: %w", err)
+				}
+			default:
+				return fmt.Errorf("unsupported op %q", operation.Op)
+			}
+			return nil
+		}()
+		if err != nil {
+			return fmt.Errorf("patch operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return nil
+}
+
+// jsonEqual compares decoded JSON values the way the "test" op requires:
+// numbers by value, so 1 and 1.0 are equal, and objects regardless of member
+// order.
+func jsonEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, _, errX := big.ParseFloat(string(a), 10, 256, big.ToNearestEven)
+		y, _, errY := big.ParseFloat(string(b), 10, 256, big.ToNearestEven)
+		return errX == nil && errY == nil && x.Cmp(y) == 0
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for name, value := range a {
+			other, ok := b[name]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		// Strings, booleans and null
+		return a == b
+	}
+}
+
+// decodeJSONValue decodes a single JSON value, keeping numbers as written.
+func decodeJSONValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("trailing data after JSON value")
+	}
+	return value, nil
+}
+
+func applyPatchOperation(doc interface{}, operation patchOperation) (interface{}, error) {
+	path, err := parseJSONPointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if len(operation.Value) == 0 {
+			return nil, errors.New("missing value")
+		}
+		value, err := decodeJSONValue(operation.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		switch operation.Op {
+		case "add":
+			return patchAdd(doc, path, value)
+		case "replace":
+			return updateAt(doc, path, func(interface{}) (interface{}, error) { return value, nil })
+		default:
+			current, err := valueAt(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !jsonEqual(current, value) {
+				return nil, errors.New("test failed")
+			}
+			return doc, nil
+		}
+	case "remove":
+		return patchRemove(doc, path)
+	case "move", "copy":
+		from, err := parseJSONPointer(operation.From)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		value, err := valueAt(doc, from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+		if operation.Op == "copy" {
+			// Copy through JSON so the two locations don't share containers
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			if value, err = decodeJSONValue(encoded); err != nil {
+				return nil, err
+			}
+			return patchAdd(doc, path, value)
+		}
+		if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+			return nil, errors.New("cannot move a value into itself")
+		}
+		if doc, err = patchRemove(doc, from); err != nil {
+			return nil, err
+		}
+		return patchAdd(doc, path, value)
+	default:
+		return nil, fmt.Errorf("unsupported op %q", operation.Op)
+	}
+}
+
+// parseJSONPointer splits an RFC 6901 pointer into unescaped reference
+// tokens; the empty pointer is the whole document.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses an array reference token, which must be a decimal index
+// without leading zeros below limit.
+func arrayIndex(token string, limit int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || strconv.Itoa(index) != token {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if index >= limit {
+		return 0, fmt.Errorf("array index %d out of range", index)
+	}
+	return index, nil
+}
+
+// valueAt returns the value at path.
+func valueAt(doc interface{}, path []string) (interface{}, error) {
+	var value interface{}
+	_, err := updateAt(doc, path, func(current interface{}) (interface{}, error) {
+		value = current
+		return current, nil
+	})
+	return value, err
+}
+
+// updateAt replaces the existing value at path with update's result,
+// returning the new document.
+func updateAt(doc interface{}, path []string, update func(interface{}) (interface{}, error)) (interface{}, error) {
+	if len(path) == 0 {
+		return update(doc)
+	}
+
+	token := path[0]
+	switch container := doc.(type) {
+	case map[string]interface{}:
+		child, ok := container[token]
+		if !ok {
+			return nil, fmt.Errorf("member %q not found", token)
+		}
+		updated, err := updateAt(child, path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		container[token] = updated
+		return container, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(container))
+		if err != nil {
+			return nil, err
+		}
+		updated, err := updateAt(container[index], path[1:], update)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = updated
+		return container, nil
+	default:
+		return nil, fmt.Errorf("cannot reference %q in a scalar", token)
+	}
+}
+
+// patchAdd adds value at path: setting an object member, inserting into an
+// array ("-" appends), or replacing the whole document.
+func patchAdd(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	token := path[len(path)-1]
+	return updateAt(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[token] = value
+			return container, nil
+		case []interface{}:
+			index := len(container)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(container)+1); err != nil {
+					return nil, err
+				}
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		default:
+			return nil, fmt.Errorf("cannot add %q to a scalar", token)
+		}
+	})
+}
+
+// patchRemove removes the existing value at path.
+func patchRemove(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+	token := path[len(path)-1]
+	return updateAt(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("member %q not found", token)
+			}
+			delete(container, token)
+			return container, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(container))
+			if err != nil {
+				return nil, err
+			}
+			return append(container[:index], container[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("cannot remove %q from a scalar", token)
+		}
+	})
+}
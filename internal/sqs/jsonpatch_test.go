@@ -0,0 +1,129 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/gorilla/mux"
+)
+
+func TestApplyJSONPatch(t *testing.T) {
+	const body = `{"a":1,"list":["x","y"],"nested":{"b~c":true,"d/e":"f"}}`
+
+	tests := []struct {
+		name     string
+		patch    string
+		expected string
+		wantErr  bool
+	}{
+		{"replace", `[{"op":"replace","path":"/a","value":2}]`, `{"a":2,"list":["x","y"],"nested":{"b~c":true,"d/e":"f"}}`, false},
+		{"add member", `[{"op":"add","path":"/z","value":null}]`, `{"a":1,"list":["x","y"],"nested":{"b~c":true,"d/e":"f"},"z":null}`, false},
+		{"insert and append", `[{"op":"add","path":"/list/0","value":"w"},{"op":"add","path":"/list/-","value":"z"}]`, `{"a":1,"list":["w","x","y","z"],"nested":{"b~c":true,"d/e":"f"}}`, false},
+		{"remove escaped members", `[{"op":"remove","path":"/nested/b~0c"},{"op":"remove","path":"/nested/d~1e"}]`, `{"a":1,"list":["x","y"],"nested":{}}`, false},
+		{"move", `[{"op":"move","from":"/a","path":"/nested/a"}]`, `{"list":["x","y"],"nested":{"a":1,"b~c":true,"d/e":"f"}}`, false},
+		{"copy", `[{"op":"copy","from":"/list","path":"/copied"},{"op":"remove","path":"/copied/0"}]`, `{"a":1,"copied":["y"],"list":["x","y"],"nested":{"b~c":true,"d/e":"f"}}`, false},
+		{"passing test", `[{"op":"test","path":"/list/1","value":"y"}]`, body, false},
+		{"failing test", `[{"op":"test","path":"/a","value":2}]`, "", true},
+		{"test compares numbers by value", `[{"op":"test","path":"/a","value":1.0},{"op":"test","path":"/a","value":1e0}]`, body, false},
+		{"test ignores member order", `[{"op":"test","path":"/nested","value":{"d/e":"f","b~c":true}}]`, body, false},
+		{"test keeps types apart", `[{"op":"test","path":"/a","value":"1"}]`, "", true},
+		{"replace missing member", `[{"op":"replace","path":"/missing","value":1}]`, "", true},
+		{"index out of range", `[{"op":"add","path":"/list/5","value":1}]`, "", true},
+		{"leading zero index", `[{"op":"remove","path":"/list/01"}]`, "", true},
+		{"move into itself", `[{"op":"move","from":"/nested","path":"/nested/child"}]`, "", true},
+		{"missing value", `[{"op":"add","path":"/a"}]`, "", true},
+		{"unsupported op", `[{"op":"merge","path":"/a","value":1}]`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var operations []patchOperation
+			if err := json.Unmarshal([]byte(tt.patch), &operations); err != nil {
+				t.Fatalf("invalid patch: %v", err)
+			}
+			got, err := applyJSONPatch(body, operations)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := applyJSONPatch("not json", []patchOperation{{Op: "remove", Path: "/a"}}); err != errBodyNotJSON {
+		t.Errorf("expected errBodyNotJSON, got %v", err)
+	}
+}
+
+func TestSQSHandler_RetryMessage_AppliesPatch(t *testing.T) {
+	const dlqURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-deadletter-queue"
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client}
+
+	received, err := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(dlqURL),
+		MaxNumberOfMessages: 1,
+	})
+	if err != nil || len(received.Messages) != 1 {
+		t.Fatalf("failed to receive the demo DLQ message: %v", err)
+	}
+	message := ConvertMessage(received.Messages[0])
+
+	retry := func(patch string) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"message":        message,
+			"targetQueueUrl": targetURL,
+			"patch":          json.RawMessage(patch),
+		})
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/retry", bytes.NewReader(payload))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": dlqURL})
+		rr := httptest.NewRecorder()
+		handler.RetryMessage(rr, req)
+		return rr
+	}
+
+	if rr := retry(`[{"op":"replace","path":"/orderId","value":"42"}]`); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	target, err := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(targetURL),
+		MaxNumberOfMessages: 10,
+	})
+	if err != nil {
+		t.Fatalf("failed to receive from the target: %v", err)
+	}
+	var patched map[string]interface{}
+	for _, msg := range target.Messages {
+		var body map[string]interface{}
+		if json.Unmarshal([]byte(aws.ToString(msg.Body)), &body) == nil && body["failureReason"] != nil {
+			patched = body
+		}
+	}
+	if patched == nil {
+		t.Fatal("expected the retried message in the target queue")
+	}
+	if patched["orderId"] != "42" || patched["error"] != "Invalid payment method" {
+		t.Errorf("expected the patched body with other fields kept, got %v", patched)
+	}
+
+	if rr := retry(`[{"op":"replace","path":"/missing","value":1}]`); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422 for a failing patch, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,35 @@
+package sqs
+
+import (
+	"strconv"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// firstReceiveLatencyMs returns the time a message waited between being sent
+// and first received (ApproximateFirstReceiveTimestamp - SentTimestamp), in
+// milliseconds. It reports false unless both attributes are present; clock
+// skew never yields a negative latency.
+func firstReceiveLatencyMs(attributes map[string]string) (int64, bool) {
+	sent, err := strconv.ParseInt(attributes["SentTimestamp"], 10, 64)
+	if err != nil || sent <= 0 {
+		return 0, false
+	}
+	firstReceive, err := strconv.ParseInt(attributes["ApproximateFirstReceiveTimestamp"], 10, 64)
+	if err != nil || firstReceive <= 0 {
+		return 0, false
+	}
+	return max(firstReceive-sent, 0), true
+}
+
+// filterByMinLatency keeps messages whose first-receive latency is at least
+// minLatencyMs. Messages without a latency are dropped.
+func filterByMinLatency(messages []internal_types.Message, minLatencyMs int64) []internal_types.Message {
+	filtered := []internal_types.Message{}
+	for _, msg := range messages {
+		if msg.FirstReceiveLatencyMs != nil && *msg.FirstReceiveLatencyMs >= minLatencyMs {
+			filtered = append(filtered, msg)
+		}
+	}
+	return filtered
+}
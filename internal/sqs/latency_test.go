@@ -0,0 +1,120 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+func TestFirstReceiveLatencyMs(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]string
+		expected   int64
+		ok         bool
+	}{
+		{name: "both timestamps", attributes: map[string]string{"SentTimestamp": "1700000000000", "ApproximateFirstReceiveTimestamp": "1700000004500"}, expected: 4500, ok: true},
+		{name: "clock skew clamps to zero", attributes: map[string]string{"SentTimestamp": "1700000005000", "ApproximateFirstReceiveTimestamp": "1700000004000"}, expected: 0, ok: true},
+		{name: "never received", attributes: map[string]string{"SentTimestamp": "1700000000000"}},
+		{name: "missing sent", attributes: map[string]string{"ApproximateFirstReceiveTimestamp": "1700000004500"}},
+		{name: "malformed", attributes: map[string]string{"SentTimestamp": "soon", "ApproximateFirstReceiveTimestamp": "1700000004500"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latency, ok := firstReceiveLatencyMs(tt.attributes)
+			if ok != tt.ok || latency != tt.expected {
+				t.Errorf("expected (%d, %v), got (%d, %v)", tt.expected, tt.ok, latency, ok)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessages_FirstReceiveLatency(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedIDs string
+		status      int
+	}{
+		// ord-001 and ord-002 waited 10 minutes before first pickup, ord-003 five
+		{name: "no filter", expectedIDs: "ord-001,ord-002,ord-003", status: http.StatusOK},
+		{name: "at least ten minutes", query: "?minLatencyMs=600000", expectedIDs: "ord-001,ord-002", status: http.StatusOK},
+		{name: "above every latency", query: "?minLatencyMs=3600000", expectedIDs: "", status: http.StatusOK},
+		{name: "invalid threshold", query: "?minLatencyMs=ten", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+
+			if rr.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, rr.Code, rr.Body.String())
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var messages []types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode messages: %v", err)
+			}
+
+			ids := []string{}
+			for _, msg := range messages {
+				ids = append(ids, msg.MessageId)
+				if msg.FirstReceiveLatencyMs == nil {
+					t.Errorf("%s: expected firstReceiveLatencyMs", msg.MessageId)
+					continue
+				}
+				expected := int64(600000)
+				if msg.MessageId == "ord-003" {
+					expected = 300000
+				}
+				// Demo timestamps are taken a moment apart at startup
+				if diff := *msg.FirstReceiveLatencyMs - expected; diff < -1000 || diff > 1000 {
+					t.Errorf("%s: expected latency near %d, got %d", msg.MessageId, expected, *msg.FirstReceiveLatencyMs)
+				}
+			}
+			sort.Strings(ids)
+			if got := strings.Join(ids, ","); got != tt.expectedIDs {
+				t.Errorf("expected %s, got %s", tt.expectedIDs, got)
+			}
+		})
+	}
+}
+
+func TestConvertMessage_OmitsLatencyWithoutTimestamps(t *testing.T) {
+	message := ConvertMessage(sqstypes.Message{
+		MessageId:  aws.String("msg-1"),
+		Body:       aws.String("hello"),
+		Attributes: map[string]string{"SentTimestamp": "1700000000000"},
+	})
+	if message.FirstReceiveLatencyMs != nil {
+		t.Errorf("expected no latency, got %d", *message.FirstReceiveLatencyMs)
+	}
+
+	encoded, err := json.Marshal(message)
+	if err != nil {
+		t.Fatalf("failed to encode message: %v", err)
+	}
+	if strings.Contains(string(encoded), "firstReceiveLatencyMs") {
+		t.Errorf("expected firstReceiveLatencyMs to be omitted, got %s", encoded)
+	}
+}
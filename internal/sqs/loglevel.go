@@ -0,0 +1,21 @@
+package sqs
+
+import (
+	"log"
+	"os"
+	"strings"
+)
+
+// debugLoggingEnabled reports whether LOG_LEVEL=debug asks for per-item
+// detail, such as the tag decision for every queue listed. Other levels log
+// only summaries of high-volume operations.
+func debugLoggingEnabled() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("LOG_LEVEL")), "debug")
+}
+
+// debugf logs like log.Printf when debug logging is enabled.
+func debugf(format string, args ...interface{}) {
+	if debugLoggingEnabled() {
+		log.Printf(format, args...)
+	}
+}
@@ -0,0 +1,98 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+// queueTagsClient serves fixed tags per queue URL.
+type queueTagsClient struct {
+	*helpers.MockSQSClient
+	tags map[string]map[string]string
+}
+
+func (c *queueTagsClient) ListQueueTags(ctx context.Context, params *awssqs.ListQueueTagsInput, optFns ...func(*awssqs.Options)) (*awssqs.ListQueueTagsOutput, error) {
+	return &awssqs.ListQueueTagsOutput{Tags: c.tags[aws.ToString(params.QueueUrl)]}, nil
+}
+
+// captureLog redirects the standard logger to a buffer for the rest of the
+// test.
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	previous := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(previous) })
+	return &buf
+}
+
+func TestSQSHandler_FilterQueues_LogSampling(t *testing.T) {
+	requiredTags := map[string][]string{"env": {"prod"}}
+
+	tests := []struct {
+		name             string
+		logLevel         string
+		queueCount       int
+		expectedPerQueue int
+	}{
+		{name: "info with few queues", logLevel: "", queueCount: 3},
+		{name: "info with many queues", logLevel: "info", queueCount: 60},
+		{name: "debug logs each decision", logLevel: "debug", queueCount: 60, expectedPerQueue: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LOG_LEVEL", tt.logLevel)
+
+			client := &queueTagsClient{MockSQSClient: helpers.NewMockSQSClient(), tags: make(map[string]map[string]string)}
+			queueURLs := make([]string, 0, tt.queueCount)
+			for i := range tt.queueCount {
+				queueURL := fmt.Sprintf("https://sqs.us-east-1.amazonaws.com/123456789012/queue-%d", i)
+				client.AddQueue(queueURL)
+				env := "prod"
+				if i%2 == 1 {
+					env = "dev"
+				}
+				client.tags[queueURL] = map[string]string{"env": env}
+				queueURLs = append(queueURLs, queueURL)
+			}
+			handler := &SQSHandler{Client: client}
+
+			output := captureLog(t)
+			queues := handler.filterQueues(context.Background(), queueURLs, false, requiredTags)
+
+			matched := (tt.queueCount + 1) / 2
+			if len(queues) != matched {
+				t.Fatalf("expected %d matching queues, got %d", matched, len(queues))
+			}
+
+			summaries, perQueue := 0, 0
+			for _, line := range strings.Split(output.String(), "\n") {
+				switch {
+				case strings.Contains(line, "Tag filter checked"):
+					summaries++
+					expected := fmt.Sprintf("checked %d queues: %d matched, %d skipped, 0 tag errors", tt.queueCount, matched, tt.queueCount-matched)
+					if !strings.Contains(line, expected) {
+						t.Errorf("expected summary %q, got %q", expected, line)
+					}
+				case strings.Contains(line, "matches all required tags"), strings.Contains(line, "has invalid value"):
+					perQueue++
+				}
+			}
+			if summaries != 1 {
+				t.Errorf("expected one summary line, got %d", summaries)
+			}
+			if perQueue != tt.expectedPerQueue {
+				t.Errorf("expected %d per-queue lines, got %d", tt.expectedPerQueue, perQueue)
+			}
+		})
+	}
+}
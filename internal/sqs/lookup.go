@@ -0,0 +1,73 @@
+package sqs
+
+import (
+	"context"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// lookupReceiveAttempts bounds how many receives lookupMessages makes while
+// looking for the requested messages. Live SQS returns a sampled subset of at
+// most 10 messages per call, so a few attempts improve the hit rate.
+const lookupReceiveAttempts = 3
+
+// lookupMessages finds messages by ID without consuming them: everything a
+// receive returns is made visible again at once (see ReleaseMessages), so
+// later attempts and other consumers still see it. IDs that were not seen are
+// absent from the result.
+func (h *SQSHandler) lookupMessages(ctx context.Context, queueURL string, messageIDs []string) (map[string]types.Message, error) {
+	wanted := make(map[string]bool, len(messageIDs))
+	for _, id := range messageIDs {
+		wanted[id] = true
+	}
+
+	maxReceive := int32(10)
+	if h.isDemo {
+		maxReceive = 1000
+	}
+
+	found := make(map[string]types.Message, len(wanted))
+	for attempt := 0; attempt < lookupReceiveAttempts && len(found) < len(wanted); attempt++ {
+		result, err := h.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(queueURL),
+			MaxNumberOfMessages:   maxReceive,
+			AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+			MessageAttributeNames: []string{"All"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+		ReleaseMessages(ctx, h.Client, queueURL, result.Messages)
+
+		for _, msg := range result.Messages {
+			id := aws.ToString(msg.MessageId)
+			if _, seen := found[id]; wanted[id] && !seen {
+				found[id] = msg
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// ReleaseMessages makes received messages visible again immediately. A zero
+// VisibilityTimeout on ReceiveMessage is not sent by the SDK (it omits zero
+// values), so a "peek" hides what it receives for the queue's default timeout
+// unless reset here. The receive still counts towards each message's
+// ApproximateReceiveCount. Failures are logged: those messages reappear when
+// the timeout expires.
+func ReleaseMessages(ctx context.Context, client SQSClientInterface, queueURL string, messages []types.Message) {
+	for _, msg := range messages {
+		if _, err := client.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          aws.String(queueURL),
+			ReceiptHandle:     msg.ReceiptHandle,
+			VisibilityTimeout: 0,
+		}); err != nil {
+			log.Printf("ReleaseMessages: Could not make message %s in queue %s visible again: %v", aws.ToString(msg.MessageId), queueURL, err)
+		}
+	}
+}
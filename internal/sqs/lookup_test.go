@@ -0,0 +1,39 @@
+package sqs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestSQSHandler_LookupMessages_ReleasesReceivedMessages(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue(queueURL)
+	mock.AddMessage(queueURL, "msg-1", "first")
+	mock.AddMessage(queueURL, "msg-2", "second")
+	handler := &SQSHandler{Client: mock}
+
+	found, err := handler.lookupMessages(context.Background(), queueURL, []string{"msg-2"})
+	if err != nil {
+		t.Fatalf("lookupMessages failed: %v", err)
+	}
+	if _, ok := found["msg-2"]; !ok {
+		t.Fatal("expected msg-2 to be found")
+	}
+
+	// Everything received is made visible again, not only the match
+	released := make(map[string]bool)
+	for _, call := range mock.ChangeMessageVisibilityCalls {
+		if call.QueueURL != queueURL || call.VisibilityTimeout != 0 {
+			t.Errorf("expected a zero-timeout reset on %s, got %+v", queueURL, call)
+		}
+		released[call.ReceiptHandle] = true
+	}
+	for _, handle := range []string{"receipt-msg-1", "receipt-msg-2"} {
+		if !released[handle] {
+			t.Errorf("expected %s to be made visible again, got %v", handle, mock.ChangeMessageVisibilityCalls)
+		}
+	}
+}
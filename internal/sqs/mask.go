@@ -0,0 +1,80 @@
+package sqs
+
+import (
+	"os"
+	"regexp"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// maskedAccountID replaces account IDs when MASK_ACCOUNT_IDS is enabled.
+const maskedAccountID = "XXXXXXXXXXXX"
+
+var (
+	// arnAccountPattern matches the account segment of an ARN
+	// (arn:partition:service:region:account-id:...).
+	arnAccountPattern = regexp.MustCompile(`(arn:[A-Za-z0-9-]+:[A-Za-z0-9-]*:[A-Za-z0-9-]*:)\d{12}\b`)
+	// urlAccountPattern matches the account path segment of a queue URL.
+	urlAccountPattern = regexp.MustCompile(`(https?://[^/\s"]+/)\d{12}\b`)
+	// accountIDPattern matches a bare account ID, as in the SenderId
+	// attribute of messages sent with account root credentials.
+	accountIDPattern = regexp.MustCompile(`^\d{12}$`)
+)
+
+// maskAccountIDsEnabled reports whether MASK_ACCOUNT_IDS=true, for
+// screen-sharing without exposing account IDs.
+func maskAccountIDsEnabled() bool {
+	return os.Getenv("MASK_ACCOUNT_IDS") == "true"
+}
+
+// maskARN replaces the account ID in an ARN, or in ARNs embedded in text such
+// as a RedrivePolicy.
+func maskARN(arn string) string {
+	return arnAccountPattern.ReplaceAllString(arn, "${1}"+maskedAccountID)
+}
+
+// maskURL replaces the account ID in a queue URL, or in URLs embedded in text.
+func maskURL(queueURL string) string {
+	return urlAccountPattern.ReplaceAllString(queueURL, "${1}"+maskedAccountID)
+}
+
+// maskAccountIDs masks every account ID in a display value: ARNs, queue URLs
+// and a value that is a bare account ID.
+func maskAccountIDs(value string) string {
+	if accountIDPattern.MatchString(value) {
+		return maskedAccountID
+	}
+	return maskURL(maskARN(value))
+}
+
+// maskAttributes returns a masked copy of attributes; the original may be
+// shared with the attribute cache and is never modified.
+func maskAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return nil
+	}
+	masked := make(map[string]string, len(attributes))
+	for name, value := range attributes {
+		masked[name] = maskAccountIDs(value)
+	}
+	return masked
+}
+
+// maskQueue masks a queue's display fields. URL stays usable for follow-up
+// requests; DisplayURL carries its masked form.
+func maskQueue(queue internal_types.Queue) internal_types.Queue {
+	queue.Name = maskAccountIDs(queue.Name)
+	queue.DisplayURL = maskURL(queue.URL)
+	queue.Attributes = maskAttributes(queue.Attributes)
+	if queue.AccountID != "" {
+		queue.AccountID = maskedAccountID
+	}
+	return queue
+}
+
+// maskMessage masks a message's attributes. The receipt handle is left intact
+// so the message can still be deleted; bodies are user data and untouched.
+func maskMessage(message internal_types.Message) internal_types.Message {
+	message.Attributes = maskAttributes(message.Attributes)
+	return message
+}
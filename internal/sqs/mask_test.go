@@ -0,0 +1,159 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+const demoAccountID = "123456789012"
+
+// accountAttributesClient adds account-bearing system attributes to every
+// demo message, as messages received from a real DLQ would carry.
+type accountAttributesClient struct {
+	*demo.DemoSQSClient
+}
+
+func (c *accountAttributesClient) ReceiveMessage(ctx context.Context, params *awssqs.ReceiveMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error) {
+	result, err := c.DemoSQSClient.ReceiveMessage(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Messages {
+		attributes := map[string]string{
+			"SenderId":                 demoAccountID,
+			"DeadLetterQueueSourceArn": "arn:aws:sqs:us-east-1:" + demoAccountID + ":demo-orders-queue",
+		}
+		for name, value := range result.Messages[i].Attributes {
+			attributes[name] = value
+		}
+		result.Messages[i].Attributes = attributes
+	}
+	return result, nil
+}
+
+func TestMaskAccountIDs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "ARN", value: "arn:aws:sqs:us-east-1:123456789012:orders", expected: "arn:aws:sqs:us-east-1:XXXXXXXXXXXX:orders"},
+		{name: "GovCloud ARN", value: "arn:aws-us-gov:sqs:us-gov-west-1:123456789012:orders", expected: "arn:aws-us-gov:sqs:us-gov-west-1:XXXXXXXXXXXX:orders"},
+		{name: "queue URL", value: "https://sqs.us-east-1.amazonaws.com/123456789012/orders", expected: "https://sqs.us-east-1.amazonaws.com/XXXXXXXXXXXX/orders"},
+		{
+			name:     "ARN inside a redrive policy",
+			value:    `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:123456789012:dlq","maxReceiveCount":"3"}`,
+			expected: `{"deadLetterTargetArn":"arn:aws:sqs:us-east-1:XXXXXXXXXXXX:dlq","maxReceiveCount":"3"}`,
+		},
+		{name: "bare account ID", value: "123456789012", expected: "XXXXXXXXXXXX"},
+		{name: "timestamp is left alone", value: "1640995200000", expected: "1640995200000"},
+		{name: "IAM principal is left alone", value: "AIDAEXAMPLE:i-0123456789", expected: "AIDAEXAMPLE:i-0123456789"},
+		{name: "plain name", value: "orders", expected: "orders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskAccountIDs(tt.value); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_MaskAccountIDs(t *testing.T) {
+	const dlqName = "demo-deadletter-queue"
+
+	for _, masked := range []bool{true, false} {
+		t.Run(map[bool]string{true: "masked", false: "unmasked"}[masked], func(t *testing.T) {
+			if masked {
+				t.Setenv("MASK_ACCOUNT_IDS", "true")
+			} else {
+				t.Setenv("MASK_ACCOUNT_IDS", "")
+			}
+			handler := &SQSHandler{Client: &accountAttributesClient{demo.NewDemoSQSClient()}, isDemo: true}
+
+			rr := httptest.NewRecorder()
+			handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+			var queues []internal_types.Queue
+			if err := json.Unmarshal(rr.Body.Bytes(), &queues); err != nil {
+				t.Fatalf("failed to decode queues: %v", err)
+			}
+
+			var dlq internal_types.Queue
+			for _, queue := range queues {
+				if !strings.Contains(queue.URL, demoAccountID) {
+					t.Errorf("expected %s to keep a usable URL, got %q", queue.Name, queue.URL)
+				}
+				for name, value := range queue.Attributes {
+					if masked && strings.Contains(value, demoAccountID) {
+						t.Errorf("%s attribute %s: expected masking, got %q", queue.Name, name, value)
+					}
+				}
+				if arn := queue.Attributes["QueueArn"]; strings.Contains(arn, demoAccountID) == masked {
+					t.Errorf("%s QueueArn: masked=%v but got %q", queue.Name, masked, arn)
+				}
+				if queue.Name == dlqName {
+					dlq = queue
+				}
+			}
+			if dlq.URL == "" {
+				t.Fatalf("expected %s in the queue list", dlqName)
+			}
+			if masked && dlq.DisplayURL != "https://sqs.us-east-1.amazonaws.com/XXXXXXXXXXXX/"+dlqName {
+				t.Errorf("expected a masked displayUrl, got %q", dlq.DisplayURL)
+			}
+			if !masked && dlq.DisplayURL != "" {
+				t.Errorf("expected no displayUrl, got %q", dlq.DisplayURL)
+			}
+
+			// The unmasked URL from the list still works for follow-up requests
+			req := httptest.NewRequest("GET", "/api/queues/x/messages", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": dlq.URL})
+			rr = httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected GetMessages 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			var messages []internal_types.Message
+			if err := json.Unmarshal(rr.Body.Bytes(), &messages); err != nil {
+				t.Fatalf("failed to decode messages: %v", err)
+			}
+			if len(messages) == 0 {
+				t.Fatal("expected DLQ messages")
+			}
+			for _, msg := range messages {
+				if msg.ReceiptHandle == "" {
+					t.Errorf("expected message %s to keep its receipt handle", msg.MessageId)
+				}
+				sender := msg.Attributes["SenderId"]
+				source := msg.Attributes["DeadLetterQueueSourceArn"]
+				if masked && (sender != maskedAccountID || strings.Contains(source, demoAccountID)) {
+					t.Errorf("expected masked attributes, got SenderId=%q source=%q", sender, source)
+				}
+				if !masked && sender != demoAccountID {
+					t.Errorf("expected SenderId %q, got %q", demoAccountID, sender)
+				}
+			}
+
+			req = httptest.NewRequest("GET", "/api/queues/x/statistics", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": dlq.URL})
+			rr = httptest.NewRecorder()
+			handler.GetQueueStatistics(rr, req)
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected statistics 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if masked && strings.Contains(rr.Body.String(), demoAccountID) {
+				t.Errorf("expected masked statistics, got %s", rr.Body.String())
+			}
+		})
+	}
+}
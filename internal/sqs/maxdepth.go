@@ -0,0 +1,44 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+)
+
+// maxDepthExceededResponse is the 409 body for a send refused by maxDepth.
+type maxDepthExceededResponse struct {
+	Error      string `json:"error"`
+	QueueDepth int    `json:"queueDepth"`
+	MaxDepth   int    `json:"maxDepth"`
+}
+
+// checkMaxDepth guards a send with an optional "maxDepth": when set, it reads
+// the queue's ApproximateNumberOfMessages once and answers 409 if the queue is
+// already at or above it, so automated replays cannot overfill a queue. It
+// reports whether the send may go ahead; otherwise the response is written.
+func (h *SQSHandler) checkMaxDepth(w http.ResponseWriter, r *http.Request, queueURL string, maxDepth *int) bool {
+	if maxDepth == nil {
+		return true
+	}
+	if *maxDepth <= 0 {
+		http.Error(w, "maxDepth must be a positive integer", http.StatusBadRequest)
+		return false
+	}
+
+	depth, err := h.sampleQueueDepth(r.Context(), queueURL)
+	if err != nil {
+		log.Printf("Error reading depth of queue %s for maxDepth: %v", queueURL, err)
+		writeSQSError(w, r, err, queueURL)
+		return false
+	}
+	if depth.Visible >= *maxDepth {
+		log.Printf("Refusing send to queue %s: depth %d is at or above maxDepth %d", queueURL, depth.Visible, *maxDepth)
+		writeJSONStatus(w, r, http.StatusConflict, maxDepthExceededResponse{
+			Error:      "queue depth is at or above maxDepth",
+			QueueDepth: depth.Visible,
+			MaxDepth:   *maxDepth,
+		})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package sqs
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_SendMessage_MaxDepth(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/replay-queue"
+
+	tests := []struct {
+		name           string
+		depth          int
+		payload        string
+		expectedStatus int
+		expectedSends  int
+	}{
+		{name: "below max depth", depth: 9, payload: `{"body":"replayed","maxDepth":10}`, expectedStatus: http.StatusOK, expectedSends: 1},
+		{name: "at max depth", depth: 10, payload: `{"body":"replayed","maxDepth":10}`, expectedStatus: http.StatusConflict},
+		{name: "above max depth", depth: 25, payload: `{"body":"replayed","maxDepth":10}`, expectedStatus: http.StatusConflict},
+		{name: "no max depth", depth: 25, payload: `{"body":"replayed"}`, expectedStatus: http.StatusOK, expectedSends: 1},
+		{name: "invalid max depth", depth: 0, payload: `{"body":"replayed","maxDepth":0}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := helpers.NewMockSQSClient()
+			mock.AddQueue(queueURL)
+			mock.SetQueueDepth(queueURL, tt.depth)
+			handler := &SQSHandler{Client: mock}
+
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", bytes.NewReader([]byte(tt.payload)))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.SendMessage(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if len(mock.SendMessageCalls) != tt.expectedSends {
+				t.Errorf("expected %d sends, got %d", tt.expectedSends, len(mock.SendMessageCalls))
+			}
+			if rr.Code == http.StatusConflict {
+				var response maxDepthExceededResponse
+				if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if response.QueueDepth != tt.depth || response.MaxDepth != 10 {
+					t.Errorf("expected depth %d and maxDepth 10, got %+v", tt.depth, response)
+				}
+			}
+		})
+	}
+}
+
+func TestSQSHandler_SendTemplateMessages_MaxDepth(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/replay-queue"
+
+	send := func(depth int) (*httptest.ResponseRecorder, *helpers.MockSQSClient) {
+		mock := helpers.NewMockSQSClient()
+		mock.AddQueue(queueURL)
+		mock.SetQueueDepth(queueURL, depth)
+		handler := &SQSHandler{Client: mock}
+
+		payload := `{"template":"{\"seq\":{{.Index}}}","count":25,"maxDepth":100}`
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages/template", bytes.NewReader([]byte(payload)))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+		rr := httptest.NewRecorder()
+		handler.SendTemplateMessages(rr, req)
+		return rr, mock
+	}
+
+	rr, mock := send(100)
+	if rr.Code != http.StatusConflict || len(mock.SendMessageBatchCalls) != 0 {
+		t.Errorf("expected 409 with nothing sent at max depth, got %d with %d batches", rr.Code, len(mock.SendMessageBatchCalls))
+	}
+
+	// The depth is read once for the request, so all three batches go out
+	rr, mock = send(99)
+	if rr.Code != http.StatusOK || len(mock.SendMessageBatchCalls) != 3 {
+		t.Errorf("expected 200 with 3 batches below max depth, got %d with %d batches", rr.Code, len(mock.SendMessageBatchCalls))
+	}
+}
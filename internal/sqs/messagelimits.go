@@ -0,0 +1,115 @@
+package sqs
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// sqsMaxReceive is the SQS limit on messages per ReceiveMessage call.
+	sqsMaxReceive = 10
+	// defaultMessagesLimit is the GetMessages page size and ceiling when
+	// MESSAGES_DEFAULT_LIMIT and MESSAGES_MAX_LIMIT are not set.
+	defaultMessagesLimit = 10
+	// messagesLimitCeiling bounds MESSAGES_MAX_LIMIT, since every 10 messages
+	// past the first page cost another receive against a live queue.
+	messagesLimitCeiling = 1000
+)
+
+// messagesLimitsFromEnv returns the GetMessages default page size
+// (MESSAGES_DEFAULT_LIMIT) and the largest ?limit= accepted
+// (MESSAGES_MAX_LIMIT). Both default to 10, a single SQS receive; a larger
+// maximum makes live pages accumulate several receives. The default never
+// exceeds the maximum.
+func messagesLimitsFromEnv() (int, int) {
+	maxLimit := defaultMessagesLimit
+	if value := os.Getenv("MESSAGES_MAX_LIMIT"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			maxLimit = min(n, messagesLimitCeiling)
+		} else {
+			log.Printf("Invalid MESSAGES_MAX_LIMIT %q, using %d", value, defaultMessagesLimit)
+		}
+	}
+
+	defaultLimit := min(defaultMessagesLimit, maxLimit)
+	if value := os.Getenv("MESSAGES_DEFAULT_LIMIT"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			defaultLimit = min(n, maxLimit)
+		} else {
+			log.Printf("Invalid MESSAGES_DEFAULT_LIMIT %q, using %d", value, defaultLimit)
+		}
+	}
+
+	return defaultLimit, maxLimit
+}
+
+// receivePage receives up to count messages for GetMessages. Demo clients and
+// counts within one SQS receive take a single call; larger live pages
+// accumulate receives of up to 10 until count is reached or a receive turns
+// up nothing new. Received messages become invisible as usual, so each
+// receive returns different messages.
+func (h *SQSHandler) receivePage(ctx context.Context, input *sqs.ReceiveMessageInput, count int) ([]types.Message, error) {
+	if h.isDemo || count <= sqsMaxReceive {
+		input.MaxNumberOfMessages = int32(count)
+		result, err := h.Client.ReceiveMessage(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+		return result.Messages, nil
+	}
+
+	seen := make(map[string]bool, count)
+	received := make([]types.Message, 0, count)
+	for len(received) < count {
+		batch := *input
+		batch.MaxNumberOfMessages = int32(min(count-len(received), sqsMaxReceive))
+		result, err := h.Client.ReceiveMessage(ctx, &batch)
+		if err != nil {
+			if len(received) > 0 {
+				// Serve the messages already received rather than lose them
+				// to their visibility timeout
+				log.Printf("GetMessages: Receive failed after %d messages: %v", len(received), err)
+				break
+			}
+			return nil, err
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+
+		added := 0
+		for _, msg := range result.Messages {
+			id := aws.ToString(msg.MessageId)
+			if seen[id] || len(received) >= count {
+				continue
+			}
+			seen[id] = true
+			received = append(received, msg)
+			added++
+		}
+		if added == 0 {
+			break
+		}
+	}
+	return received, nil
+}
+
+// pageWindow returns the messages from offset up to limit of them: offset
+// selects the window start and limit bounds its length, so a window running
+// past the end is shortened and one starting past the end is empty. The
+// result is never nil so it encodes as [].
+func pageWindow[T any](messages []T, offset, limit int) []T {
+	if offset < 0 || offset >= len(messages) || limit <= 0 {
+		return []T{}
+	}
+	window := messages[offset:]
+	if limit < len(window) {
+		window = window[:limit]
+	}
+	return window
+}
@@ -0,0 +1,136 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestMessagesLimitsFromEnv(t *testing.T) {
+	tests := []struct {
+		name            string
+		defaultEnv      string
+		maxEnv          string
+		expectedDefault int
+		expectedMax     int
+	}{
+		{name: "unset", expectedDefault: 10, expectedMax: 10},
+		{name: "raised max keeps default", maxEnv: "50", expectedDefault: 10, expectedMax: 50},
+		{name: "default above max is clamped", defaultEnv: "20", maxEnv: "5", expectedDefault: 5, expectedMax: 5},
+		{name: "max capped at ceiling", maxEnv: "5000", expectedDefault: 10, expectedMax: messagesLimitCeiling},
+		{name: "invalid values ignored", defaultEnv: "abc", maxEnv: "0", expectedDefault: 10, expectedMax: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MESSAGES_DEFAULT_LIMIT", tt.defaultEnv)
+			t.Setenv("MESSAGES_MAX_LIMIT", tt.maxEnv)
+
+			defaultLimit, maxLimit := messagesLimitsFromEnv()
+			if defaultLimit != tt.expectedDefault || maxLimit != tt.expectedMax {
+				t.Errorf("expected (%d, %d), got (%d, %d)", tt.expectedDefault, tt.expectedMax, defaultLimit, maxLimit)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessages_ConfiguredLimits(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name             string
+		defaultEnv       string
+		maxEnv           string
+		query            string
+		expectedStatus   int
+		expectedCount    int
+		expectedReceives int
+	}{
+		{name: "configured default applies", defaultEnv: "5", expectedStatus: http.StatusOK, expectedCount: 5, expectedReceives: 1},
+		{name: "limit up to max accumulates receives", maxEnv: "25", query: "?limit=25", expectedStatus: http.StatusOK, expectedCount: 25, expectedReceives: 3},
+		{name: "accumulation stops when queue runs dry", maxEnv: "100", query: "?limit=100", expectedStatus: http.StatusOK, expectedCount: 45, expectedReceives: 6},
+		{name: "limit over max rejected", maxEnv: "25", query: "?limit=26", expectedStatus: http.StatusBadRequest},
+		{name: "default max rejects over 10", query: "?limit=11", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MESSAGES_DEFAULT_LIMIT", tt.defaultEnv)
+			t.Setenv("MESSAGES_MAX_LIMIT", tt.maxEnv)
+
+			client := &batchingReceiveClient{MockSQSClient: helpers.NewMockSQSClient()}
+			for i := 0; i < 45; i++ {
+				client.messages = append(client.messages, sqstypes.Message{
+					MessageId: aws.String(fmt.Sprintf("msg-%d", i)),
+					Body:      aws.String("body"),
+				})
+			}
+			handler := &SQSHandler{Client: client}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+
+			handler.GetMessages(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				if client.receives != 0 {
+					t.Errorf("expected no receives for a rejected limit, got %d", client.receives)
+				}
+				return
+			}
+
+			var messages []types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(messages) != tt.expectedCount {
+				t.Errorf("expected %d messages, got %d", tt.expectedCount, len(messages))
+			}
+			if client.receives != tt.expectedReceives {
+				t.Errorf("expected %d receives, got %d", tt.expectedReceives, client.receives)
+			}
+		})
+	}
+}
+
+func TestPageWindow(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	tests := []struct {
+		name     string
+		offset   int
+		limit    int
+		expected []int
+	}{
+		{name: "first page", offset: 0, limit: 3, expected: []int{1, 2, 3}},
+		{name: "middle page", offset: 3, limit: 3, expected: []int{4, 5, 6}},
+		{name: "limit exceeds remainder", offset: 5, limit: 3, expected: []int{6, 7}},
+		{name: "limit exceeds all", offset: 0, limit: 10, expected: items},
+		{name: "offset at end", offset: 7, limit: 3, expected: []int{}},
+		{name: "offset beyond end", offset: 50, limit: 3, expected: []int{}},
+		{name: "negative offset", offset: -1, limit: 3, expected: []int{}},
+		{name: "zero limit", offset: 0, limit: 0, expected: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pageWindow(items, tt.offset, tt.limit)
+			if got == nil || !slices.Equal(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package sqs
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// ConvertMessage maps an SDK message onto the API message shape shared by the
+// REST handlers and the WebSocket stream.
+func ConvertMessage(msg types.Message) internal_types.Message {
+	message := internal_types.Message{
+		MessageId:     aws.ToString(msg.MessageId),
+		Body:          aws.ToString(msg.Body),
+		ReceiptHandle: aws.ToString(msg.ReceiptHandle),
+		Attributes:    make(map[string]string),
+	}
+
+	for k, v := range msg.Attributes {
+		message.Attributes[k] = v
+	}
+
+	// AWSTraceHeader is a message system attribute carrying the X-Ray trace
+	// context; surface it directly for tracing users.
+	message.TraceHeader = msg.Attributes[string(types.MessageSystemAttributeNameAWSTraceHeader)]
+
+	message.ContentType = inferContentType(message.Body)
+
+	if latency, ok := firstReceiveLatencyMs(msg.Attributes); ok {
+		message.FirstReceiveLatencyMs = &latency
+	}
+
+	return message
+}
+
+// traceHeaderAttributes returns the message system attributes that send
+// traceHeader as AWSTraceHeader, or nil when it is empty.
+func traceHeaderAttributes(traceHeader string) map[string]types.MessageSystemAttributeValue {
+	if traceHeader == "" {
+		return nil
+	}
+	return map[string]types.MessageSystemAttributeValue{
+		string(types.MessageSystemAttributeNameForSendsAWSTraceHeader): {
+			DataType:    aws.String("String"),
+			StringValue: aws.String(traceHeader),
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package sqs
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// messageSize is a message's size as SQS counts it against maxMessageBytes.
+type messageSize struct {
+	BodyBytes      int `json:"bodyBytes"`
+	AttributeBytes int `json:"attributeBytes"`
+	TotalBytes     int `json:"totalBytes"`
+	LimitBytes     int `json:"limitBytes"`
+}
+
+// exceedsLimit reports whether the message is too large to send.
+func (s messageSize) exceedsLimit() bool {
+	return s.TotalBytes > s.LimitBytes
+}
+
+// sqsMessageSize computes the size SQS charges for a message: the body bytes
+// plus, per message attribute, the bytes of its name, data type and value.
+// System attributes such as AWSTraceHeader do not count.
+func sqsMessageSize(body string, attributes map[string]types.MessageAttributeValue) messageSize {
+	size := messageSize{BodyBytes: len(body), LimitBytes: maxMessageBytes}
+	for name, value := range attributes {
+		size.AttributeBytes += len(name) + len(aws.ToString(value.DataType)) +
+			len(aws.ToString(value.StringValue)) + len(value.BinaryValue)
+	}
+	size.TotalBytes = size.BodyBytes + size.AttributeBytes
+	return size
+}
+
+// sendBatches splits items into SendMessageBatch requests within both batch
+// limits: at most sendBatchSize entries, and at most maxMessageBytes of bodies
+// and attributes combined. entry returns the batch entry an item sends.
+func sendBatches[T any](items []T, entry func(T) types.SendMessageBatchRequestEntry) [][]T {
+	var batches [][]T
+	start, batchBytes := 0, 0
+	for i, item := range items {
+		e := entry(item)
+		size := sqsMessageSize(aws.ToString(e.MessageBody), e.MessageAttributes).TotalBytes
+		if i > start && (i-start == sendBatchSize || batchBytes+size > maxMessageBytes) {
+			batches = append(batches, items[start:i])
+			start, batchBytes = i, 0
+		}
+		batchBytes += size
+	}
+	if start < len(items) {
+		batches = append(batches, items[start:])
+	}
+	return batches
+}
+
+// stringMessageAttributes converts plain request attributes to String
+// message attributes, or nil when there are none.
+func stringMessageAttributes(attributes map[string]string) map[string]types.MessageAttributeValue {
+	if len(attributes) == 0 {
+		return nil
+	}
+	converted := make(map[string]types.MessageAttributeValue, len(attributes))
+	for name, value := range attributes {
+		converted[name] = types.MessageAttributeValue{
+			DataType:    aws.String(stringAttributeType),
+			StringValue: aws.String(value),
+		}
+	}
+	return converted
+}
+
+// tooLargeMessage describes an oversized message for errors and logs.
+func tooLargeMessage(size messageSize) string {
+	return fmt.Sprintf("message is %d bytes including attributes (body %d, attributes %d), over the %d byte limit",
+		size.TotalBytes, size.BodyBytes, size.AttributeBytes, size.LimitBytes)
+}
+
+// writeMessageTooLarge answers 413 with the size breakdown of the message
+// that was refused. details, when non-nil, is merged into the response.
+func writeMessageTooLarge(w http.ResponseWriter, r *http.Request, size messageSize, details map[string]interface{}) {
+	response := map[string]interface{}{
+		"error": tooLargeMessage(size),
+		"size":  size,
+	}
+	for key, value := range details {
+		response[key] = value
+	}
+	writeJSONStatus(w, r, http.StatusRequestEntityTooLarge, response)
+}
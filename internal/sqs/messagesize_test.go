@@ -0,0 +1,233 @@
+package sqs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSMessageSize(t *testing.T) {
+	size := sqsMessageSize("hello", map[string]sqstypes.MessageAttributeValue{
+		"source": {DataType: aws.String("String"), StringValue: aws.String("api")},
+		"blob":   {DataType: aws.String("Binary"), BinaryValue: []byte{1, 2, 3, 4}},
+	})
+
+	// source: 6 + 6 + 3, blob: 4 + 6 + 4
+	expected := messageSize{BodyBytes: 5, AttributeBytes: 29, TotalBytes: 34, LimitBytes: maxMessageBytes}
+	if size != expected {
+		t.Errorf("expected %+v, got %+v", expected, size)
+	}
+	if size.exceedsLimit() {
+		t.Error("did not expect a small message to exceed the limit")
+	}
+}
+
+func TestSQSHandler_SendMessage_SizeLimit(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	// 16 bytes under the limit on its own
+	body := strings.Repeat("x", maxMessageBytes-16)
+
+	tests := []struct {
+		name           string
+		payload        map[string]interface{}
+		expectedStatus int
+		expectedSize   *messageSize
+	}{
+		{
+			name:           "body alone fits",
+			payload:        map[string]interface{}{"body": body},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "trace header does not count",
+			payload:        map[string]interface{}{"body": body, "traceHeader": strings.Repeat("t", 64)},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "attribute pushes message over the limit",
+			payload: map[string]interface{}{
+				"body":       body,
+				"attributes": map[string]string{"source": "checkout"},
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			// source (6) + String (6) + checkout (8)
+			expectedSize: &messageSize{
+				BodyBytes:      maxMessageBytes - 16,
+				AttributeBytes: 20,
+				TotalBytes:     maxMessageBytes + 4,
+				LimitBytes:     maxMessageBytes,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			handler := &SQSHandler{Client: mockClient}
+
+			raw, _ := json.Marshal(tt.payload)
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", bytes.NewReader(raw))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+
+			handler.SendMessage(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d (body=%s)", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedSize == nil {
+				if len(mockClient.SendMessageCalls) != 1 {
+					t.Errorf("expected 1 SendMessage call, got %d", len(mockClient.SendMessageCalls))
+				}
+				return
+			}
+
+			if len(mockClient.SendMessageCalls) != 0 {
+				t.Errorf("expected no SendMessage call, got %d", len(mockClient.SendMessageCalls))
+			}
+			var resp struct {
+				Error string      `json:"error"`
+				Size  messageSize `json:"size"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Size != *tt.expectedSize {
+				t.Errorf("expected size %+v, got %+v", *tt.expectedSize, resp.Size)
+			}
+			if !strings.Contains(resp.Error, fmt.Sprint(tt.expectedSize.TotalBytes)) {
+				t.Errorf("expected the error to state the total size, got %q", resp.Error)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_BatchSends_SizeLimit(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	body := strings.Repeat("x", maxMessageBytes-16)
+
+	t.Run("import reports the oversized line", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		handler := &SQSHandler{Client: mockClient}
+
+		fits, _ := json.Marshal(map[string]interface{}{"body": body})
+		tooLarge, _ := json.Marshal(map[string]interface{}{
+			"body":       body,
+			"attributes": map[string]string{"source": "checkout"},
+		})
+		rr := httptest.NewRecorder()
+		handler.ImportMessages(rr, importReq(t, queueURL, string(fits)+"\n"+string(tooLarge)+"\n"))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d (body=%s)", rr.Code, rr.Body.String())
+		}
+		var resp importResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Details.Sent != 1 || len(resp.Details.Failed) != 1 || resp.Details.Failed[0].Line != 2 {
+			t.Fatalf("expected line 1 sent and line 2 failed, got %+v", resp.Details)
+		}
+		if want := tooLargeMessage(messageSize{BodyBytes: len(body), AttributeBytes: 20, TotalBytes: len(body) + 20, LimitBytes: maxMessageBytes}); resp.Details.Failed[0].Error != want {
+			t.Errorf("expected error %q, got %q", want, resp.Details.Failed[0].Error)
+		}
+	})
+
+	t.Run("template rejects an oversized render", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		handler := &SQSHandler{Client: mockClient}
+
+		raw, _ := json.Marshal(map[string]interface{}{
+			"template":  `{{if eq .Index 1}}{{.Vars.padding}}{{.Vars.padding}}{{else}}small{{end}}`,
+			"count":     2,
+			"variables": map[string]interface{}{"padding": strings.Repeat("p", maxMessageBytes/2+1)},
+		})
+		rr := httptest.NewRecorder()
+		handler.SendTemplateMessages(rr, templateReq(queueURL, string(raw)))
+
+		if rr.Code != http.StatusRequestEntityTooLarge {
+			t.Fatalf("expected 413, got %d (body=%s)", rr.Code, rr.Body.String())
+		}
+		if len(mockClient.SendMessageBatchCalls) != 0 {
+			t.Errorf("expected nothing sent, got %d batches", len(mockClient.SendMessageBatchCalls))
+		}
+		var resp struct {
+			Index int         `json:"index"`
+			Size  messageSize `json:"size"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Index != 1 || resp.Size.TotalBytes != maxMessageBytes+2 {
+			t.Errorf("expected index 1 at %d bytes, got %+v", maxMessageBytes+2, resp)
+		}
+	})
+}
+
+func TestSQSHandler_BatchSends_SplitBySize(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	// Ten 100 KiB messages: only two fit in one 256 KiB batch
+	body := strings.Repeat("x", 100*1024)
+
+	batchSizes := func(calls []awssqs.SendMessageBatchInput) string {
+		var sizes []int
+		for _, call := range calls {
+			total := 0
+			for _, entry := range call.Entries {
+				total += len(aws.ToString(entry.MessageBody))
+			}
+			if total > maxMessageBytes {
+				t.Errorf("batch of %d bytes exceeds the %d byte limit", total, maxMessageBytes)
+			}
+			sizes = append(sizes, len(call.Entries))
+		}
+		return fmt.Sprint(sizes)
+	}
+
+	t.Run("import", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		handler := &SQSHandler{Client: mockClient}
+
+		line, _ := json.Marshal(map[string]interface{}{"body": body})
+		rr := httptest.NewRecorder()
+		handler.ImportMessages(rr, importReq(t, queueURL, strings.Repeat(string(line)+"\n", 10)))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d (body=%s)", rr.Code, rr.Body.String())
+		}
+		if got := batchSizes(mockClient.SendMessageBatchCalls); got != "[2 2 2 2 2]" {
+			t.Errorf("expected batches of [2 2 2 2 2], got %s", got)
+		}
+	})
+
+	t.Run("template", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		handler := &SQSHandler{Client: mockClient}
+
+		raw, _ := json.Marshal(map[string]interface{}{
+			"template":  `{{.Vars.padding}}`,
+			"count":     10,
+			"variables": map[string]interface{}{"padding": body},
+		})
+		rr := httptest.NewRecorder()
+		handler.SendTemplateMessages(rr, templateReq(queueURL, string(raw)))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d (body=%s)", rr.Code, rr.Body.String())
+		}
+		if got := batchSizes(mockClient.SendMessageBatchCalls); got != "[2 2 2 2 2]" {
+			t.Errorf("expected batches of [2 2 2 2 2], got %s", got)
+		}
+	})
+}
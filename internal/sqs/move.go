@@ -0,0 +1,262 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+const (
+	// defaultMoveLimit is how many matching messages a move transfers when
+	// the request does not say.
+	defaultMoveLimit = 100
+	// maxMoveLimit caps the limit of a single move request.
+	maxMoveLimit = 1000
+	// maxMoveScanned bounds how many messages a move inspects, so a queue of
+	// mostly non-matching messages cannot keep the scan running indefinitely.
+	maxMoveScanned = 10000
+)
+
+// messageFilter selects messages the way the UI's search box does: every
+// attribute filter must match, then the text must appear in the message ID,
+// body, or an attribute name or value. All matching is a case-insensitive
+// substring match. Attributes cover both system and string message attributes.
+type messageFilter struct {
+	Text       string            `json:"text"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// messageFilterAttributes merges a message's system attributes with its
+// string message attributes, which take precedence on a name clash.
+func messageFilterAttributes(msg types.Message) map[string]string {
+	attributes := make(map[string]string, len(msg.Attributes)+len(msg.MessageAttributes))
+	for name, value := range msg.Attributes {
+		attributes[name] = value
+	}
+	for name, value := range msg.MessageAttributes {
+		if value.StringValue != nil {
+			attributes[name] = *value.StringValue
+		}
+	}
+	return attributes
+}
+
+// matches reports whether msg passes the filter. An empty filter matches
+// every message.
+func (f messageFilter) matches(msg types.Message) bool {
+	attributes := messageFilterAttributes(msg)
+
+	for name, want := range f.Attributes {
+		value, ok := attributes[name]
+		if !ok || value == "" || !strings.Contains(strings.ToLower(value), strings.ToLower(want)) {
+			return false
+		}
+	}
+
+	text := strings.ToLower(strings.TrimSpace(f.Text))
+	if text == "" {
+		return true
+	}
+	if strings.Contains(strings.ToLower(aws.ToString(msg.MessageId)), text) ||
+		strings.Contains(strings.ToLower(aws.ToString(msg.Body)), text) {
+		return true
+	}
+	for name, value := range attributes {
+		if strings.Contains(strings.ToLower(name), text) || strings.Contains(strings.ToLower(value), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// moveFailure reports a matching message that could not be moved.
+type moveFailure struct {
+	MessageID string `json:"messageId"`
+	Error     string `json:"error"`
+}
+
+// moveMessage sends msg to the target queue with body, its message
+// attributes and trace header, then deletes it from the source. FIFO targets
+// keep the message's group and use its ID for deduplication.
+func (h *SQSHandler) moveMessage(ctx context.Context, sourceURL, targetURL string, msg types.Message, body string) error {
+	input := &sqs.SendMessageInput{
+		QueueUrl:                aws.String(targetURL),
+		MessageBody:             aws.String(body),
+		MessageAttributes:       msg.MessageAttributes,
+		MessageSystemAttributes: traceHeaderAttributes(msg.Attributes[string(types.MessageSystemAttributeNameAWSTraceHeader)]),
+	}
+	if isFIFOQueue(targetURL) {
+		groupID := msg.Attributes[string(types.MessageSystemAttributeNameMessageGroupId)]
+		if groupID == "" {
+			groupID = "moved"
+		}
+		input.MessageGroupId = aws.String(groupID)
+		input.MessageDeduplicationId = msg.MessageId
+	}
+
+	if _, err := h.Client.SendMessage(ctx, input); err != nil {
+		return err
+	}
+
+	_, err := h.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(sourceURL),
+		ReceiptHandle: msg.ReceiptHandle,
+	})
+	return err
+}
+
+// MoveMessages handles HTTP requests to move the messages matching a filter
+// to another queue, up to a limit. Messages are received with a zero
+// visibility timeout, so those that do not match are never hidden from other
+// consumers; matching messages are sent to the target and then deleted.
+//
+// An optional "patch" list of JSON Patch (RFC 6902) operations is applied to
+// each matching body before it is sent. A malformed patch is answered with 422
+// before anything moves; a message the patch does not apply to, or whose body
+// isn't JSON, stays in place and is reported in failed.
+func (h *SQSHandler) MoveMessages(w http.ResponseWriter, r *http.Request) {
+	sourceURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var payload struct {
+		TargetQueueURL string           `json:"targetQueueUrl"`
+		Filter         messageFilter    `json:"filter"`
+		Limit          int              `json:"limit"`
+		Patch          []patchOperation `json:"patch"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := checkJSONPatch(payload.Patch); err != nil {
+		writeJSONStatus(w, r, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	targetURL := normalizeQueueURL(payload.TargetQueueURL)
+	if targetURL == "" {
+		http.Error(w, "targetQueueUrl is required", http.StatusBadRequest)
+		return
+	}
+	if targetURL == sourceURL {
+		http.Error(w, "targetQueueUrl must differ from the source queue", http.StatusBadRequest)
+		return
+	}
+	if !retryTargetAllowed(targetURL) {
+		log.Printf("MoveMessages: Refusing target %s, not allowed by RETRY_TARGET_ALLOW", targetURL)
+		http.Error(w, "targetQueueUrl is not allowed by RETRY_TARGET_ALLOW", http.StatusForbidden)
+		return
+	}
+
+	limit := payload.Limit
+	switch {
+	case limit < 0:
+		http.Error(w, "limit must be positive", http.StatusBadRequest)
+		return
+	case limit == 0:
+		limit = defaultMoveLimit
+	case limit > maxMoveLimit:
+		limit = maxMoveLimit
+	}
+
+	maxReceive := int32(10)
+	if h.isDemo {
+		maxReceive = 1000
+	}
+
+	ctx := r.Context()
+	moved, skipped := 0, 0
+	failed := []moveFailure{}
+	seen := make(map[string]bool)
+
+	// Scan until the limit is reached or a receive yields nothing new.
+	// Messages that failed to move stay on the queue; the seen set stops
+	// them being retried.
+	for moved+len(failed) < limit && len(seen) < maxMoveScanned {
+		result, err := h.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(sourceURL),
+			MaxNumberOfMessages:   maxReceive,
+			AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
+			MessageAttributeNames: []string{"All"},
+		})
+		if err != nil {
+			log.Printf("MoveMessages: Error receiving from queue %s: %v", sourceURL, err)
+			writeSQSError(w, r, err, sourceURL)
+			return
+		}
+		noteRequestID(ctx, result.ResultMetadata)
+
+		fresh := 0
+		// left are the received messages staying on the source queue, made
+		// visible again once this batch is done
+		var left []types.Message
+		for _, msg := range result.Messages {
+			messageID := aws.ToString(msg.MessageId)
+			if seen[messageID] {
+				left = append(left, msg)
+				continue
+			}
+			seen[messageID] = true
+			fresh++
+
+			if !payload.Filter.matches(msg) {
+				skipped++
+				left = append(left, msg)
+				continue
+			}
+			if moved+len(failed) >= limit {
+				left = append(left, msg)
+				continue
+			}
+
+			body := aws.ToString(msg.Body)
+			if len(payload.Patch) > 0 {
+				patched, err := applyJSONPatch(body, payload.Patch)
+				if err != nil {
+					log.Printf("MoveMessages: Not moving message %s, patch failed: %v", messageID, err)
+					failed = append(failed, moveFailure{MessageID: messageID, Error: err.Error()})
+					left = append(left, msg)
+					continue
+				}
+				body = patched
+			}
+
+			if err := h.moveMessage(ctx, sourceURL, targetURL, msg, body); err != nil {
+				log.Printf("MoveMessages: Failed to move message %s: %v", messageID, err)
+				failed = append(failed, moveFailure{MessageID: messageID, Error: err.Error()})
+				left = append(left, msg)
+				continue
+			}
+			moved++
+		}
+		ReleaseMessages(ctx, h.Client, sourceURL, left)
+
+		if fresh == 0 {
+			break
+		}
+	}
+
+	log.Printf("MoveMessages: Moved %d messages from %s to %s (%d skipped, %d failed)", moved, sourceURL, targetURL, skipped, len(failed))
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:        statusMoved,
+		AffectedCount: affected(moved),
+		Details: map[string]interface{}{
+			"moved":   moved,
+			"skipped": skipped,
+			"failed":  failed,
+		},
+	})
+}
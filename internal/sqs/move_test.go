@@ -0,0 +1,309 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func moveReq(queueURL, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/move", strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+}
+
+// queueContents returns the IDs and Priority attributes of a demo queue's
+// messages, sorted by ID.
+func queueContents(t *testing.T, client *demo.DemoSQSClient, queueURL string) ([]string, map[string]string) {
+	t.Helper()
+	result, err := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: 1000,
+	})
+	if err != nil {
+		t.Fatalf("ReceiveMessage failed: %v", err)
+	}
+
+	ids := []string{}
+	priorities := map[string]string{}
+	for _, msg := range result.Messages {
+		id := aws.ToString(msg.MessageId)
+		ids = append(ids, id)
+		if priority, ok := msg.MessageAttributes["Priority"]; ok {
+			priorities[aws.ToString(msg.Body)] = aws.ToString(priority.StringValue)
+		}
+	}
+	sort.Strings(ids)
+	return ids, priorities
+}
+
+func TestSQSHandler_MoveMessages(t *testing.T) {
+	const sourceURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue"
+
+	tests := []struct {
+		name            string
+		body            string
+		expectedMoved   int
+		expectedSkipped int
+		expectedSource  []string
+	}{
+		{
+			name:            "moves only high priority messages",
+			body:            `{"targetQueueUrl": "` + targetURL + `", "filter": {"attributes": {"Priority": "high"}}}`,
+			expectedMoved:   2,
+			expectedSkipped: 1,
+			expectedSource:  []string{"ord-002"},
+		},
+		{
+			name:            "limit caps the moved messages",
+			body:            `{"targetQueueUrl": "` + targetURL + `", "filter": {"attributes": {"Priority": "HIGH"}}, "limit": 1}`,
+			expectedMoved:   1,
+			expectedSkipped: 1,
+			expectedSource:  []string{"ord-002", "ord-003"},
+		},
+		{
+			name:            "text filter matches the body",
+			body:            `{"targetQueueUrl": "` + targetURL + `", "filter": {"text": "processing"}}`,
+			expectedMoved:   1,
+			expectedSkipped: 2,
+			expectedSource:  []string{"ord-001", "ord-003"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := demo.NewDemoSQSClient()
+			targetBefore, _ := queueContents(t, client, targetURL)
+			handler := &SQSHandler{Client: client, isDemo: true}
+
+			rr := httptest.NewRecorder()
+			handler.MoveMessages(rr, moveReq(sourceURL, tt.body))
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+
+			var response struct {
+				Status        string `json:"status"`
+				AffectedCount int    `json:"affectedCount"`
+				Details       struct {
+					Moved   int           `json:"moved"`
+					Skipped int           `json:"skipped"`
+					Failed  []moveFailure `json:"failed"`
+				} `json:"details"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if response.Status != "moved" || response.AffectedCount != tt.expectedMoved {
+				t.Errorf("expected status moved affecting %d, got %+v", tt.expectedMoved, response)
+			}
+			if response.Details.Moved != tt.expectedMoved || response.Details.Skipped != tt.expectedSkipped || len(response.Details.Failed) != 0 {
+				t.Errorf("expected %d moved, %d skipped, none failed; got %+v", tt.expectedMoved, tt.expectedSkipped, response.Details)
+			}
+
+			source, _ := queueContents(t, client, sourceURL)
+			if strings.Join(source, ",") != strings.Join(tt.expectedSource, ",") {
+				t.Errorf("expected source to keep %v, got %v", tt.expectedSource, source)
+			}
+
+			target, _ := queueContents(t, client, targetURL)
+			if len(target) != len(targetBefore)+tt.expectedMoved {
+				t.Errorf("expected target to gain %d messages, had %d now %d", tt.expectedMoved, len(targetBefore), len(target))
+			}
+		})
+	}
+}
+
+func TestSQSHandler_MoveMessages_KeepsAttributes(t *testing.T) {
+	const sourceURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-archive-queue"
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	rr := httptest.NewRecorder()
+	handler.MoveMessages(rr, moveReq(sourceURL, `{"targetQueueUrl": "`+targetURL+`", "filter": {"attributes": {"Priority": "high"}}}`))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	_, priorities := queueContents(t, client, targetURL)
+	if len(priorities) != 2 {
+		t.Fatalf("expected 2 moved messages with a Priority attribute, got %v", priorities)
+	}
+	for body, priority := range priorities {
+		if priority != "high" {
+			t.Errorf("message %s: expected Priority high, got %s", body, priority)
+		}
+	}
+}
+
+func TestSQSHandler_MoveMessages_InvalidRequests(t *testing.T) {
+	const sourceURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "malformed body", body: `{`},
+		{name: "missing target", body: `{"filter": {"text": "x"}}`},
+		{name: "target is the source", body: `{"targetQueueUrl": "` + sourceURL + `"}`},
+		{name: "negative limit", body: `{"targetQueueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/other", "limit": -1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: &callRecordingClient{}}
+			rr := httptest.NewRecorder()
+			handler.MoveMessages(rr, moveReq(sourceURL, tt.body))
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", rr.Code)
+			}
+		})
+	}
+}
+
+func TestMessageFilter_Matches(t *testing.T) {
+	msg := sqstypes.Message{
+		MessageId:  aws.String("ord-001"),
+		Body:       aws.String(`{"status": "pending"}`),
+		Attributes: map[string]string{"ApproximateReceiveCount": "5"},
+		MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+			"Priority": {DataType: aws.String("String"), StringValue: aws.String("high")},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		filter   messageFilter
+		expected bool
+	}{
+		{name: "empty filter", expected: true},
+		{name: "message attribute", filter: messageFilter{Attributes: map[string]string{"Priority": "Hi"}}, expected: true},
+		{name: "system attribute", filter: messageFilter{Attributes: map[string]string{"ApproximateReceiveCount": "5"}}, expected: true},
+		{name: "attribute mismatch", filter: messageFilter{Attributes: map[string]string{"Priority": "low"}}, expected: false},
+		{name: "missing attribute", filter: messageFilter{Attributes: map[string]string{"Tenant": "a"}}, expected: false},
+		{name: "text in body", filter: messageFilter{Text: "PENDING"}, expected: true},
+		{name: "text in message ID", filter: messageFilter{Text: "ord-"}, expected: true},
+		{name: "text in attribute name", filter: messageFilter{Text: "priority"}, expected: true},
+		{name: "text and attribute", filter: messageFilter{Text: "shipped", Attributes: map[string]string{"Priority": "high"}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(msg); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_MoveMessages_ReleasesSkippedMessages(t *testing.T) {
+	const sourceURL = "https://sqs.us-east-1.amazonaws.com/123456789012/source-queue"
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/target-queue"
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue(sourceURL)
+	mock.AddQueue(targetURL)
+	mock.AddMessage(sourceURL, "msg-keep", "stays here")
+	mock.AddMessage(sourceURL, "msg-move", "move me")
+	handler := &SQSHandler{Client: mock}
+
+	rr := httptest.NewRecorder()
+	handler.MoveMessages(rr, moveReq(sourceURL, `{"targetQueueUrl": "`+targetURL+`", "filter": {"text": "move me"}}`))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	released := make(map[string]bool)
+	for _, call := range mock.ChangeMessageVisibilityCalls {
+		if call.QueueURL == sourceURL && call.VisibilityTimeout == 0 {
+			released[call.ReceiptHandle] = true
+		}
+	}
+	if !released["receipt-msg-keep"] {
+		t.Errorf("expected the skipped message to be made visible again, got %v", mock.ChangeMessageVisibilityCalls)
+	}
+	if released["receipt-msg-move"] {
+		t.Error("expected the moved message not to be made visible again")
+	}
+}
+
+func TestSQSHandler_MoveMessages_AppliesPatch(t *testing.T) {
+	const sourceURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue"
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	// Both high priority orders match; only ord-001 passes the test op
+	body := `{"targetQueueUrl": "` + targetURL + `", "filter": {"attributes": {"Priority": "high"}},
+		"patch": [{"op": "test", "path": "/amount", "value": 99.990}, {"op": "replace", "path": "/status", "value": "retried"}]}`
+	rr := httptest.NewRecorder()
+	handler.MoveMessages(rr, moveReq(sourceURL, body))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var response struct {
+		Details struct {
+			Moved  int           `json:"moved"`
+			Failed []moveFailure `json:"failed"`
+		} `json:"details"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Details.Moved != 1 || len(response.Details.Failed) != 1 || response.Details.Failed[0].MessageID != "ord-003" {
+		t.Fatalf("expected ord-001 moved and ord-003 failed, got %+v", response.Details)
+	}
+
+	source, _ := queueContents(t, client, sourceURL)
+	if strings.Join(source, ",") != "ord-002,ord-003" {
+		t.Errorf("expected the unpatched order to stay, got %v", source)
+	}
+	_, target := queueContents(t, client, targetURL)
+	if _, ok := target[`{"amount":99.99,"customerId":"cust-001","items":[{"quantity":2,"sku":"WIDGET-001"}],"orderId":"12345","status":"retried"}`]; !ok {
+		t.Errorf("expected the patched body on the target, got %v", target)
+	}
+
+	t.Run("malformed patch", func(t *testing.T) {
+		handler := &SQSHandler{Client: &callRecordingClient{}}
+		rr := httptest.NewRecorder()
+		handler.MoveMessages(rr, moveReq(sourceURL, `{"targetQueueUrl": "`+targetURL+`", "patch": [{"op": "merge", "path": "/a"}]}`))
+		if rr.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", rr.Code)
+		}
+	})
+}
+
+func TestSQSHandler_MoveMessages_RetryTargetAllow(t *testing.T) {
+	const sourceURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue"
+	t.Setenv("RETRY_TARGET_ALLOW", "demo-archive-*")
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+	rr := httptest.NewRecorder()
+	handler.MoveMessages(rr, moveReq(sourceURL, `{"targetQueueUrl": "`+targetURL+`"}`))
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if source, _ := queueContents(t, client, sourceURL); len(source) != 3 {
+		t.Errorf("expected nothing moved, source holds %v", source)
+	}
+}
@@ -0,0 +1,134 @@
+package sqs
+
+import (
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// Message sort orders by SentTimestamp.
+const (
+	SortNewestFirst = "desc"
+	SortOldestFirst = "asc"
+)
+
+// Value types for sorting by a message attribute with ?sortAttrType=.
+const (
+	sortAttrTypeString = "string"
+	sortAttrTypeNumber = "number"
+)
+
+// parseSortOrder normalizes an order value, reporting whether it is valid.
+func parseSortOrder(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case SortNewestFirst:
+		return SortNewestFirst, true
+	case SortOldestFirst:
+		return SortOldestFirst, true
+	}
+	return "", false
+}
+
+// DefaultSortOrder returns the message order from MESSAGE_SORT_ORDER
+// ("desc", newest first, or "asc", oldest first). Newest first is the default.
+func DefaultSortOrder() string {
+	value := os.Getenv("MESSAGE_SORT_ORDER")
+	if value == "" {
+		return SortNewestFirst
+	}
+	order, ok := parseSortOrder(value)
+	if !ok {
+		log.Printf("Invalid MESSAGE_SORT_ORDER %q, using %s", value, SortNewestFirst)
+		return SortNewestFirst
+	}
+	return order
+}
+
+// ResolveSortOrder returns the requested order when valid, otherwise the
+// MESSAGE_SORT_ORDER default.
+func ResolveSortOrder(requested string) string {
+	if order, ok := parseSortOrder(requested); ok {
+		return order
+	}
+	return DefaultSortOrder()
+}
+
+// SortMessages orders messages by SentTimestamp. The sort is stable so
+// messages sharing a timestamp keep their receive order.
+func SortMessages(messages []internal_types.Message, order string) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		timeI := getTimestampFromMessage(messages[i])
+		timeJ := getTimestampFromMessage(messages[j])
+		if order == SortOldestFirst {
+			return timeI < timeJ
+		}
+		return timeI > timeJ
+	})
+}
+
+// parseSortAttrType normalizes a ?sortAttrType= value, defaulting to string,
+// and reports whether it is valid.
+func parseSortAttrType(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", sortAttrTypeString:
+		return sortAttrTypeString, true
+	case sortAttrTypeNumber:
+		return sortAttrTypeNumber, true
+	}
+	return "", false
+}
+
+// SortMessagesByAttribute orders messages by the value of a message
+// attribute, highest first for SortNewestFirst and lowest first for
+// SortOldestFirst. Converted messages do not carry message attributes, so the
+// values are read from the received messages by ID. Number values compare
+// numerically and string values lexically; messages missing the attribute,
+// or with a value that is not a number, sort last in either order. The sort
+// is stable so ties keep their existing order.
+func SortMessagesByAttribute(messages []internal_types.Message, received []types.Message, attribute, valueType, order string) {
+	values := make(map[string]string, len(received))
+	for _, msg := range received {
+		if value, ok := msg.MessageAttributes[attribute]; ok && value.StringValue != nil {
+			values[aws.ToString(msg.MessageId)] = *value.StringValue
+		}
+	}
+
+	numbers := make(map[string]float64, len(values))
+	if valueType == sortAttrTypeNumber {
+		for id, value := range values {
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				numbers[id] = number
+			}
+		}
+	}
+
+	sort.SliceStable(messages, func(i, j int) bool {
+		idI, idJ := messages[i].MessageId, messages[j].MessageId
+		var hasI, hasJ bool
+		var less, greater bool
+		if valueType == sortAttrTypeNumber {
+			var numberI, numberJ float64
+			numberI, hasI = numbers[idI]
+			numberJ, hasJ = numbers[idJ]
+			less, greater = numberI < numberJ, numberI > numberJ
+		} else {
+			var valueI, valueJ string
+			valueI, hasI = values[idI]
+			valueJ, hasJ = values[idJ]
+			less, greater = valueI < valueJ, valueI > valueJ
+		}
+		if !hasI || !hasJ {
+			return hasI && !hasJ
+		}
+		if order == SortOldestFirst {
+			return less
+		}
+		return greater
+	})
+}
@@ -0,0 +1,139 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_GetMessages_SortOrder(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	tests := []struct {
+		name     string
+		envOrder string
+		query    string
+		expected string
+	}{
+		{name: "newest first by default", expected: "msg-3,msg-2,msg-1"},
+		{name: "env default oldest first", envOrder: "asc", expected: "msg-1,msg-2,msg-3"},
+		{name: "request overrides env", envOrder: "asc", query: "?order=desc", expected: "msg-3,msg-2,msg-1"},
+		{name: "request asc", query: "?order=ASC", expected: "msg-1,msg-2,msg-3"},
+		{name: "invalid request order falls back to env", envOrder: "asc", query: "?order=sideways", expected: "msg-1,msg-2,msg-3"},
+		{name: "invalid env falls back to newest first", envOrder: "oldest", expected: "msg-3,msg-2,msg-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MESSAGE_SORT_ORDER", tt.envOrder)
+
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			mockClient.AddMessageWithTimestamp(queueURL, "msg-2", "b", "1700000002000")
+			mockClient.AddMessageWithTimestamp(queueURL, "msg-3", "c", "1700000003000")
+			mockClient.AddMessageWithTimestamp(queueURL, "msg-1", "a", "1700000001000")
+			handler := &SQSHandler{Client: mockClient}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rr.Code)
+			}
+
+			var messages []types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode messages: %v", err)
+			}
+			ids := []string{}
+			for _, msg := range messages {
+				ids = append(ids, msg.MessageId)
+			}
+			if got := strings.Join(ids, ","); got != tt.expected {
+				t.Errorf("expected order %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessages_SortByAttribute(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue"
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expected       string
+	}{
+		{name: "number highest first", query: "&sortAttrType=number", expectedStatus: http.StatusOK, expected: "p10,p5,p1"},
+		{name: "number lowest first", query: "&sortAttrType=number&order=asc", expectedStatus: http.StatusOK, expected: "p1,p5,p10"},
+		{name: "string sorts lexically", query: "&order=asc", expectedStatus: http.StatusOK, expected: "p1,p10,p5"},
+		{name: "invalid type", query: "&sortAttrType=date", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := demo.NewDemoSQSClient()
+			bodies := make(map[string]string)
+			send := func(label string, priority string) {
+				input := &awssqs.SendMessageInput{QueueUrl: aws.String(queueURL), MessageBody: aws.String(label)}
+				if priority != "" {
+					input.MessageAttributes = map[string]sqstypes.MessageAttributeValue{
+						"Priority": {DataType: aws.String("Number"), StringValue: aws.String(priority)},
+					}
+				}
+				output, err := client.SendMessage(context.Background(), input)
+				if err != nil {
+					t.Fatalf("failed to send: %v", err)
+				}
+				bodies[aws.ToString(output.MessageId)] = label
+			}
+			send("p5", "5")
+			send("unprioritized", "")
+			send("p10", "10")
+			send("p1", "1")
+
+			handler := &SQSHandler{Client: client, isDemo: true}
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?limit=10&sortAttr=Priority"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var messages []types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			var got []string
+			for _, message := range messages {
+				got = append(got, message.Body)
+			}
+			if prioritized := strings.Join(got[:3], ","); prioritized != tt.expected {
+				t.Errorf("expected %s first, got %s", tt.expected, prioritized)
+			}
+			if !slices.Contains(got[3:], "unprioritized") {
+				t.Errorf("expected the unprioritized message after the prioritized ones, got %v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// policyStatementSummary is one statement of a queue access policy.
+type policyStatementSummary struct {
+	Sid        string   `json:"sid,omitempty"`
+	Effect     string   `json:"effect"`
+	Principals []string `json:"principals"`
+	Actions    []string `json:"actions"`
+	// Conditional is set when the statement only applies under conditions
+	// (e.g. aws:SourceArn), which the summary does not evaluate
+	Conditional bool `json:"conditional,omitempty"`
+}
+
+// policySummary is a readable view of a queue's Policy attribute: who may
+// do what. Principals and Actions are the distinct values granted by Allow
+// statements, sorted.
+type policySummary struct {
+	Statements []policyStatementSummary `json:"statements"`
+	Principals []string                 `json:"principals"`
+	Actions    []string                 `json:"actions"`
+}
+
+// policyDocument is the subset of an IAM policy document the summary reads.
+// Statement, Principal and Action each take several JSON shapes, so they are
+// decoded by hand.
+type policyDocument struct {
+	Statement json.RawMessage `json:"Statement"`
+}
+
+type policyStatement struct {
+	Sid       string          `json:"Sid"`
+	Effect    string          `json:"Effect"`
+	Principal json.RawMessage `json:"Principal"`
+	Action    json.RawMessage `json:"Action"`
+	Condition json.RawMessage `json:"Condition"`
+}
+
+// stringOrList decodes a JSON string or array of strings.
+func stringOrList(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// policyPrincipals flattens a Principal element: "*" stays "*", and a map
+// such as {"AWS": [...], "Service": "sns.amazonaws.com"} yields its values.
+func policyPrincipals(raw json.RawMessage) ([]string, error) {
+	if principals, err := stringOrList(raw); err == nil {
+		return principals, nil
+	}
+
+	var byType map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &byType); err != nil {
+		return nil, fmt.Errorf("invalid Principal: %w", err)
+	}
+	var principals []string
+	for principalType, values := range byType {
+		list, err := stringOrList(values)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s principal: %w", principalType, err)
+		}
+		principals = append(principals, list...)
+	}
+	sort.Strings(principals)
+	return principals, nil
+}
+
+// summarizePolicy parses a queue Policy attribute. An empty policy yields an
+// empty summary; a malformed one yields an empty summary and the error.
+func summarizePolicy(policy string) (policySummary, error) {
+	summary := policySummary{
+		Statements: []policyStatementSummary{},
+		Principals: []string{},
+		Actions:    []string{},
+	}
+	if policy == "" {
+		return summary, nil
+	}
+
+	var document policyDocument
+	if err := json.Unmarshal([]byte(policy), &document); err != nil {
+		return summary, fmt.Errorf("invalid policy JSON: %w", err)
+	}
+
+	// Statement is either a single object or an array of them
+	var statements []policyStatement
+	if err := json.Unmarshal(document.Statement, &statements); err != nil {
+		var single policyStatement
+		if err := json.Unmarshal(document.Statement, &single); err != nil {
+			return summary, fmt.Errorf("invalid policy Statement: %w", err)
+		}
+		statements = []policyStatement{single}
+	}
+
+	principals := make(map[string]bool)
+	actions := make(map[string]bool)
+	parsed := make([]policyStatementSummary, 0, len(statements))
+	for _, statement := range statements {
+		statementPrincipals, err := policyPrincipals(statement.Principal)
+		if err != nil {
+			return summary, err
+		}
+		statementActions, err := stringOrList(statement.Action)
+		if err != nil {
+			return summary, fmt.Errorf("invalid Action: %w", err)
+		}
+
+		parsed = append(parsed, policyStatementSummary{
+			Sid:         statement.Sid,
+			Effect:      statement.Effect,
+			Principals:  append([]string{}, statementPrincipals...),
+			Actions:     append([]string{}, statementActions...),
+			Conditional: len(statement.Condition) > 0 && string(statement.Condition) != "null",
+		})
+
+		if statement.Effect != "Allow" {
+			continue
+		}
+		for _, principal := range statementPrincipals {
+			principals[principal] = true
+		}
+		for _, action := range statementActions {
+			actions[action] = true
+		}
+	}
+
+	summary.Statements = parsed
+	for principal := range principals {
+		summary.Principals = append(summary.Principals, principal)
+	}
+	for action := range actions {
+		summary.Actions = append(summary.Actions, action)
+	}
+	sort.Strings(summary.Principals)
+	sort.Strings(summary.Actions)
+	return summary, nil
+}
+
+// maskPolicySummary masks account IDs in the summary's principals.
+func maskPolicySummary(summary policySummary) policySummary {
+	maskAll := func(values []string) []string {
+		masked := make([]string, len(values))
+		for i, value := range values {
+			masked[i] = maskAccountIDs(value)
+		}
+		return masked
+	}
+
+	statements := make([]policyStatementSummary, len(summary.Statements))
+	for i, statement := range summary.Statements {
+		statement.Principals = maskAll(statement.Principals)
+		statements[i] = statement
+	}
+	summary.Statements = statements
+	summary.Principals = maskAll(summary.Principals)
+	return summary
+}
@@ -0,0 +1,125 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/gorilla/mux"
+)
+
+func TestSummarizePolicy(t *testing.T) {
+	tests := []struct {
+		name               string
+		policy             string
+		expectedPrincipals []string
+		expectedActions    []string
+		expectedStatements int
+		expectErr          bool
+	}{
+		{name: "no policy", expectedPrincipals: []string{}, expectedActions: []string{}},
+		{
+			name:               "single statement object with wildcard principal",
+			policy:             `{"Statement":{"Effect":"Allow","Principal":"*","Action":"sqs:SendMessage"}}`,
+			expectedPrincipals: []string{"*"},
+			expectedActions:    []string{"sqs:SendMessage"},
+			expectedStatements: 1,
+		},
+		{
+			name: "principal lists are merged and deny statements are not granted",
+			policy: `{"Statement":[
+				{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::111111111111:root","arn:aws:iam::222222222222:root"]},"Action":["sqs:ReceiveMessage","sqs:SendMessage"]},
+				{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:root"},"Action":"sqs:SendMessage"},
+				{"Effect":"Deny","Principal":"*","Action":"sqs:DeleteMessage"}
+			]}`,
+			expectedPrincipals: []string{"arn:aws:iam::111111111111:root", "arn:aws:iam::222222222222:root"},
+			expectedActions:    []string{"sqs:ReceiveMessage", "sqs:SendMessage"},
+			expectedStatements: 3,
+		},
+		{name: "not JSON", policy: `{"Statement":`, expectedPrincipals: []string{}, expectedActions: []string{}, expectErr: true},
+		{name: "invalid principal", policy: `{"Statement":[{"Effect":"Allow","Principal":42,"Action":"sqs:*"}]}`, expectedPrincipals: []string{}, expectedActions: []string{}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary, err := summarizePolicy(tt.policy)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("expected error=%v, got %v", tt.expectErr, err)
+			}
+			if fmt.Sprint(summary.Principals) != fmt.Sprint(tt.expectedPrincipals) {
+				t.Errorf("expected principals %v, got %v", tt.expectedPrincipals, summary.Principals)
+			}
+			if fmt.Sprint(summary.Actions) != fmt.Sprint(tt.expectedActions) {
+				t.Errorf("expected actions %v, got %v", tt.expectedActions, summary.Actions)
+			}
+			if len(summary.Statements) != tt.expectedStatements {
+				t.Errorf("expected %d statements, got %d", tt.expectedStatements, len(summary.Statements))
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetQueueStatistics_Policy(t *testing.T) {
+	tests := []struct {
+		name               string
+		queueURL           string
+		expectedPrincipals []string
+		expectedActions    []string
+		expectConditional  bool
+	}{
+		{
+			name:               "demo queue with a policy",
+			queueURL:           "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue",
+			expectedPrincipals: []string{"arn:aws:iam::123456789012:role/demo-order-worker", "sns.amazonaws.com"},
+			expectedActions:    []string{"sqs:ChangeMessageVisibility", "sqs:DeleteMessage", "sqs:ReceiveMessage", "sqs:SendMessage"},
+			expectConditional:  true,
+		},
+		{
+			name:               "demo queue without a policy",
+			queueURL:           "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue",
+			expectedPrincipals: []string{},
+			expectedActions:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+			req := httptest.NewRequest("GET", "/api/queues/x/statistics", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": tt.queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetQueueStatistics(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			var resp struct {
+				Policy      policySummary `json:"policy"`
+				PolicyError string        `json:"policyError"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.PolicyError != "" {
+				t.Errorf("unexpected policyError %q", resp.PolicyError)
+			}
+			if fmt.Sprint(resp.Policy.Principals) != fmt.Sprint(tt.expectedPrincipals) {
+				t.Errorf("expected principals %v, got %v", tt.expectedPrincipals, resp.Policy.Principals)
+			}
+			if fmt.Sprint(resp.Policy.Actions) != fmt.Sprint(tt.expectedActions) {
+				t.Errorf("expected actions %v, got %v", tt.expectedActions, resp.Policy.Actions)
+			}
+			conditional := false
+			for _, statement := range resp.Policy.Statements {
+				conditional = conditional || statement.Conditional
+			}
+			if conditional != tt.expectConditional {
+				t.Errorf("expected conditional=%v, got %v", tt.expectConditional, conditional)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package sqs
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// defaultAttributeCacheTTL is how long prefetched queue attributes are served
+// before ListQueues fetches them again.
+const defaultAttributeCacheTTL = 30 * time.Second
+
+// prefetchMaxQueues bounds how many queues the warm-up lists.
+const prefetchMaxQueues = 1000
+
+// PrefetchEnabled reports whether PREFETCH_QUEUES=true asks for the queue
+// attribute cache to be warmed at startup.
+func PrefetchEnabled() bool {
+	return os.Getenv("PREFETCH_QUEUES") == "true"
+}
+
+// attributeCacheTTLFromEnv returns ATTRIBUTE_CACHE_TTL_SECONDS, falling back
+// to defaultAttributeCacheTTL when unset or invalid.
+func attributeCacheTTLFromEnv() time.Duration {
+	if value := os.Getenv("ATTRIBUTE_CACHE_TTL_SECONDS"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		log.Printf("Invalid ATTRIBUTE_CACHE_TTL_SECONDS %q, using %s", value, defaultAttributeCacheTTL)
+	}
+	return defaultAttributeCacheTTL
+}
+
+// queueAttributeCache holds GetQueueAttributes results keyed by queue URL for
+// a fixed TTL. Counts in cached attributes are up to one TTL stale.
+type queueAttributeCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]attributeCacheEntry
+}
+
+type attributeCacheEntry struct {
+	attributes map[string]string
+	expires    time.Time
+}
+
+func newQueueAttributeCache(ttl time.Duration) *queueAttributeCache {
+	return &queueAttributeCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]attributeCacheEntry),
+	}
+}
+
+// get returns the cached attributes for queueURL if they have not expired.
+func (c *queueAttributeCache) get(queueURL string) (map[string]string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[queueURL]
+	if !ok || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.attributes, true
+}
+
+func (c *queueAttributeCache) set(queueURL string, attributes map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[queueURL] = attributeCacheEntry{attributes: attributes, expires: c.now().Add(c.ttl)}
+}
+
+func (c *queueAttributeCache) evict(queueURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, queueURL)
+}
+
+func (c *queueAttributeCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// EnableAttributeCache makes ListQueues serve queue attributes from a cache
+// whose TTL comes from ATTRIBUTE_CACHE_TTL_SECONDS (default 30s).
+func (h *SQSHandler) EnableAttributeCache() {
+	h.attributeCache = newQueueAttributeCache(attributeCacheTTLFromEnv())
+}
+
+// queueAttributes returns all attributes for queueURL, reading through the
+// attribute cache when it is enabled.
+func (h *SQSHandler) queueAttributes(ctx context.Context, queueURL string) (map[string]string, error) {
+	if h.attributeCache != nil {
+		if attributes, ok := h.attributeCache.get(queueURL); ok {
+			return attributes, nil
+		}
+	}
+
+	release, err := acquireAWSCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := h.Client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
+	})
+	release()
+	if err != nil {
+		return nil, err
+	}
+	noteRequestID(ctx, attrs.ResultMetadata)
+
+	if h.attributeCache != nil && attrs.Attributes != nil {
+		h.attributeCache.set(queueURL, attrs.Attributes)
+	}
+	return attrs.Attributes, nil
+}
+
+// WarmAttributeCache lists queues and fetches attributes for those passing
+// the env-configured tag filter, so the first UI ListQueues is served from
+// the cache. It enables the cache if needed and stops early when ctx is
+// cancelled (e.g. on shutdown).
+func (h *SQSHandler) WarmAttributeCache(ctx context.Context) error {
+	if h.attributeCache == nil {
+		h.EnableAttributeCache()
+	}
+	start := time.Now()
+
+	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{
+		MaxResults: aws.Int32(prefetchMaxQueues),
+	})
+	if err != nil {
+		log.Printf("Prefetch: Error listing queues: %v", err)
+		return err
+	}
+
+	disableTagFilter, requiredTags := tagFilterFromEnv()
+	warmed := 0
+
+	for _, queueURL := range result.QueueUrls {
+		if err := ctx.Err(); err != nil {
+			log.Printf("Prefetch: Cancelled after warming %d queues", warmed)
+			return err
+		}
+
+		if !disableTagFilter {
+			tags, err := h.listQueueTags(ctx, queueURL)
+			if err != nil || !matchesRequiredTags(queueURL, tags, requiredTags) {
+				continue
+			}
+		}
+
+		if _, err := h.queueAttributes(ctx, queueURL); err != nil {
+			log.Printf("Prefetch: Error fetching attributes for queue %s: %v", queueURL, err)
+			continue
+		}
+		warmed++
+	}
+
+	log.Printf("Prefetch: Warmed attributes for %d of %d queues in %s", warmed, len(result.QueueUrls), time.Since(start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,91 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+)
+
+// attributeCountingClient counts GetQueueAttributes calls on the demo client.
+type attributeCountingClient struct {
+	*demo.DemoSQSClient
+	attributeCalls int
+}
+
+func (c *attributeCountingClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	c.attributeCalls++
+	return c.DemoSQSClient.GetQueueAttributes(ctx, params, optFns...)
+}
+
+func TestSQSHandler_WarmAttributeCache(t *testing.T) {
+	t.Setenv("DISABLE_TAG_FILTER", "")
+	t.Setenv("FILTER_ENV", "")
+
+	client := &attributeCountingClient{DemoSQSClient: demo.NewDemoSQSClient()}
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	if err := handler.WarmAttributeCache(context.Background()); err != nil {
+		t.Fatalf("WarmAttributeCache failed: %v", err)
+	}
+
+	// Only queues passing the default tag filter are warmed
+	base := "https://sqs.us-east-1.amazonaws.com/123456789012/"
+	for _, name := range []string{"demo-orders-queue", "demo-payments-queue", "demo-deadletter-queue", "demo-events.fifo"} {
+		if _, ok := handler.attributeCache.get(base + name); !ok {
+			t.Errorf("expected cached attributes for %s", name)
+		}
+	}
+	for _, name := range []string{"demo-notifications-queue", "demo-analytics-queue"} {
+		if _, ok := handler.attributeCache.get(base + name); ok {
+			t.Errorf("expected %s to be skipped by the tag filter", name)
+		}
+	}
+
+	warmCalls := client.attributeCalls
+	rr := httptest.NewRecorder()
+	handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if client.attributeCalls != warmCalls {
+		t.Errorf("expected ListQueues to be served from the cache, got %d fresh attribute calls", client.attributeCalls-warmCalls)
+	}
+}
+
+func TestSQSHandler_WarmAttributeCache_Cancelled(t *testing.T) {
+	client := &attributeCountingClient{DemoSQSClient: demo.NewDemoSQSClient()}
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := handler.WarmAttributeCache(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if client.attributeCalls != 0 {
+		t.Errorf("expected no attribute calls after cancellation, got %d", client.attributeCalls)
+	}
+}
+
+func TestQueueAttributeCache_Expiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newQueueAttributeCache(30 * time.Second)
+	cache.now = func() time.Time { return now }
+
+	cache.set("queue", map[string]string{"ApproximateNumberOfMessages": "3"})
+	if _, ok := cache.get("queue"); !ok {
+		t.Fatal("expected a fresh entry to be served")
+	}
+
+	now = now.Add(30 * time.Second)
+	if _, ok := cache.get("queue"); ok {
+		t.Error("expected the entry to expire after the TTL")
+	}
+}
@@ -0,0 +1,45 @@
+package sqs
+
+import (
+	"net/http"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// defaultMaxQueuesReturned caps ListQueues responses so large accounts don't
+// bloat the response and slow the UI.
+const defaultMaxQueuesReturned = 200
+
+// maxQueuesReturnedFromEnv reads MAX_QUEUES_RETURNED, falling back to the
+// default when unset or invalid.
+func maxQueuesReturnedFromEnv() int {
+	return envInt("MAX_QUEUES_RETURNED", 1, defaultMaxQueuesReturned)
+}
+
+// capQueues trims filtered queues to maxQueues, reporting whether any were
+// dropped.
+func capQueues(queues []internal_types.Queue, maxQueues int) ([]internal_types.Queue, bool) {
+	if len(queues) <= maxQueues {
+		return queues, false
+	}
+	return queues[:maxQueues], true
+}
+
+// writeQueueList answers with the queue list: a bare array by default, with
+// X-Queues-Truncated set when the cap was hit, or with ?envelope=true an
+// object carrying a "truncated" flag so the UI can prompt for a narrower
+// filter.
+func writeQueueList(w http.ResponseWriter, r *http.Request, queues []internal_types.Queue, truncated bool, maxQueues int) error {
+	if truncated {
+		w.Header().Set("X-Queues-Truncated", "true")
+	}
+	if r.URL.Query().Get("envelope") != "true" {
+		return streamJSONList(w, r, queues)
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"queues":    queues,
+		"truncated": truncated,
+		"maxQueues": maxQueues,
+	})
+	return nil
+}
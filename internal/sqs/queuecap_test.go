@@ -0,0 +1,77 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestSQSHandler_ListQueues_MaxQueuesReturned(t *testing.T) {
+	tests := []struct {
+		name              string
+		maxQueues         string
+		query             string
+		expectedCount     int
+		expectedTruncated bool
+	}{
+		{"truncated by the cap", "3", "?envelope=true", 3, true},
+		{"within the cap", "10", "?envelope=true", 5, false},
+		{"limit above the cap", "3", "?envelope=true&limit=50", 3, true},
+		{"default cap", "", "?envelope=true", 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DISABLE_TAG_FILTER", "true")
+			t.Setenv("MAX_QUEUES_RETURNED", tt.maxQueues)
+
+			mockClient := helpers.NewMockSQSClient()
+			for i := 1; i <= 5; i++ {
+				mockClient.AddQueue(fmt.Sprintf("https://sqs.us-east-1.amazonaws.com/123456789012/queue-%d", i))
+			}
+			handler := &SQSHandler{Client: mockClient}
+
+			rr := httptest.NewRecorder()
+			handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues"+tt.query, nil))
+
+			var response struct {
+				Queues    []internal_types.Queue `json:"queues"`
+				Truncated bool                   `json:"truncated"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(response.Queues) != tt.expectedCount {
+				t.Errorf("expected %d queues, got %d", tt.expectedCount, len(response.Queues))
+			}
+			if response.Truncated != tt.expectedTruncated {
+				t.Errorf("expected truncated %v, got %v", tt.expectedTruncated, response.Truncated)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_ListQueues_TruncatedHeader(t *testing.T) {
+	t.Setenv("DISABLE_TAG_FILTER", "true")
+	t.Setenv("MAX_QUEUES_RETURNED", "1")
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue("https://sqs.us-east-1.amazonaws.com/123456789012/queue-1")
+	mockClient.AddQueue("https://sqs.us-east-1.amazonaws.com/123456789012/queue-2")
+	handler := &SQSHandler{Client: mockClient}
+
+	rr := httptest.NewRecorder()
+	handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+	var queues []internal_types.Queue
+	if err := json.NewDecoder(rr.Body).Decode(&queues); err != nil {
+		t.Fatalf("expected a bare array without ?envelope=true: %v", err)
+	}
+	if len(queues) != 1 || rr.Header().Get("X-Queues-Truncated") != "true" {
+		t.Errorf("expected 1 queue with X-Queues-Truncated, got %d and %q", len(queues), rr.Header().Get("X-Queues-Truncated"))
+	}
+}
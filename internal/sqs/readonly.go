@@ -0,0 +1,14 @@
+package sqs
+
+import "os"
+
+// ReadOnlyMessage is the error returned for mutations refused in read-only
+// mode.
+const ReadOnlyMessage = "read-only mode"
+
+// ReadOnlyEnabled reports whether READ_ONLY=true, which disables every
+// endpoint and WebSocket frame that changes queues or messages so the UI is
+// safe for viewing production.
+func ReadOnlyEnabled() bool {
+	return os.Getenv("READ_ONLY") == "true"
+}
@@ -0,0 +1,106 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+)
+
+// defaultRecordSampleSize is how many messages are recorded per queue when
+// no sample size is given.
+const defaultRecordSampleSize = 20
+
+// RecordDataset snapshots the queues' attributes, tags and up to sampleSize
+// messages each into a demo dataset (the DEMO_DATA_FILE format). It only
+// reads: messages are sampled with a zero visibility timeout, so none are
+// consumed. No queue URLs records the tag-filtered queue list.
+func (h *SQSHandler) RecordDataset(ctx context.Context, queueURLs []string, sampleSize int) (*demo.Dataset, error) {
+	if sampleSize <= 0 {
+		sampleSize = defaultRecordSampleSize
+	}
+	if len(queueURLs) == 0 {
+		queues, err := h.ListFilteredQueues(ctx, 1000)
+		if err != nil {
+			return nil, fmt.Errorf("list queues: %w", err)
+		}
+		for _, queue := range queues {
+			queueURLs = append(queueURLs, queue.URL)
+		}
+	}
+
+	ds := &demo.Dataset{Queues: []demo.DatasetQueue{}}
+	for _, queueURL := range queueURLs {
+		queueURL = normalizeQueueURL(queueURL)
+
+		attributes, err := h.queueAttributes(ctx, queueURL)
+		if err != nil {
+			return nil, fmt.Errorf("attributes of %s: %w", queueURL, err)
+		}
+		tags, err := h.listQueueTags(ctx, queueURL)
+		if err != nil {
+			return nil, fmt.Errorf("tags of %s: %w", queueURL, err)
+		}
+		sampled, err := h.sampleMessages(ctx, queueURL, sampleSize)
+		if err != nil {
+			return nil, fmt.Errorf("messages of %s: %w", queueURL, err)
+		}
+
+		queue := demo.DatasetQueue{
+			URL:        queueURL,
+			Attributes: attributes,
+			Tags:       tags,
+			Messages:   make([]demo.DatasetMessage, 0, len(sampled)),
+		}
+		for _, msg := range sampled {
+			message := demo.DatasetMessage{
+				MessageID:  aws.ToString(msg.MessageId),
+				Body:       aws.ToString(msg.Body),
+				Attributes: msg.Attributes,
+			}
+			if len(msg.MessageAttributes) > 0 {
+				message.MessageAttributes = make(map[string]demo.DatasetMessageAttribute, len(msg.MessageAttributes))
+				for name, value := range msg.MessageAttributes {
+					message.MessageAttributes[name] = demo.DatasetMessageAttribute{
+						DataType:    aws.ToString(value.DataType),
+						StringValue: aws.ToString(value.StringValue),
+						BinaryValue: value.BinaryValue,
+					}
+				}
+			}
+			queue.Messages = append(queue.Messages, message)
+		}
+		log.Printf("Record: Recorded %s with %d messages", queueURL, len(queue.Messages))
+		ds.Queues = append(ds.Queues, queue)
+	}
+	return ds, nil
+}
+
+// IsDemo reports whether the handler serves demo data rather than a live
+// SQS endpoint.
+func (h *SQSHandler) IsDemo() bool {
+	return h.isDemo
+}
+
+// newDemoHandler returns a demo-mode handler serving the dataset in
+// DEMO_DATA_FILE when set, otherwise the built-in demo data.
+func newDemoHandler(cfg aws.Config) (*SQSHandler, error) {
+	client := demo.NewDemoSQSClient()
+	if path := os.Getenv("DEMO_DATA_FILE"); path != "" {
+		ds, err := demo.LoadDataset(path)
+		if err != nil {
+			return nil, fmt.Errorf("DEMO_DATA_FILE: %w", err)
+		}
+		log.Printf("Using demo dataset %s (%d queues)", path, len(ds.Queues))
+		client = demo.NewDemoSQSClientFromDataset(ds)
+	}
+	return &SQSHandler{
+		Client: client,
+		S3:     demo.NewDemoS3Client(),
+		config: cfg,
+		isDemo: true,
+	}, nil
+}
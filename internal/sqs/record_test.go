@@ -0,0 +1,96 @@
+package sqs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestSQSHandler_RecordDataset(t *testing.T) {
+	const ordersURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	const emptyURL = "https://sqs.us-east-1.amazonaws.com/123456789012/empty"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(ordersURL)
+	mockClient.AddQueue(emptyURL)
+	mockClient.AddMessage(ordersURL, "msg-1", `{"orderId":"1"}`)
+	mockClient.AddMessage(ordersURL, "msg-2", `{"orderId":"2"}`)
+	mockClient.AddMessage(ordersURL, "msg-3", `{"orderId":"3"}`)
+	handler := &SQSHandler{Client: mockClient}
+
+	ds, err := handler.RecordDataset(context.Background(), []string{ordersURL, emptyURL}, 2)
+	if err != nil {
+		t.Fatalf("RecordDataset failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "demo-data.json")
+	if err := demo.WriteDataset(path, ds); err != nil {
+		t.Fatalf("WriteDataset failed: %v", err)
+	}
+	loaded, err := demo.LoadDataset(path)
+	if err != nil {
+		t.Fatalf("recorded dataset does not load: %v", err)
+	}
+	if len(loaded.Queues) != 2 || len(loaded.Queues[0].Messages) != 2 || len(loaded.Queues[1].Messages) != 0 {
+		t.Fatalf("expected 2 queues with 2 and 0 messages, got %+v", loaded.Queues)
+	}
+	if loaded.Queues[0].Tags["product"] != "amt" {
+		t.Errorf("expected the queue tags recorded, got %v", loaded.Queues[0].Tags)
+	}
+
+	// The demo client replays the recorded shapes
+	client := demo.NewDemoSQSClientFromDataset(loaded)
+	ctx := context.Background()
+	queues, _ := client.ListQueues(ctx, &awssqs.ListQueuesInput{})
+	if strings.Join(queues.QueueUrls, ",") != ordersURL+","+emptyURL {
+		t.Errorf("expected the recorded queues, got %v", queues.QueueUrls)
+	}
+	attributes, _ := client.GetQueueAttributes(ctx, &awssqs.GetQueueAttributesInput{QueueUrl: aws.String(ordersURL)})
+	if attributes.Attributes["VisibilityTimeout"] != "30" || attributes.Attributes["ApproximateNumberOfMessages"] != "2" {
+		t.Errorf("expected recorded attributes with the replayed depth, got %v", attributes.Attributes)
+	}
+	received, _ := client.ReceiveMessage(ctx, &awssqs.ReceiveMessageInput{QueueUrl: aws.String(ordersURL), MaxNumberOfMessages: 10})
+	if len(received.Messages) != 2 || aws.ToString(received.Messages[0].Body) != `{"orderId":"1"}` {
+		t.Errorf("expected the recorded messages, got %+v", received.Messages)
+	}
+	if received.Messages[0].Attributes["SentTimestamp"] != "1640995200000" {
+		t.Errorf("expected the recorded message attributes, got %v", received.Messages[0].Attributes)
+	}
+}
+
+func TestNewSQSHandler_DemoDataFile(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/recorded"
+
+	path := filepath.Join(t.TempDir(), "demo-data.json")
+	ds := &demo.Dataset{Queues: []demo.DatasetQueue{{URL: queueURL, Messages: []demo.DatasetMessage{{MessageID: "m-1", Body: "hello"}}}}}
+	if err := demo.WriteDataset(path, ds); err != nil {
+		t.Fatalf("WriteDataset failed: %v", err)
+	}
+
+	t.Setenv("MULTI_TENANT", "")
+	t.Setenv("FORCE_LIVE_MODE", "")
+	t.Setenv("FORCE_DEMO_MODE", "true")
+	t.Setenv("DEMO_DATA_FILE", path)
+	handler, err := NewSQSHandler()
+	if err != nil {
+		t.Fatalf("NewSQSHandler failed: %v", err)
+	}
+	queues, _ := handler.Client.ListQueues(context.Background(), &awssqs.ListQueuesInput{})
+	if len(queues.QueueUrls) != 1 || queues.QueueUrls[0] != queueURL {
+		t.Errorf("expected the dataset's queue, got %v", queues.QueueUrls)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"queues":[]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSQSHandler(); err == nil || !strings.Contains(err.Error(), "DEMO_DATA_FILE") {
+		t.Errorf("expected an invalid dataset to fail, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package sqs
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+)
+
+// RefreshReceiptHandles handles HTTP requests to fetch fresh receipt handles for
+// a list of message IDs. Messages are re-received without being consumed; IDs
+// that are not found map to null.
+func (h *SQSHandler) RefreshReceiptHandles(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var payload struct {
+		MessageIDs []string `json:"messageIds"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	found, err := h.lookupMessages(r.Context(), queueURL, payload.MessageIDs)
+	if err != nil {
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+
+	handles := make(map[string]*string, len(payload.MessageIDs))
+	for _, id := range payload.MessageIDs {
+		handles[id] = nil
+		if msg, ok := found[id]; ok {
+			handles[id] = msg.ReceiptHandle
+		}
+	}
+
+	log.Printf("RefreshReceiptHandles: Refreshed %d of %d handles for queue %s", len(found), len(handles), queueURL)
+
+	writeJSON(w, r, map[string]interface{}{
+		"receiptHandles": handles,
+	})
+}
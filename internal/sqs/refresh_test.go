@@ -0,0 +1,74 @@
+package sqs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func refreshHandlesReq(queueURL, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages/refresh-handles", strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+}
+
+func TestSQSHandler_RefreshReceiptHandles(t *testing.T) {
+	const ordersQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+	rr := httptest.NewRecorder()
+	handler.RefreshReceiptHandles(rr, refreshHandlesReq(ordersQueue, `{"messageIds": ["ord-001", "gone-999"]}`))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		ReceiptHandles map[string]*string `json:"receiptHandles"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if handle := response.ReceiptHandles["ord-001"]; handle == nil || *handle == "" {
+		t.Errorf("Expected a receipt handle for ord-001, got %v", handle)
+	}
+
+	handle, present := response.ReceiptHandles["gone-999"]
+	if !present {
+		t.Error("Expected gone-999 to be present in the response")
+	} else if handle != nil {
+		t.Errorf("Expected a null handle for gone-999, got %q", *handle)
+	}
+}
+
+func TestSQSHandler_RefreshReceiptHandles_Errors(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	t.Run("invalid body", func(t *testing.T) {
+		handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+		rr := httptest.NewRecorder()
+		handler.RefreshReceiptHandles(rr, refreshHandlesReq(queueURL, "not json"))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+	})
+
+	t.Run("receive error", func(t *testing.T) {
+		mockClient := helpers.NewMockSQSClient()
+		mockClient.SetError("ReceiveMessage", errors.New("access denied"))
+		handler := &SQSHandler{Client: mockClient}
+		rr := httptest.NewRecorder()
+		handler.RefreshReceiptHandles(rr, refreshHandlesReq(queueURL, `{"messageIds": ["msg1"]}`))
+		if rr.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", rr.Code)
+		}
+	})
+}
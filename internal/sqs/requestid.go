@@ -0,0 +1,111 @@
+package sqs
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// requestIDHeader carries the AWS request ID of the SQS call behind a
+// response, for quoting in AWS support cases.
+const requestIDHeader = "X-Amz-Request-Id"
+
+// requestIDKey keys the request's requestIDRecorder in its context.
+type requestIDKey struct{}
+
+// requestIDRecorder keeps the request ID of the first SQS call a request
+// made. Handlers may call SQS concurrently, hence the lock.
+type requestIDRecorder struct {
+	mu sync.Mutex
+	id string
+}
+
+// noteRequestID records the request ID from an SDK output's ResultMetadata
+// for RequestIDMiddleware to send. Only the request's first SQS call counts;
+// without the middleware this does nothing.
+func noteRequestID(ctx context.Context, metadata middleware.Metadata) {
+	recorder, ok := ctx.Value(requestIDKey{}).(*requestIDRecorder)
+	if !ok {
+		return
+	}
+	if id, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok && id != "" {
+		recorder.mu.Lock()
+		if recorder.id == "" {
+			recorder.id = id
+		}
+		recorder.mu.Unlock()
+	}
+}
+
+// RequestIDMiddleware sets X-Amz-Request-Id on responses from the request ID
+// of the first SQS call the handler made (see noteRequestID), unless the
+// handler set the header itself, as writeSQSError does for failed calls.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		recorder := &requestIDRecorder{}
+		ctx := context.WithValue(r.Context(), requestIDKey{}, recorder)
+		next.ServeHTTP(&requestIDWriter{ResponseWriter: w, recorder: recorder}, r.WithContext(ctx))
+	})
+}
+
+// requestIDWriter adds the recorded request ID header before the response
+// headers are written.
+type requestIDWriter struct {
+	http.ResponseWriter
+	recorder    *requestIDRecorder
+	wroteHeader bool
+}
+
+func (w *requestIDWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.recorder.mu.Lock()
+		id := w.recorder.id
+		w.recorder.mu.Unlock()
+		if id != "" && w.Header().Get(requestIDHeader) == "" {
+			w.Header().Set(requestIDHeader, id)
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *requestIDWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush forwards to the wrapped writer so streamed responses still flush.
+func (w *requestIDWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// setRequestID sets the request ID header from an SDK output's
+// ResultMetadata, when the call recorded one. Handlers use it for the call a
+// response is about, which takes precedence over the first one noted.
+func setRequestID(w http.ResponseWriter, metadata middleware.Metadata) {
+	if id, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok && id != "" {
+		w.Header().Set(requestIDHeader, id)
+	}
+}
+
+// setErrorRequestID sets the request ID header from a failed call's error,
+// returning the ID ("" when the error carries none, e.g. a network failure).
+func setErrorRequestID(w http.ResponseWriter, err error) string {
+	var withID interface{ ServiceRequestID() string }
+	if !errors.As(err, &withID) || withID.ServiceRequestID() == "" {
+		return ""
+	}
+	w.Header().Set(requestIDHeader, withID.ServiceRequestID())
+	return withID.ServiceRequestID()
+}
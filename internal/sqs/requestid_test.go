@@ -0,0 +1,176 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+// requestIDClient stamps ListQueues output with a request ID, as the SDK's
+// response middleware does.
+type requestIDClient struct {
+	*helpers.MockSQSClient
+	requestID string
+}
+
+func (c *requestIDClient) ListQueues(ctx context.Context, params *awssqs.ListQueuesInput, optFns ...func(*awssqs.Options)) (*awssqs.ListQueuesOutput, error) {
+	output, err := c.MockSQSClient.ListQueues(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	awsmiddleware.SetRequestIDMetadata(&output.ResultMetadata, c.requestID)
+	return output, nil
+}
+
+func (c *requestIDClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	output, err := c.MockSQSClient.GetQueueAttributes(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	awsmiddleware.SetRequestIDMetadata(&output.ResultMetadata, c.requestID)
+	return output, nil
+}
+
+func (c *requestIDClient) ReceiveMessage(ctx context.Context, params *awssqs.ReceiveMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error) {
+	output, err := c.MockSQSClient.ReceiveMessage(ctx, params, optFns...)
+	if err != nil {
+		return nil, err
+	}
+	awsmiddleware.SetRequestIDMetadata(&output.ResultMetadata, c.requestID)
+	return output, nil
+}
+
+func TestSQSHandler_ListQueues_RequestIDHeader(t *testing.T) {
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue("https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue")
+	handler := &SQSHandler{Client: &requestIDClient{MockSQSClient: mock, requestID: "7a62c49f-347e-4fc4-9331-6e8e7a96aa73"}}
+
+	rr := httptest.NewRecorder()
+	handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get(requestIDHeader); got != "7a62c49f-347e-4fc4-9331-6e8e7a96aa73" {
+		t.Errorf("expected the request ID header, got %q", got)
+	}
+
+	// Without metadata there is no header
+	rr = httptest.NewRecorder()
+	(&SQSHandler{Client: mock}).ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+	if got := rr.Header().Get(requestIDHeader); got != "" {
+		t.Errorf("expected no request ID header, got %q", got)
+	}
+}
+
+func TestWriteSQSError_RequestID(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	// The SDK wraps service errors in a ResponseError carrying the request ID
+	err := &smithy.OperationError{
+		ServiceID:     "SQS",
+		OperationName: "SendMessage",
+		Err: &awshttp.ResponseError{
+			ResponseError: &smithyhttp.ResponseError{Err: &smithy.GenericAPIError{Code: "RequestThrottled", Message: "slow down"}},
+			RequestID:     "req-1234",
+		},
+	}
+
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+	rr := httptest.NewRecorder()
+	writeSQSError(rr, req, err, queueURL)
+
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", rr.Code)
+	}
+	if got := rr.Header().Get(requestIDHeader); got != "req-1234" {
+		t.Errorf("expected the request ID header, got %q", got)
+	}
+	var response sqsErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.RequestID != "req-1234" {
+		t.Errorf("expected requestId in the error envelope, got %+v", response)
+	}
+
+	rr = httptest.NewRecorder()
+	writeSQSError(rr, req, errors.New("connection refused"), queueURL)
+	if got := rr.Header().Get(requestIDHeader); got != "" {
+		t.Errorf("expected no request ID header for a network error, got %q", got)
+	}
+}
+
+func TestRequestIDMiddleware_SDKBackedResponses(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	mock := helpers.NewMockSQSClient()
+	mock.AddQueue(queueURL)
+	mock.AddMessage(queueURL, "msg-1", "hello")
+	handler := &SQSHandler{Client: &requestIDClient{MockSQSClient: mock, requestID: "req-5678"}}
+
+	tests := []struct {
+		name    string
+		path    string
+		handler http.HandlerFunc
+	}{
+		{name: "messages", path: "/api/queues/{queueUrl}/messages", handler: handler.GetMessages},
+		{name: "statistics", path: "/api/queues/{queueUrl}/statistics", handler: handler.GetQueueStatistics},
+		{name: "snapshot", path: "/api/queues/{queueUrl}/snapshot", handler: handler.CreateSnapshot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			RequestIDMiddleware(tt.handler).ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if got := rr.Header().Get(requestIDHeader); got != "req-5678" {
+				t.Errorf("expected the request ID header, got %q", got)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_DeleteMessage_ErrorEnvelope(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	mock := helpers.NewMockSQSClient()
+	mock.SetError("DeleteMessage", &awshttp.ResponseError{
+		ResponseError: &smithyhttp.ResponseError{Err: &smithy.GenericAPIError{Code: "InternalError", Message: "try again"}},
+		RequestID:     "req-9012",
+	})
+	handler := &SQSHandler{Client: mock}
+
+	req := httptest.NewRequest("DELETE", "/api/queues/{queueUrl}/messages/{receiptHandle}", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL, "receiptHandle": "receipt-1"})
+	rr := httptest.NewRecorder()
+	handler.DeleteMessage(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected status 500, got %d", rr.Code)
+	}
+	var response sqsErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("expected a JSON error envelope, got %q", rr.Body.String())
+	}
+	if response.Error != "InternalError" || response.RequestID != "req-9012" {
+		t.Errorf("unexpected error envelope %+v", response)
+	}
+}
@@ -0,0 +1,49 @@
+package sqs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// defaultMaxRequestBodyBytes leaves room above the SQS message limit for the
+// JSON envelope, so an oversized message still reaches the size check and
+// gets its detailed 413 rather than being cut off while decoding.
+const defaultMaxRequestBodyBytes = maxMessageBytes + 64*1024
+
+// maxRequestBodyBytesFromEnv returns the largest request body the send
+// endpoints read (MAX_REQUEST_BODY_BYTES).
+func maxRequestBodyBytesFromEnv() int64 {
+	value := os.Getenv("MAX_REQUEST_BODY_BYTES")
+	if value == "" {
+		return defaultMaxRequestBodyBytes
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid MAX_REQUEST_BODY_BYTES %q, using %d", value, defaultMaxRequestBodyBytes)
+		return defaultMaxRequestBodyBytes
+	}
+	return n
+}
+
+// decodeLimitedJSON decodes the request body into v, reading at most
+// MAX_REQUEST_BODY_BYTES so a client cannot stream an unbounded body into
+// memory. It answers 413 when the limit is hit and 400 for other decode
+// errors, reporting whether decoding succeeded.
+func decodeLimitedJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	limit := maxRequestBodyBytesFromEnv()
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit)).Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("request body exceeds %d bytes", limit), http.StatusRequestEntityTooLarge)
+			return false
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package sqs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+// endlessBody streams a JSON body that never ends and counts the bytes read
+// from it.
+type endlessBody struct {
+	read    int64
+	started bool
+}
+
+func (b *endlessBody) Read(p []byte) (int, error) {
+	if !b.started {
+		b.started = true
+		n := copy(p, `{"body": "`)
+		b.read += int64(n)
+		return n, nil
+	}
+	for i := range p {
+		p[i] = 'x'
+	}
+	b.read += int64(len(p))
+	return len(p), nil
+}
+
+func TestSQSHandler_SendEndpoints_RequestBodyLimit(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	const limit = 64 * 1024
+	t.Setenv("MAX_REQUEST_BODY_BYTES", "65536")
+
+	mockClient := helpers.NewMockSQSClient()
+	handler := &SQSHandler{Client: mockClient}
+
+	endpoints := map[string]http.HandlerFunc{
+		"SendMessage":          handler.SendMessage,
+		"SendTemplateMessages": handler.SendTemplateMessages,
+		"RetryMessage":         handler.RetryMessage,
+		"ValidateMessage":      handler.ValidateMessage,
+	}
+
+	for name, endpoint := range endpoints {
+		t.Run(name, func(t *testing.T) {
+			body := &endlessBody{}
+			req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", io.NopCloser(body))
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+
+			endpoint(rr, req)
+
+			if rr.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("expected status 413, got %d: %s", rr.Code, rr.Body.String())
+			}
+			// The decoder reads in chunks, so allow one buffer past the limit
+			if body.read > 2*limit {
+				t.Errorf("expected reading to stop near the %d byte limit, read %d", limit, body.read)
+			}
+		})
+	}
+
+	if len(mockClient.SendMessageCalls) != 0 {
+		t.Errorf("expected nothing sent, got %d sends", len(mockClient.SendMessageCalls))
+	}
+}
+
+func TestSQSHandler_SendMessage_DefaultBodyLimitKeepsSizeError(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+
+	// Just over the SQS limit: the decoder accepts it, so the detailed
+	// message size 413 is returned rather than the request body one
+	body := `{"body": "` + strings.Repeat("x", maxMessageBytes+1) + `"}`
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+	rr := httptest.NewRecorder()
+
+	handler.SendMessage(rr, req)
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d", rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), `"size"`) {
+		t.Errorf("expected the message size breakdown, got %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,59 @@
+package sqs
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// prettyJSONIndent is the indent used for pretty-printed responses.
+const prettyJSONIndent = "  "
+
+// prettyJSONRequested reports whether the client asked for indented JSON,
+// with ?pretty=true or an Accept media type carrying a pretty=true parameter
+// (e.g. "application/json; pretty=true"). Responses are compact otherwise.
+func prettyJSONRequested(r *http.Request) bool {
+	if pretty := r.URL.Query().Get("pretty"); pretty == "true" || pretty == "1" {
+		return true
+	}
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		if _, params, err := mime.ParseMediaType(strings.TrimSpace(accepted)); err == nil && params["pretty"] == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// newJSONEncoder returns an encoder writing to out, indented when the request
+// asks for pretty output.
+func newJSONEncoder(out io.Writer, r *http.Request) *json.Encoder {
+	enc := json.NewEncoder(out)
+	if prettyJSONRequested(r) {
+		enc.SetIndent("", prettyJSONIndent)
+	}
+	return enc
+}
+
+// writeJSON writes v as a 200 JSON response.
+func writeJSON(w http.ResponseWriter, r *http.Request, v interface{}) {
+	writeJSONStatus(w, r, http.StatusOK, v)
+}
+
+// WriteJSONStatus is writeJSONStatus for handlers registered outside this
+// package, so their responses honor ?pretty= the same way.
+func WriteJSONStatus(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	writeJSONStatus(w, r, status, v)
+}
+
+// writeJSONStatus writes v as a JSON response with the given status. The
+// status is committed before encoding, so an encoding error is only logged.
+func writeJSONStatus(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := newJSONEncoder(w, r).Encode(v); err != nil {
+		log.Printf("Error encoding %s response: %v", RedactedPath(r), err)
+	}
+}
@@ -0,0 +1,84 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/gorilla/mux"
+)
+
+func TestWriteJSON_Pretty(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		accept   string
+		expected string
+	}{
+		{name: "compact by default", expected: "{\"queue\":\"orders\"}\n"},
+		{name: "pretty query", query: "?pretty=true", expected: "{\n  \"queue\": \"orders\"\n}\n"},
+		{name: "pretty accept parameter", accept: "text/html, application/json; pretty=true", expected: "{\n  \"queue\": \"orders\"\n}\n"},
+		{name: "pretty=false stays compact", query: "?pretty=false", expected: "{\"queue\":\"orders\"}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/config"+tt.query, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rr := httptest.NewRecorder()
+			writeJSON(rr, req, map[string]string{"queue": "orders"})
+
+			if got := rr.Body.String(); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected application/json, got %q", ct)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_PrettyResponses(t *testing.T) {
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+	queueVars := map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"}
+
+	tests := []struct {
+		name   string
+		handle http.HandlerFunc
+		path   string
+		vars   map[string]string
+	}{
+		{name: "statistics", handle: handler.GetQueueStatistics, path: "/api/queues/{queueUrl}/statistics", vars: queueVars},
+		{name: "streamed messages", handle: handler.GetMessages, path: "/api/queues/{queueUrl}/messages", vars: queueVars},
+		{name: "streamed queues", handle: handler.ListQueues, path: "/api/queues"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, pretty := range []bool{false, true} {
+				path := tt.path
+				if pretty {
+					path += "?pretty=true"
+				}
+				req := mux.SetURLVars(httptest.NewRequest("GET", path, nil), tt.vars)
+				rr := httptest.NewRecorder()
+				tt.handle(rr, req)
+
+				if rr.Code != http.StatusOK {
+					t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+				}
+				if !json.Valid(rr.Body.Bytes()) {
+					t.Fatalf("response is not valid JSON: %s", rr.Body.String())
+				}
+				if indented := strings.Contains(rr.Body.String(), "\n  "); indented != pretty {
+					t.Errorf("pretty=%v: expected indented=%v, got body %s", pretty, pretty, rr.Body.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package sqs
+
+import (
+	"net/http"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// Operation statuses reported in OperationResult.Status.
+const (
+	statusSent     = "sent"
+	statusDeleted  = "deleted"
+	statusRetried  = "retried"
+	statusImported = "imported"
+	statusArchived = "archived"
+	statusMoved    = "moved"
+	statusConsumed = "consumed"
+	statusResent   = "resent"
+	// statusQueueDeleted reports a whole queue deleted, not a message
+	statusQueueDeleted = "queueDeleted"
+	// statusDemoReset reports the demo data restored to its seeded state
+	statusDemoReset = "demoReset"
+)
+
+// affected returns a pointer for OperationResult.AffectedCount.
+func affected(count int) *int {
+	return &count
+}
+
+// writeOperationResult encodes result as the JSON response of a mutating
+// handler.
+func writeOperationResult(w http.ResponseWriter, r *http.Request, result internal_types.OperationResult) {
+	writeJSON(w, r, result)
+}
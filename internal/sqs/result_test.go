@@ -0,0 +1,123 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestMutatingHandlers_ReturnOperationResult(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+	const targetURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+
+	tests := []struct {
+		name           string
+		handler        func(*SQSHandler) http.HandlerFunc
+		method         string
+		path           string
+		body           string
+		vars           map[string]string
+		expectedStatus string
+		expectMessage  bool
+		expectedCount  int
+	}{
+		{
+			name:           "send",
+			handler:        func(h *SQSHandler) http.HandlerFunc { return h.SendMessage },
+			method:         "POST",
+			path:           "/api/queues/{queueUrl}/messages",
+			body:           `{"body":"hello"}`,
+			vars:           map[string]string{"queueUrl": queueURL},
+			expectedStatus: "sent",
+			expectMessage:  true,
+		},
+		{
+			name:           "retry",
+			handler:        func(h *SQSHandler) http.HandlerFunc { return h.RetryMessage },
+			method:         "POST",
+			path:           "/api/queues/{queueUrl}/retry",
+			body:           `{"message":{"messageId":"msg-1","body":"hello","receiptHandle":"receipt-msg-1"},"targetQueueUrl":"` + targetURL + `"}`,
+			vars:           map[string]string{"queueUrl": queueURL},
+			expectedStatus: "retried",
+			expectMessage:  true,
+		},
+		{
+			name:           "delete with result",
+			handler:        func(h *SQSHandler) http.HandlerFunc { return h.DeleteMessage },
+			method:         "DELETE",
+			path:           "/api/queues/{queueUrl}/messages/{receiptHandle}?result=true",
+			vars:           map[string]string{"queueUrl": queueURL, "receiptHandle": "receipt-msg-1"},
+			expectedStatus: "deleted",
+			expectedCount:  1,
+		},
+		{
+			name:           "template send",
+			handler:        func(h *SQSHandler) http.HandlerFunc { return h.SendTemplateMessages },
+			method:         "POST",
+			path:           "/api/queues/{queueUrl}/messages/template",
+			body:           `{"template":"m{{.Index}}","count":3}`,
+			vars:           map[string]string{"queueUrl": queueURL},
+			expectedStatus: "sent",
+			expectedCount:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			mockClient.AddMessage(queueURL, "msg-1", "hello")
+			handler := &SQSHandler{Client: mockClient}
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, tt.vars)
+			rr := httptest.NewRecorder()
+			tt.handler(handler)(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected application/json, got %q", ct)
+			}
+
+			var result types.OperationResult
+			if err := json.NewDecoder(rr.Body).Decode(&result); err != nil {
+				t.Fatalf("failed to decode result: %v", err)
+			}
+			if result.Status != tt.expectedStatus {
+				t.Errorf("expected status %q, got %q", tt.expectedStatus, result.Status)
+			}
+			if tt.expectMessage && result.MessageId == "" {
+				t.Error("expected a messageId")
+			}
+			if tt.expectedCount > 0 && (result.AffectedCount == nil || *result.AffectedCount != tt.expectedCount) {
+				t.Errorf("expected affectedCount %d, got %v", tt.expectedCount, result.AffectedCount)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_DeleteMessage_NoContentByDefault(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+	mockClient.AddMessage(queueURL, "msg-1", "hello")
+	handler := &SQSHandler{Client: mockClient}
+
+	req := httptest.NewRequest("DELETE", "/api/queues/{queueUrl}/messages/{receiptHandle}", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL, "receiptHandle": "receipt-msg-1"})
+	rr := httptest.NewRecorder()
+	handler.DeleteMessage(rr, req)
+
+	if rr.Code != http.StatusNoContent || rr.Body.Len() != 0 {
+		t.Errorf("expected an empty 204, got %d: %q", rr.Code, rr.Body.String())
+	}
+}
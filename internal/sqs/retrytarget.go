@@ -0,0 +1,60 @@
+package sqs
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"strings"
+)
+
+// retryTargetPatterns returns the comma-separated RETRY_TARGET_ALLOW glob
+// patterns, or nil when the variable is unset and any target is allowed.
+func retryTargetPatterns() []string {
+	var patterns []string
+	for _, pattern := range strings.Split(os.Getenv("RETRY_TARGET_ALLOW"), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// retryTargetAllowed reports whether a retry, move or edit-resend may send to
+// targetURL. Each
+// pattern is matched (path.Match syntax) against both the queue URL and the
+// queue name, so "orders-*" and
+// "https://sqs.us-east-1.amazonaws.com/123456789012/*" both work. Malformed
+// patterns never match.
+func retryTargetAllowed(targetURL string) bool {
+	patterns := retryTargetPatterns()
+	if patterns == nil {
+		return true
+	}
+
+	name := queueNameFromURL(targetURL)
+	for _, pattern := range patterns {
+		for _, candidate := range []string{targetURL, name} {
+			matched, err := path.Match(pattern, candidate)
+			if err != nil {
+				log.Printf("Invalid RETRY_TARGET_ALLOW pattern %q: %v", pattern, err)
+				break
+			}
+			if matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateRetryTargetAllow reports malformed RETRY_TARGET_ALLOW patterns, so
+// startup can reject them rather than silently refusing every retry.
+func ValidateRetryTargetAllow() error {
+	for _, pattern := range retryTargetPatterns() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("RETRY_TARGET_ALLOW pattern %q is invalid: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package sqs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestRetryTargetAllowed(t *testing.T) {
+	const target = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	tests := []struct {
+		name     string
+		allow    string
+		expected bool
+	}{
+		{name: "unset allows any target", allow: "", expected: true},
+		{name: "queue name pattern", allow: "orders-*", expected: true},
+		{name: "queue URL pattern", allow: "https://sqs.us-east-1.amazonaws.com/123456789012/*", expected: true},
+		{name: "second of several patterns", allow: "payments-*, orders-queue", expected: true},
+		{name: "no pattern matches", allow: "payments-*", expected: false},
+		{name: "other account", allow: "https://sqs.us-east-1.amazonaws.com/999999999999/*", expected: false},
+		{name: "malformed pattern never matches", allow: "[orders", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RETRY_TARGET_ALLOW", tt.allow)
+
+			if got := retryTargetAllowed(target); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateRetryTargetAllow(t *testing.T) {
+	t.Setenv("RETRY_TARGET_ALLOW", "orders-*")
+	if err := ValidateRetryTargetAllow(); err != nil {
+		t.Errorf("expected valid patterns, got %v", err)
+	}
+
+	t.Setenv("RETRY_TARGET_ALLOW", "orders-*,[bad")
+	if err := ValidateRetryTargetAllow(); err == nil {
+		t.Error("expected an error for a malformed pattern")
+	}
+}
+
+func TestSQSHandler_RetryMessage_SendsToCheckedTarget(t *testing.T) {
+	t.Setenv("RETRY_TARGET_ALLOW", "orders-queue")
+	const target = "https://sqs.us-east-1.amazonaws.com/123456789012/orders-queue"
+
+	mockClient := helpers.NewMockSQSClient()
+	handler := &SQSHandler{Client: mockClient}
+
+	// A collapsed scheme and stray whitespace, as a proxied path can produce
+	body := `{"message": {"messageId": "dlq-001", "body": "hello", "receiptHandle": "receipt-dlq-001"}, "targetQueueUrl": " https:/sqs.us-east-1.amazonaws.com/123456789012/orders-queue "}`
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/retry", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"})
+	rr := httptest.NewRecorder()
+	handler.RetryMessage(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(mockClient.SendMessageCalls) != 1 || mockClient.SendMessageCalls[0].QueueURL != target {
+		t.Errorf("expected one send to the checked target %s, got %+v", target, mockClient.SendMessageCalls)
+	}
+}
@@ -0,0 +1,31 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Client adapts the AWS S3 client to S3ClientInterface.
+type s3Client struct {
+	client *s3.Client
+}
+
+// newS3Client builds the S3 client used for archiving from the same AWS
+// configuration as the SQS client.
+func newS3Client(cfg aws.Config) S3ClientInterface {
+	return &s3Client{client: s3.NewFromConfig(cfg)}
+}
+
+// PutObject writes body to bucket/key as a JSON object.
+func (c *s3Client) PutObject(ctx context.Context, bucket, key string, body []byte) error {
+	_, err := c.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(body),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
@@ -0,0 +1,39 @@
+package sqs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+func TestS3Client_PutObject(t *testing.T) {
+	var method, path, contentType, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		method, path, contentType, body = r.Method, r.URL.Path, r.Header.Get("Content-Type"), string(data)
+	}))
+	defer server.Close()
+
+	cfg := aws.Config{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+		BaseEndpoint: aws.String(server.URL),
+	}
+	client := &s3Client{client: s3.NewFromConfig(cfg, func(o *s3.Options) { o.UsePathStyle = true })}
+
+	if err := client.PutObject(context.Background(), "archive-bucket", "orders/msg-1.json", []byte(`{"id":"msg-1"}`)); err != nil {
+		t.Fatalf("PutObject failed: %v", err)
+	}
+	if method != http.MethodPut || path != "/archive-bucket/orders/msg-1.json" {
+		t.Errorf("expected PUT /archive-bucket/orders/msg-1.json, got %s %s", method, path)
+	}
+	if contentType != "application/json" || body != `{"id":"msg-1"}` {
+		t.Errorf("unexpected object %q (%s)", body, contentType)
+	}
+}
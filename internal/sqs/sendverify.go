@@ -0,0 +1,148 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+const (
+	// defaultSendVerifyTimeout bounds how long SendAndVerifyMessage polls for
+	// the sent message.
+	defaultSendVerifyTimeout = 10 * time.Second
+	// sendVerifyPollInterval spaces the polls for the sent message.
+	sendVerifyPollInterval = 250 * time.Millisecond
+)
+
+// sendVerifyTimeoutFromEnv reads SEND_VERIFY_TIMEOUT_MS, falling back to the
+// default for missing or non-positive values.
+func sendVerifyTimeoutFromEnv() time.Duration {
+	if n, err := strconv.Atoi(os.Getenv("SEND_VERIFY_TIMEOUT_MS")); err == nil && n > 0 {
+		return time.Duration(n) * time.Millisecond
+	}
+	return defaultSendVerifyTimeout
+}
+
+// sendVerifyResult reports a round trip; LatencyMs is the time from the send
+// until the message was seen, or until polling gave up.
+type sendVerifyResult struct {
+	MessageID string `json:"messageId"`
+	Observed  bool   `json:"observed"`
+	LatencyMs int64  `json:"latencyMs"`
+	Polls     int    `json:"polls"`
+}
+
+// SendAndVerifyMessage handles HTTP requests to check a queue end to end: it
+// sends the message, then polls without consuming until a message with the
+// returned ID is visible, up to SEND_VERIFY_TIMEOUT_MS (?timeoutMs= may
+// shorten it) or until the client goes away. Not observing the message is
+// reported in the result rather than as an error. The message is left on the
+// queue and, like everything each poll receives, made visible again right away
+// (see ReleaseMessages).
+func (h *SQSHandler) SendAndVerifyMessage(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	timeout := sendVerifyTimeoutFromEnv()
+	if value := r.URL.Query().Get("timeoutMs"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			http.Error(w, "timeoutMs must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		timeout = min(timeout, time.Duration(n)*time.Millisecond)
+	}
+
+	var payload struct {
+		Body string `json:"body"`
+		// Attributes are plain strings or {"dataType", "value"} objects
+		Attributes             map[string]sendAttribute `json:"attributes"`
+		MessageGroupID         string                   `json:"messageGroupId"`
+		MessageDeduplicationID string                   `json:"messageDeduplicationId"`
+	}
+	if !decodeLimitedJSON(w, r, &payload) {
+		return
+	}
+
+	attributes, typeErr := typedMessageAttributes(withDefaultAttributes(payload.Attributes))
+	if typeErr != nil {
+		log.Printf("SendAndVerifyMessage: Refusing message for queue %s: %v", queueURL, typeErr)
+		writeAttributeTypeError(w, r, typeErr)
+		return
+	}
+	input := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(payload.Body),
+		MessageAttributes: attributes,
+	}
+	if size := sqsMessageSize(payload.Body, input.MessageAttributes); size.exceedsLimit() {
+		log.Printf("SendAndVerifyMessage: Refusing %d byte message for queue %s", size.TotalBytes, queueURL)
+		writeMessageTooLarge(w, r, size, nil)
+		return
+	}
+	if isFIFOQueue(queueURL) {
+		groupID := payload.MessageGroupID
+		if groupID == "" {
+			groupID = "send-and-verify"
+		}
+		input.MessageGroupId = aws.String(groupID)
+		if payload.MessageDeduplicationID != "" {
+			input.MessageDeduplicationId = aws.String(payload.MessageDeduplicationID)
+		}
+	}
+
+	if !h.checkFIFOSendParameters(w, r, queueURL, aws.ToString(input.MessageGroupId), aws.ToString(input.MessageDeduplicationId)) {
+		return
+	}
+
+	ctx := r.Context()
+	sent, err := h.Client.SendMessage(ctx, input)
+	if err != nil {
+		log.Printf("SendAndVerifyMessage: Error sending to queue %s: %v", queueURL, err)
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+	setRequestID(w, sent.ResultMetadata)
+
+	result := sendVerifyResult{MessageID: aws.ToString(sent.MessageId)}
+	start := h.clock()
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+poll:
+	for {
+		result.Polls++
+		found, err := h.lookupMessages(ctx, queueURL, []string{result.MessageID})
+		if err != nil && ctx.Err() == nil {
+			// The message was sent, so a failed poll ends verification
+			// rather than the request
+			log.Printf("SendAndVerifyMessage: Error polling queue %s: %v", queueURL, err)
+			break
+		}
+		if _, ok := found[result.MessageID]; ok {
+			result.Observed = true
+			break
+		}
+
+		select {
+		case <-time.After(sendVerifyPollInterval):
+		case <-deadline.C:
+			break poll
+		case <-ctx.Done():
+			break poll
+		}
+	}
+	result.LatencyMs = max(h.clock().Sub(start).Milliseconds(), 0)
+
+	if !result.Observed {
+		log.Printf("SendAndVerifyMessage: Message %s not observed in queue %s after %d polls", result.MessageID, queueURL, result.Polls)
+	}
+	writeJSON(w, r, result)
+}
@@ -0,0 +1,95 @@
+package sqs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+// invisibleSendClient accepts sends but never returns them from receives.
+type invisibleSendClient struct {
+	*demo.DemoSQSClient
+}
+
+func (c *invisibleSendClient) ReceiveMessage(ctx context.Context, params *awssqs.ReceiveMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error) {
+	return &awssqs.ReceiveMessageOutput{}, nil
+}
+
+func TestSQSHandler_SendAndVerifyMessage(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	sendAndVerify := func(handler *SQSHandler, query string) (*httptest.ResponseRecorder, sendVerifyResult) {
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/send-and-verify"+query, bytes.NewBufferString(`{"body":"round trip"}`))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+		rr := httptest.NewRecorder()
+		handler.SendAndVerifyMessage(rr, req)
+
+		var result sendVerifyResult
+		if rr.Code == http.StatusOK {
+			if err := json.Unmarshal(rr.Body.Bytes(), &result); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+		}
+		return rr, result
+	}
+
+	t.Run("observed", func(t *testing.T) {
+		client := demo.NewDemoSQSClient()
+		rr, result := sendAndVerify(&SQSHandler{Client: client, isDemo: true}, "")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		if !result.Observed || result.MessageID == "" {
+			t.Errorf("expected the sent message to be observed, got %+v", result)
+		}
+		if result.LatencyMs < 0 {
+			t.Errorf("expected a non-negative latency, got %d", result.LatencyMs)
+		}
+	})
+
+	t.Run("observed message stays visible", func(t *testing.T) {
+		// The mock answers every send with test-message-id
+		mock := helpers.NewMockSQSClient()
+		mock.AddQueue(queueURL)
+		mock.AddMessage(queueURL, "test-message-id", "round trip")
+		rr, result := sendAndVerify(&SQSHandler{Client: mock}, "")
+		if rr.Code != http.StatusOK || !result.Observed {
+			t.Fatalf("expected the sent message to be observed, got %d: %s", rr.Code, rr.Body.String())
+		}
+		released := false
+		for _, call := range mock.ChangeMessageVisibilityCalls {
+			if call.ReceiptHandle == "receipt-test-message-id" && call.VisibilityTimeout == 0 {
+				released = true
+			}
+		}
+		if !released {
+			t.Errorf("expected the polled message to be made visible again, got %+v", mock.ChangeMessageVisibilityCalls)
+		}
+	})
+
+	t.Run("not observed before the timeout", func(t *testing.T) {
+		handler := &SQSHandler{Client: &invisibleSendClient{demo.NewDemoSQSClient()}, isDemo: true}
+		rr, result := sendAndVerify(handler, "?timeoutMs=600")
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		if result.Observed || result.Polls < 2 || result.LatencyMs < 600 {
+			t.Errorf("expected an unobserved result after polling until the timeout, got %+v", result)
+		}
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		rr, _ := sendAndVerify(&SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}, "?timeoutMs=0")
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status 400, got %d", rr.Code)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package sqs
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// defaultShareLinkTTL is how long a share link works when
+// SHARE_LINK_TTL_SECONDS is not set.
+const defaultShareLinkTTL = 15 * time.Minute
+
+// errInvalidShareToken covers malformed, tampered and expired share tokens
+// alike, so callers learn nothing about why a token was refused.
+var errInvalidShareToken = errors.New("share link is invalid or has expired")
+
+// shareClaims is the signed content of a share token.
+type shareClaims struct {
+	QueueURL  string `json:"q"`
+	MessageID string `json:"m"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// shareSigner signs and verifies message share tokens with an HMAC-SHA256
+// key taken from SHARE_LINK_SECRET, or generated per process when that is
+// unset (links then stop working on restart). The zero value is ready to use.
+type shareSigner struct {
+	once sync.Once
+	key  []byte
+	// now is the signer clock, replaceable in tests
+	now func() time.Time
+}
+
+func (s *shareSigner) secret() []byte {
+	s.once.Do(func() {
+		if secret := os.Getenv("SHARE_LINK_SECRET"); secret != "" {
+			s.key = []byte(secret)
+			return
+		}
+		s.key = make([]byte, 32)
+		if _, err := rand.Read(s.key); err != nil {
+			log.Printf("Share links: Cannot generate a signing key: %v", err)
+		}
+	})
+	return s.key
+}
+
+func (s *shareSigner) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+func (s *shareSigner) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, s.secret())
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// sign returns a token for the message that expires after ttl, with its
+// expiry time.
+func (s *shareSigner) sign(queueURL, messageID string, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := s.clock().Add(ttl).Truncate(time.Second)
+	claims, err := json.Marshal(shareClaims{QueueURL: queueURL, MessageID: messageID, ExpiresAt: expiresAt.Unix()})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(claims)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload)), expiresAt, nil
+}
+
+// verify returns the claims of a correctly signed, unexpired token.
+func (s *shareSigner) verify(token string) (shareClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return shareClaims{}, errInvalidShareToken
+	}
+	provided, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(provided, s.mac(payload)) {
+		return shareClaims{}, errInvalidShareToken
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return shareClaims{}, errInvalidShareToken
+	}
+	var claims shareClaims
+	if err := json.Unmarshal(decoded, &claims); err != nil {
+		return shareClaims{}, errInvalidShareToken
+	}
+	if !s.clock().Before(time.Unix(claims.ExpiresAt, 0)) {
+		return shareClaims{}, errInvalidShareToken
+	}
+	return claims, nil
+}
+
+// shareLinkTTLFromEnv returns SHARE_LINK_TTL_SECONDS, falling back to
+// defaultShareLinkTTL when unset or invalid.
+func shareLinkTTLFromEnv() time.Duration {
+	if value := os.Getenv("SHARE_LINK_TTL_SECONDS"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		log.Printf("Invalid SHARE_LINK_TTL_SECONDS %q, using %s", value, defaultShareLinkTTL)
+	}
+	return defaultShareLinkTTL
+}
+
+// ShareMessage handles HTTP requests for a temporary link to a message. The
+// message must currently be visible; the link is a signed token naming the
+// queue and message ID that GetSharedMessage accepts until it expires.
+func (h *SQSHandler) ShareMessage(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+	messageID := mux.Vars(r)["messageId"]
+
+	found, err := h.lookupMessages(r.Context(), queueURL, []string{messageID})
+	if err != nil {
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+	if _, ok := found[messageID]; !ok {
+		http.Error(w, "message not found", http.StatusNotFound)
+		return
+	}
+
+	token, expiresAt, err := h.shareLinks.sign(queueURL, messageID, shareLinkTTLFromEnv())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("ShareMessage: Shared message %s of queue %s until %s", messageID, queueURL, expiresAt.Format(time.RFC3339))
+
+	writeJSON(w, r, map[string]string{
+		"token":     token,
+		"path":      routeBasePath(r) + "/api/shared/" + token,
+		"expiresAt": expiresAt.UTC().Format(time.RFC3339),
+	})
+}
+
+// routeBasePath returns the BASE_PATH prefix of the route serving r, the part
+// of its path template before "/api/", or "" outside a router.
+func routeBasePath(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			if i := strings.Index(template, "/api/"); i > 0 {
+				return template[:i]
+			}
+		}
+	}
+	return ""
+}
+
+// RedactedPath returns r's URL path for logging, with a share token replaced:
+// the token is the only credential a shared link needs.
+func RedactedPath(r *http.Request) string {
+	if token := mux.Vars(r)["token"]; token != "" {
+		return strings.Replace(r.URL.Path, token, "[redacted]", 1)
+	}
+	return r.URL.Path
+}
+
+// GetSharedMessage handles HTTP requests for a shared message by token. It
+// is served without API auth, so the token is the only credential: tampered
+// or expired tokens get 403. The message is re-fetched without being
+// consumed, answering 404 once it is gone or not currently visible, and is
+// returned without its receipt handle.
+func (h *SQSHandler) GetSharedMessage(w http.ResponseWriter, r *http.Request) {
+	claims, err := h.shareLinks.verify(mux.Vars(r)["token"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	found, err := h.lookupMessages(context.Background(), claims.QueueURL, []string{claims.MessageID})
+	if err != nil {
+		writeSQSError(w, r, err, claims.QueueURL)
+		return
+	}
+	msg, ok := found[claims.MessageID]
+	if !ok {
+		http.Error(w, "message not found", http.StatusNotFound)
+		return
+	}
+
+	message := ConvertMessage(msg)
+	message.ReceiptHandle = ""
+	if maskAccountIDsEnabled() {
+		message = maskMessage(message)
+	}
+	writeJSON(w, r, message)
+}
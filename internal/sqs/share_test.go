@@ -0,0 +1,125 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func shareMessage(t *testing.T, handler *SQSHandler, queueURL, messageID string) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages/{messageId}/share", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL, "messageId": messageID})
+	rr := httptest.NewRecorder()
+	handler.ShareMessage(rr, req)
+
+	var response struct {
+		Token string `json:"token"`
+	}
+	if rr.Code == http.StatusOK {
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return rr, response.Token
+}
+
+func getShared(handler *SQSHandler, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/api/shared/{token}", nil)
+	req = mux.SetURLVars(req, map[string]string{"token": token})
+	rr := httptest.NewRecorder()
+	handler.GetSharedMessage(rr, req)
+	return rr
+}
+
+func TestSQSHandler_ShareMessage(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+	t.Setenv("SHARE_LINK_SECRET", "test-secret")
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddMessage(queueURL, "msg-1", "shared body")
+	handler := &SQSHandler{Client: mockClient}
+	handler.shareLinks.now = func() time.Time { return now }
+
+	rr, token := shareMessage(t, handler, queueURL, "msg-1")
+	if rr.Code != http.StatusOK || token == "" {
+		t.Fatalf("expected a token, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	t.Run("valid token resolves the message", func(t *testing.T) {
+		rr := getShared(handler, token)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var message types.Message
+		if err := json.NewDecoder(rr.Body).Decode(&message); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if message.MessageId != "msg-1" || message.Body != "shared body" {
+			t.Errorf("unexpected message %+v", message)
+		}
+		if message.ReceiptHandle != "" {
+			t.Errorf("expected no receipt handle, got %q", message.ReceiptHandle)
+		}
+	})
+
+	t.Run("tampered token rejected", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(token, ".")
+		forged, _, _ := strings.Cut(shareTokenFor(t, "other-secret", queueURL, "msg-1"), ".")
+		for _, bad := range []string{forged + "." + signature, payload + ".x" + signature, payload, ""} {
+			if rr := getShared(handler, bad); rr.Code != http.StatusForbidden {
+				t.Errorf("token %q: expected status 403, got %d", bad, rr.Code)
+			}
+		}
+		if rr := getShared(handler, shareTokenFor(t, "other-secret", queueURL, "msg-1")); rr.Code != http.StatusForbidden {
+			t.Errorf("token signed with another secret: expected status 403, got %d", rr.Code)
+		}
+	})
+
+	t.Run("expired token rejected", func(t *testing.T) {
+		later := &SQSHandler{Client: mockClient}
+		later.shareLinks.now = func() time.Time { return now.Add(defaultShareLinkTTL) }
+		if rr := getShared(later, token); rr.Code != http.StatusForbidden {
+			t.Errorf("expected status 403, got %d", rr.Code)
+		}
+	})
+
+	t.Run("deleted message not found", func(t *testing.T) {
+		mockClient.DeleteMessage(context.Background(), &awssqs.DeleteMessageInput{
+			QueueUrl:      aws.String(queueURL),
+			ReceiptHandle: aws.String("receipt-msg-1"),
+		})
+		if rr := getShared(handler, token); rr.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rr.Code)
+		}
+	})
+
+	t.Run("unknown message cannot be shared", func(t *testing.T) {
+		if rr, _ := shareMessage(t, handler, queueURL, "missing"); rr.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", rr.Code)
+		}
+	})
+}
+
+// shareTokenFor signs a token with the given secret.
+func shareTokenFor(t *testing.T, secret, queueURL, messageID string) string {
+	t.Helper()
+	signer := &shareSigner{key: []byte(secret)}
+	signer.once.Do(func() {})
+	token, _, err := signer.sign(queueURL, messageID, time.Hour)
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+	return token
+}
@@ -0,0 +1,245 @@
+package sqs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// maxSnapshotMessages caps how many messages one snapshot holds.
+	maxSnapshotMessages = 1000
+	// defaultSnapshotPageSize and maxSnapshotPageSize bound ?pageSize=.
+	defaultSnapshotPageSize = 10
+	maxSnapshotPageSize     = 100
+	// defaultSnapshotTTL is how long a snapshot can be paged when
+	// SNAPSHOT_TTL_SECONDS is not set.
+	defaultSnapshotTTL = 5 * time.Minute
+	// defaultMaxSnapshots is how many snapshots are kept when MAX_SNAPSHOTS
+	// is not set.
+	defaultMaxSnapshots = 100
+)
+
+// messageSnapshot is a frozen, ordered copy of a queue's messages.
+type messageSnapshot struct {
+	queueURL  string
+	pageSize  int
+	messages  []internal_types.Message
+	expiresAt time.Time
+	// seq orders snapshots by creation, for evicting the oldest
+	seq int
+}
+
+// SnapshotPage is one page of a snapshot. Pages are numbered from 1.
+type SnapshotPage struct {
+	SnapshotID    string                   `json:"snapshotId"`
+	QueueURL      string                   `json:"queueUrl"`
+	Page          int                      `json:"page"`
+	PageSize      int                      `json:"pageSize"`
+	TotalPages    int                      `json:"totalPages"`
+	TotalMessages int                      `json:"totalMessages"`
+	ExpiresAt     string                   `json:"expiresAt"`
+	Messages      []internal_types.Message `json:"messages"`
+}
+
+// snapshotStore holds snapshots in memory. The zero value is ready to use.
+// Expired snapshots answer 410 until they are swept, one TTL after expiry.
+type snapshotStore struct {
+	mu        sync.Mutex
+	snapshots map[string]*messageSnapshot
+	// seq numbers the snapshots put so far
+	seq int
+	// now is the store clock, replaceable in tests
+	now func() time.Time
+}
+
+func (s *snapshotStore) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+// put stores a snapshot under a new random ID, sweeping long-expired ones and
+// evicting the oldest so that at most limit are kept, and returns the ID with
+// a copy of the stored snapshot.
+func (s *snapshotStore) put(snapshot messageSnapshot, ttl time.Duration, limit int) (string, messageSnapshot, error) {
+	idBytes := make([]byte, 12)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", messageSnapshot{}, err
+	}
+	id := hex.EncodeToString(idBytes)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock()
+	if s.snapshots == nil {
+		s.snapshots = make(map[string]*messageSnapshot)
+	}
+	for existingID, existing := range s.snapshots {
+		if now.After(existing.expiresAt.Add(ttl)) {
+			delete(s.snapshots, existingID)
+		}
+	}
+	for len(s.snapshots) >= limit {
+		oldestID := ""
+		for existingID, existing := range s.snapshots {
+			if oldestID == "" || existing.seq < s.snapshots[oldestID].seq {
+				oldestID = existingID
+			}
+		}
+		delete(s.snapshots, oldestID)
+	}
+
+	s.seq++
+	snapshot.seq = s.seq
+	snapshot.expiresAt = now.Add(ttl)
+	s.snapshots[id] = &snapshot
+	return id, snapshot, nil
+}
+
+// get returns a copy of the snapshot, whether it exists and whether it has
+// expired. An expired snapshot's messages are released immediately.
+func (s *snapshotStore) get(id string) (messageSnapshot, bool, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot, ok := s.snapshots[id]
+	if !ok {
+		return messageSnapshot{}, false, false
+	}
+	if !s.clock().Before(snapshot.expiresAt) {
+		snapshot.messages = nil
+		return *snapshot, true, true
+	}
+	return *snapshot, true, false
+}
+
+// page builds page (1-based) of the snapshot.
+func (snapshot messageSnapshot) page(id string, page int) SnapshotPage {
+	totalPages := (len(snapshot.messages) + snapshot.pageSize - 1) / snapshot.pageSize
+	start := min((page-1)*snapshot.pageSize, len(snapshot.messages))
+	end := min(start+snapshot.pageSize, len(snapshot.messages))
+
+	return SnapshotPage{
+		SnapshotID:    id,
+		QueueURL:      snapshot.queueURL,
+		Page:          page,
+		PageSize:      snapshot.pageSize,
+		TotalPages:    totalPages,
+		TotalMessages: len(snapshot.messages),
+		ExpiresAt:     snapshot.expiresAt.UTC().Format(time.RFC3339),
+		Messages:      snapshot.messages[start:end],
+	}
+}
+
+// snapshotTTLFromEnv returns SNAPSHOT_TTL_SECONDS, falling back to
+// defaultSnapshotTTL when unset or invalid.
+func snapshotTTLFromEnv() time.Duration {
+	if value := os.Getenv("SNAPSHOT_TTL_SECONDS"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		log.Printf("Invalid SNAPSHOT_TTL_SECONDS %q, using %s", value, defaultSnapshotTTL)
+	}
+	return defaultSnapshotTTL
+}
+
+// maxSnapshotsFromEnv returns MAX_SNAPSHOTS, falling back to
+// defaultMaxSnapshots when unset or invalid.
+func maxSnapshotsFromEnv() int {
+	if value := os.Getenv("MAX_SNAPSHOTS"); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			return n
+		}
+		log.Printf("Invalid MAX_SNAPSHOTS %q, using %d", value, defaultMaxSnapshots)
+	}
+	return defaultMaxSnapshots
+}
+
+// CreateSnapshot handles HTTP requests to freeze up to maxSnapshotMessages of a
+// queue's messages, received without consuming them, and returns the snapshot
+// ID with its first page. ?pageSize= (default 10, max 100) is fixed for the
+// snapshot's lifetime and ?order= sorts it like GetMessages.
+func (h *SQSHandler) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	pageSize := defaultSnapshotPageSize
+	if sizeParam := r.URL.Query().Get("pageSize"); sizeParam != "" {
+		parsed, err := strconv.Atoi(sizeParam)
+		if err != nil || parsed <= 0 || parsed > maxSnapshotPageSize {
+			http.Error(w, fmt.Sprintf("pageSize must be between 1 and %d", maxSnapshotPageSize), http.StatusBadRequest)
+			return
+		}
+		pageSize = parsed
+	}
+
+	received, err := h.sampleMessages(r.Context(), queueURL, maxSnapshotMessages)
+	if err != nil {
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+
+	messages := make([]internal_types.Message, 0, len(received))
+	for _, msg := range received {
+		messages = append(messages, ConvertMessage(msg))
+	}
+	SortMessages(messages, ResolveSortOrder(r.URL.Query().Get("order")))
+
+	id, snapshot, err := h.snapshots.put(messageSnapshot{queueURL: queueURL, pageSize: pageSize, messages: messages}, snapshotTTLFromEnv(), maxSnapshotsFromEnv())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	log.Printf("CreateSnapshot: Snapshot %s holds %d messages from queue %s", id, len(messages), queueURL)
+
+	writeJSON(w, r, snapshot.page(id, 1))
+}
+
+// GetSnapshotPage handles HTTP requests for ?page=k (default 1) of a
+// snapshot. Unknown snapshots, or ones taken of another queue, are 404;
+// expired ones are 410.
+func (h *SQSHandler) GetSnapshotPage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+	snapshotID := vars["snapshotId"]
+
+	page := 1
+	if pageParam := r.URL.Query().Get("page"); pageParam != "" {
+		parsed, err := strconv.Atoi(pageParam)
+		if err != nil || parsed < 1 {
+			http.Error(w, "page must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		page = parsed
+	}
+
+	snapshot, found, expired := h.snapshots.get(snapshotID)
+	if !found || snapshot.queueURL != queueURL {
+		http.Error(w, "snapshot not found", http.StatusNotFound)
+		return
+	}
+	if expired {
+		http.Error(w, "snapshot expired", http.StatusGone)
+		return
+	}
+
+	writeJSON(w, r, snapshot.page(snapshotID, page))
+}
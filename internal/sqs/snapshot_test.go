@@ -0,0 +1,170 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/gorilla/mux"
+)
+
+func snapshotReq(path string, vars map[string]string) *http.Request {
+	return mux.SetURLVars(httptest.NewRequest("GET", path, nil), vars)
+}
+
+func decodeSnapshotPage(t *testing.T, rr *httptest.ResponseRecorder) SnapshotPage {
+	t.Helper()
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var page SnapshotPage
+	if err := json.NewDecoder(rr.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode snapshot page: %v", err)
+	}
+	return page
+}
+
+func TestSQSHandler_Snapshot_StablePages(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/snapshot-queue"
+
+	client := demo.NewDemoSQSClient()
+	send := func(body string) {
+		client.SendMessage(context.Background(), &awssqs.SendMessageInput{
+			QueueUrl: aws.String(queueURL), MessageBody: aws.String(body),
+		})
+	}
+	for i := 0; i < 25; i++ {
+		send(fmt.Sprintf("message-%d", i))
+	}
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	rr := httptest.NewRecorder()
+	handler.CreateSnapshot(rr, snapshotReq("/api/queues/{queueUrl}/snapshot?pageSize=10", map[string]string{"queueUrl": queueURL}))
+	first := decodeSnapshotPage(t, rr)
+
+	if first.SnapshotID == "" || first.TotalMessages != 25 || first.TotalPages != 3 || len(first.Messages) != 10 {
+		t.Fatalf("unexpected first page: id=%q total=%d pages=%d len=%d", first.SnapshotID, first.TotalMessages, first.TotalPages, len(first.Messages))
+	}
+
+	// New arrivals must not shift the snapshot's pages
+	send("late arrival")
+
+	getPage := func(page int) SnapshotPage {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		handler.GetSnapshotPage(rr, snapshotReq(fmt.Sprintf("/api/queues/{queueUrl}/snapshot/{snapshotId}?page=%d", page),
+			map[string]string{"queueUrl": queueURL, "snapshotId": first.SnapshotID}))
+		return decodeSnapshotPage(t, rr)
+	}
+
+	seen := map[string]int{}
+	for _, msg := range first.Messages {
+		seen[msg.MessageId] = 1
+	}
+	for page, expectedLen := range map[int]int{2: 10, 3: 5, 4: 0} {
+		got := getPage(page)
+		if len(got.Messages) != expectedLen {
+			t.Errorf("page %d: expected %d messages, got %d", page, expectedLen, len(got.Messages))
+		}
+		for _, msg := range got.Messages {
+			if previous, dup := seen[msg.MessageId]; dup {
+				t.Errorf("message %s appears on pages %d and %d", msg.MessageId, previous, page)
+			}
+			seen[msg.MessageId] = page
+		}
+	}
+	if len(seen) != 25 {
+		t.Errorf("expected the pages to cover 25 messages, got %d", len(seen))
+	}
+
+	again := getPage(1)
+	for i := range first.Messages {
+		if again.Messages[i].MessageId != first.Messages[i].MessageId {
+			t.Fatalf("page 1 changed between reads at position %d", i)
+		}
+	}
+}
+
+func TestSQSHandler_Snapshot_ExpiredAndUnknown(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	t.Setenv("SNAPSHOT_TTL_SECONDS", "60")
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+	handler.snapshots.now = func() time.Time { return now }
+
+	rr := httptest.NewRecorder()
+	handler.CreateSnapshot(rr, snapshotReq("/api/queues/{queueUrl}/snapshot", map[string]string{"queueUrl": queueURL}))
+	snapshotID := decodeSnapshotPage(t, rr).SnapshotID
+
+	tests := []struct {
+		name       string
+		queueURL   string
+		snapshotID string
+		advance    time.Duration
+		expected   int
+	}{
+		{name: "live snapshot", queueURL: queueURL, snapshotID: snapshotID, expected: http.StatusOK},
+		{name: "unknown snapshot", queueURL: queueURL, snapshotID: "missing", expected: http.StatusNotFound},
+		{name: "other queue", queueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/demo-payments-queue", snapshotID: snapshotID, expected: http.StatusNotFound},
+		{name: "expired snapshot", queueURL: queueURL, snapshotID: snapshotID, advance: 61 * time.Second, expected: http.StatusGone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.advance)
+
+			rr := httptest.NewRecorder()
+			handler.GetSnapshotPage(rr, snapshotReq("/api/queues/{queueUrl}/snapshot/{snapshotId}",
+				map[string]string{"queueUrl": tt.queueURL, "snapshotId": tt.snapshotID}))
+
+			if rr.Code != tt.expected {
+				t.Errorf("expected %d, got %d: %s", tt.expected, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestSQSHandler_Snapshot_MaxSnapshots(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	t.Setenv("MAX_SNAPSHOTS", "2")
+
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+	var ids []string
+	for i := 0; i < 3; i++ {
+		rr := httptest.NewRecorder()
+		handler.CreateSnapshot(rr, snapshotReq("/api/queues/{queueUrl}/snapshot", map[string]string{"queueUrl": queueURL}))
+		ids = append(ids, decodeSnapshotPage(t, rr).SnapshotID)
+	}
+
+	// The oldest snapshot is evicted to make room for the third
+	for i, expected := range []int{http.StatusNotFound, http.StatusOK, http.StatusOK} {
+		rr := httptest.NewRecorder()
+		handler.GetSnapshotPage(rr, snapshotReq("/api/queues/{queueUrl}/snapshot/{snapshotId}",
+			map[string]string{"queueUrl": queueURL, "snapshotId": ids[i]}))
+		if rr.Code != expected {
+			t.Errorf("snapshot %d: expected %d, got %d", i, expected, rr.Code)
+		}
+	}
+}
+
+func TestSQSHandler_CreateSnapshot_InvalidPageSize(t *testing.T) {
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+	for _, size := range []string{"0", "101", "ten"} {
+		rr := httptest.NewRecorder()
+		handler.CreateSnapshot(rr, snapshotReq("/api/queues/{queueUrl}/snapshot?pageSize="+size,
+			map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"}))
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("pageSize=%s: expected 400, got %d", size, rr.Code)
+		}
+	}
+}
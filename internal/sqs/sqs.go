@@ -3,11 +3,11 @@ package sqs
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -17,7 +17,6 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
-	"github.com/cjunks94/go-sqs-ui/internal/demo"
 	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
 	"github.com/gorilla/mux"
 )
@@ -29,14 +28,36 @@ type SQSClientInterface interface {
 	ListQueueTags(ctx context.Context, params *sqs.ListQueueTagsInput, optFns ...func(*sqs.Options)) (*sqs.ListQueueTagsOutput, error)
 	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
 	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+	SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error)
 	DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error)
+	DeleteQueue(ctx context.Context, params *sqs.DeleteQueueInput, optFns ...func(*sqs.Options)) (*sqs.DeleteQueueOutput, error)
+	ListDeadLetterSourceQueues(ctx context.Context, params *sqs.ListDeadLetterSourceQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListDeadLetterSourceQueuesOutput, error)
 }
 
 // SQSHandler handles HTTP requests for AWS SQS operations and maintains the SQS client.
 type SQSHandler struct {
 	Client SQSClientInterface
+	// S3 receives archived messages; nil disables archiving
+	S3     S3ClientInterface
 	config aws.Config
 	isDemo bool
+	// attributeCache serves ListQueues attributes when prefetching is
+	// enabled; nil fetches them on every request
+	attributeCache *queueAttributeCache
+	// alarms are the registered depth alarms, evaluated by RunAlarmSampler
+	alarms alarmRegistry
+	// snapshots are the paginated message snapshots served by GetSnapshotPage
+	snapshots snapshotStore
+	// uiMetadata is the UI-only queue metadata served by GetUIMetadata
+	uiMetadata uiMetadataStore
+	// shareLinks signs the message share tokens read by GetSharedMessage
+	shareLinks shareSigner
+	// tenantClients builds the per-request clients of MULTI_TENANT mode;
+	// nil uses newTenantClient
+	tenantClients tenantClientFactory
+	// now is the handler clock for message ages; nil uses time.Now
+	now func() time.Time
 }
 
 // NewSQSHandler creates a new SQS handler, automatically detecting and configuring AWS or demo mode.
@@ -46,17 +67,22 @@ func NewSQSHandler() (*SQSHandler, error) {
 	forceLiveMode := os.Getenv("FORCE_LIVE_MODE") == "true"
 
 	if forceDemoMode && forceLiveMode {
-		log.Fatal("Cannot set both FORCE_DEMO_MODE and FORCE_LIVE_MODE")
+		return nil, errors.New("cannot set both FORCE_DEMO_MODE and FORCE_LIVE_MODE")
+	}
+
+	// Multi-tenant mode acts with each request's credentials and never falls
+	// back to demo mode
+	if MultiTenantEnabled() {
+		if forceDemoMode {
+			return nil, errors.New("cannot set both MULTI_TENANT and FORCE_DEMO_MODE")
+		}
+		return newMultiTenantHandler()
 	}
 
 	// If demo mode is forced, use it regardless of AWS config
 	if forceDemoMode {
 		log.Printf("Using demo mode (FORCE_DEMO_MODE=true)")
-		return &SQSHandler{
-			Client: demo.NewDemoSQSClient(),
-			config: aws.Config{},
-			isDemo: true,
-		}, nil
+		return newDemoHandler(aws.Config{})
 	}
 
 	// Custom SQS endpoint (e.g. a local ElasticMQ/LocalStack container). When
@@ -71,17 +97,13 @@ func NewSQSHandler() (*SQSHandler, error) {
 	}
 
 	// Try to load AWS config
-	cfg, err := config.LoadDefaultConfig(context.TODO())
+	cfg, err := loadAWSConfig(context.TODO())
 	if err != nil {
 		if forceLiveMode {
-			log.Fatalf("FORCE_LIVE_MODE is set but AWS config not available: %v", err)
+			return nil, fmt.Errorf("FORCE_LIVE_MODE is set but AWS config not available: %w", err)
 		}
 		log.Printf("Warning: AWS config not available (%v), using demo mode", err)
-		return &SQSHandler{
-			Client: demo.NewDemoSQSClient(),
-			config: aws.Config{},
-			isDemo: true,
-		}, nil
+		return newDemoHandler(aws.Config{})
 	}
 
 	// Test if we can actually connect to AWS
@@ -92,19 +114,16 @@ func NewSQSHandler() (*SQSHandler, error) {
 	_, err = sqsClient.ListQueues(ctx, &sqs.ListQueuesInput{MaxResults: aws.Int32(1)})
 	if err != nil {
 		if forceLiveMode {
-			log.Fatalf("FORCE_LIVE_MODE is set but cannot connect to AWS SQS: %v", err)
+			return nil, fmt.Errorf("FORCE_LIVE_MODE is set but cannot connect to AWS SQS: %w", err)
 		}
 		log.Printf("Warning: Cannot connect to AWS SQS (%v), using demo mode", err)
-		return &SQSHandler{
-			Client: demo.NewDemoSQSClient(),
-			config: cfg,
-			isDemo: true,
-		}, nil
+		return newDemoHandler(cfg)
 	}
 
 	log.Printf("Successfully connected to AWS SQS")
 	return &SQSHandler{
 		Client: sqsClient,
+		S3:     newS3Client(cfg),
 		config: cfg,
 		isDemo: false,
 	}, nil
@@ -113,8 +132,10 @@ func NewSQSHandler() (*SQSHandler, error) {
 // normalizeQueueURL restores the scheme separator that Gorilla mux collapses
 // ("https:/" -> "https://", "http:/" -> "http://") when the queue URL is
 // embedded in the request path. The http:// case matters for local
-// SQS-compatible servers such as ElasticMQ/LocalStack.
+// SQS-compatible servers such as ElasticMQ/LocalStack. Surrounding whitespace
+// is trimmed.
 func normalizeQueueURL(queueURL string) string {
+	queueURL = strings.TrimSpace(queueURL)
 	if strings.HasPrefix(queueURL, "https:/") && !strings.HasPrefix(queueURL, "https://") {
 		return strings.Replace(queueURL, "https:/", "https://", 1)
 	}
@@ -124,6 +145,39 @@ func normalizeQueueURL(queueURL string) string {
 	return queueURL
 }
 
+// queueURLFromRequest returns the normalized queueUrl path variable. A blank
+// one (an encoding bug in the client) is answered with 400 before any AWS
+// call, and ok is false.
+func queueURLFromRequest(w http.ResponseWriter, r *http.Request) (queueURL string, ok bool) {
+	queueURL = normalizeQueueURL(mux.Vars(r)["queueUrl"])
+	if queueURL == "" {
+		http.Error(w, "queueUrl required", http.StatusBadRequest)
+		return "", false
+	}
+	return queueURL, true
+}
+
+// isFIFOQueue reports whether the queue URL names a FIFO queue.
+func isFIFOQueue(queueURL string) bool {
+	return strings.HasSuffix(queueURL, ".fifo")
+}
+
+// HasFIFOQueues reports whether ListQueues returns any FIFO queue, so FIFO
+// controls are only offered where they apply. A failed listing reports false.
+func (h *SQSHandler) HasFIFOQueues(ctx context.Context) bool {
+	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{MaxResults: aws.Int32(1000)})
+	if err != nil {
+		log.Printf("HasFIFOQueues: Could not list queues: %v", err)
+		return false
+	}
+	for _, queueURL := range result.QueueUrls {
+		if isFIFOQueue(queueURL) {
+			return true
+		}
+	}
+	return false
+}
+
 // resolveRegion returns AWS_REGION (or AWS_DEFAULT_REGION), falling back to us-east-1.
 func resolveRegion() string {
 	if r := os.Getenv("AWS_REGION"); r != "" {
@@ -139,7 +193,7 @@ func resolveRegion() string {
 // endpoint (local ElasticMQ/LocalStack), using dummy static credentials so it
 // works without real AWS credentials. This is live mode against a local server.
 func newCustomEndpointHandler(endpoint string) (*SQSHandler, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
+	cfg, err := loadAWSConfig(context.TODO(),
 		config.WithRegion(resolveRegion()),
 		config.WithCredentialsProvider(
 			credentials.NewStaticCredentialsProvider("local", "local", ""),
@@ -162,7 +216,13 @@ func newCustomEndpointHandler(endpoint string) (*SQSHandler, error) {
 }
 
 // ListQueues handles HTTP requests to list SQS queues with optional tag-based filtering.
+// At most MAX_QUEUES_RETURNED filtered queues are returned; see writeQueueList for how truncation is flagged.
 func (h *SQSHandler) ListQueues(w http.ResponseWriter, r *http.Request) {
+	handler, ok := h.forRequest(w, r)
+	if !ok {
+		return
+	}
+
 	log.Printf("ListQueues: Starting to fetch queues")
 	ctx := context.Background()
 
@@ -174,52 +234,71 @@ func (h *SQSHandler) ListQueues(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{
+	// Env-configured tag filter, optionally overridden for this request
+	disableTagFilter, requiredTags, err := tagFilterForRequest(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := handler.Client.ListQueues(ctx, &sqs.ListQueuesInput{
 		MaxResults: aws.Int32(limit),
 	})
 	if err != nil {
 		log.Printf("ListQueues: Error fetching queues: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSQSError(w, r, err, "")
 		return
 	}
+	setRequestID(w, result.ResultMetadata)
 
 	log.Printf("ListQueues: Found %d queues", len(result.QueueUrls))
-	queues := []internal_types.Queue{}
-
-	// Check if tag filtering is disabled
-	disableTagFilter := os.Getenv("DISABLE_TAG_FILTER") == "true"
+	queues := handler.filterQueues(ctx, result.QueueUrls, disableTagFilter, requiredTags)
+	// The cap applies after filtering, on top of the ?limit= passed to AWS
+	maxQueues := maxQueuesReturnedFromEnv()
+	queues, truncated := capQueues(queues, maxQueues)
+	for i := range queues {
+		queues[i].UIMetadata = h.uiMetadata.get(queues[i].URL)
+	}
 
-	// Define required tags for filtering (configurable via environment)
-	requiredTags := map[string][]string{}
+	if err := writeQueueList(w, r, queues, truncated, maxQueues); err != nil {
+		log.Printf("ListQueues: Error encoding response: %v", err)
+		return
+	}
+	log.Printf("ListQueues: Successfully returned %d filtered queues (out of %d total)", len(queues), len(result.QueueUrls))
+}
 
-	if !disableTagFilter {
-		// Use custom tags if provided, otherwise use defaults
-		if businessUnit := os.Getenv("FILTER_BUSINESS_UNIT"); businessUnit != "" {
-			requiredTags["businessunit"] = strings.Split(businessUnit, ",")
-		} else {
-			requiredTags["businessunit"] = []string{"degrees"}
-		}
+// ListFilteredQueues lists up to limit queues with the environment's tag
+// filter applied, as ListQueues does without per-request overrides.
+func (h *SQSHandler) ListFilteredQueues(ctx context.Context, limit int32) ([]internal_types.Queue, error) {
+	disableTagFilter, requiredTags := tagFilterFromEnv()
 
-		if product := os.Getenv("FILTER_PRODUCT"); product != "" {
-			requiredTags["product"] = strings.Split(product, ",")
-		} else {
-			requiredTags["product"] = []string{"amt"}
-		}
+	result, err := h.Client.ListQueues(ctx, &sqs.ListQueuesInput{
+		MaxResults: aws.Int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	noteRequestID(ctx, result.ResultMetadata)
+	return h.filterQueues(ctx, result.QueueUrls, disableTagFilter, requiredTags), nil
+}
 
-		if env := os.Getenv("FILTER_ENV"); env != "" {
-			requiredTags["env"] = strings.Split(env, ",")
-		} else {
-			requiredTags["env"] = []string{"stg", "prod"}
-		}
+// filterQueues drops queues that fail the tag filter or no longer exist and
+// attaches the attributes of the rest, masking display fields when
+// MASK_ACCOUNT_IDS is enabled.
+func (h *SQSHandler) filterQueues(ctx context.Context, queueURLs []string, disableTagFilter bool, requiredTags map[string][]string) []internal_types.Queue {
+	queues := []internal_types.Queue{}
 
+	if !disableTagFilter {
 		log.Printf("ListQueues: Tag filtering enabled with: %+v", requiredTags)
 	} else {
-		log.Printf("ListQueues: Tag filtering disabled (DISABLE_TAG_FILTER=true)")
+		log.Printf("ListQueues: Tag filtering disabled")
 	}
 
-	filteredCount := 0
+	// Per-queue tag decisions are only logged at LOG_LEVEL=debug; otherwise
+	// one summary line keeps large accounts from flooding the logs
+	matched, skipped, tagErrors := 0, 0, 0
 
-	for _, queueURL := range result.QueueUrls {
+	for _, queueURL := range queueURLs {
 		// Skip tag checking if filtering is disabled
 		if disableTagFilter {
 			queue := internal_types.Queue{
@@ -228,22 +307,20 @@ func (h *SQSHandler) ListQueues(w http.ResponseWriter, r *http.Request) {
 			}
 
 			// Get queue attributes
-			attrs, err := h.Client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
-				QueueUrl:       aws.String(queueURL),
-				AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
-			})
+			attributes, err := h.queueAttributes(ctx, queueURL)
+
+			// A queue deleted out-of-band is dropped and forgotten
+			if isQueueNotFound(err) {
+				h.forgetDeletedQueue(queueURL)
+				continue
+			}
 
-			if err == nil && attrs.Attributes != nil {
-				queue.Attributes = attrs.Attributes
+			if err == nil && attributes != nil {
+				queue.Attributes = attributes
 				// Extract queue name from ARN
-				if name, ok := attrs.Attributes["QueueArn"]; ok {
-					for i := len(name) - 1; i >= 0; i-- {
-						if name[i] == ':' {
-							queue.Name = name[i+1:]
-							break
-						}
-					}
-				}
+				identity := queueIdentities.derive(queueURL, attributes["QueueArn"])
+				queue.Name = identity.Name
+				setQueueARNFields(&queue, identity)
 			}
 
 			queues = append(queues, queue)
@@ -251,74 +328,132 @@ func (h *SQSHandler) ListQueues(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Check queue tags if filtering is enabled
-		tagsResult, err := h.Client.ListQueueTags(ctx, &sqs.ListQueueTagsInput{
-			QueueUrl: aws.String(queueURL),
-		})
+		tags, err := h.listQueueTags(ctx, queueURL)
+		tagsUnavailable := false
 		if err != nil {
-			log.Printf("ListQueues: Error fetching tags for queue %s: %v", queueURL, err)
-			continue
-		}
-
-		// Check if queue matches all required tags
-		matchesAllTags := true
-		for tagKey, validValues := range requiredTags {
-			tagValue, exists := tagsResult.Tags[tagKey]
-			if !exists {
-				log.Printf("ListQueues: Queue %s missing required tag: %s", queueURL, tagKey)
-				matchesAllTags = false
-				break
+			if isQueueNotFound(err) {
+				h.forgetDeletedQueue(queueURL)
+				continue
 			}
-			if !contains(validValues, tagValue) {
-				log.Printf("ListQueues: Queue %s has invalid value '%s' for tag '%s' (expected: %v)", queueURL, tagValue, tagKey, validValues)
-				matchesAllTags = false
-				break
+			tagErrors++
+			debugf("ListQueues: Error fetching tags for queue %s: %v", queueURL, err)
+			// Optionally show the queue unfiltered rather than hide it
+			// during a partial tag API outage
+			if !includeQueuesWithoutTags() {
+				continue
 			}
-		}
-
-		if !matchesAllTags {
+			tagsUnavailable = true
+		} else if !matchesRequiredTags(queueURL, tags, requiredTags) {
+			// Check if queue matches all required tags
+			skipped++
 			continue
+		} else {
+			matched++
+			debugf("ListQueues: Queue %s matches all required tags", queueURL)
 		}
 
-		filteredCount++
-		log.Printf("ListQueues: Queue %s matches all required tags", queueURL)
-
 		// Get queue attributes for matching queues
-		attrs, err := h.Client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
-			QueueUrl:       aws.String(queueURL),
-			AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameAll},
-		})
-
-		queueName := queueURL
-		if attrs != nil && attrs.Attributes != nil {
-			if name, ok := attrs.Attributes["QueueArn"]; ok {
-				for i := len(name) - 1; i >= 0; i-- {
-					if name[i] == ':' {
-						queueName = name[i+1:]
-						break
-					}
-				}
-			}
+		attributes, err := h.queueAttributes(ctx, queueURL)
+
+		if isQueueNotFound(err) {
+			h.forgetDeletedQueue(queueURL)
+			continue
 		}
 
 		queue := internal_types.Queue{
-			Name: queueName,
-			URL:  queueURL,
+			Name:            queueURL,
+			URL:             queueURL,
+			TagsUnavailable: tagsUnavailable,
+		}
+		if attributes != nil {
+			identity := queueIdentities.derive(queueURL, attributes["QueueArn"])
+			queue.Name = identity.Name
+			setQueueARNFields(&queue, identity)
 		}
 
-		if err == nil && attrs.Attributes != nil {
-			queue.Attributes = attrs.Attributes
+		if err == nil && attributes != nil {
+			queue.Attributes = attributes
 		}
 
 		queues = append(queues, queue)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(queues); err != nil {
-		log.Printf("ListQueues: Error encoding response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
+	if !disableTagFilter {
+		log.Printf("ListQueues: Tag filter checked %d queues: %d matched, %d skipped, %d tag errors", len(queueURLs), matched, skipped, tagErrors)
+	}
+
+	if maskAccountIDsEnabled() {
+		for i := range queues {
+			queues[i] = maskQueue(queues[i])
+		}
+	}
+
+	return queues
+}
+
+// tagFilterFromEnv reports whether tag filtering is disabled (DISABLE_TAG_FILTER)
+// and, when enabled, the required tag values from FILTER_BUSINESS_UNIT,
+// FILTER_PRODUCT and FILTER_ENV, falling back to the defaults.
+func tagFilterFromEnv() (bool, map[string][]string) {
+	if os.Getenv("DISABLE_TAG_FILTER") == "true" {
+		return true, map[string][]string{}
+	}
+
+	return false, requiredTagsFromEnv()
+}
+
+// requiredTagsFromEnv returns the required tag values from FILTER_BUSINESS_UNIT,
+// FILTER_PRODUCT and FILTER_ENV, falling back to the defaults.
+func requiredTagsFromEnv() map[string][]string {
+	requiredTags := map[string][]string{}
+
+	// Use custom tags if provided, otherwise use defaults
+	if businessUnit := os.Getenv("FILTER_BUSINESS_UNIT"); businessUnit != "" {
+		requiredTags["businessunit"] = strings.Split(businessUnit, ",")
+	} else {
+		requiredTags["businessunit"] = []string{"degrees"}
+	}
+
+	if product := os.Getenv("FILTER_PRODUCT"); product != "" {
+		requiredTags["product"] = strings.Split(product, ",")
+	} else {
+		requiredTags["product"] = []string{"amt"}
+	}
+
+	if env := os.Getenv("FILTER_ENV"); env != "" {
+		requiredTags["env"] = strings.Split(env, ",")
+	} else {
+		requiredTags["env"] = []string{"stg", "prod"}
+	}
+
+	return requiredTags
+}
+
+// matchesRequiredTags reports whether tags carry an accepted value for every
+// required tag key, logging the first mismatch at LOG_LEVEL=debug.
+func matchesRequiredTags(queueURL string, tags map[string]string, requiredTags map[string][]string) bool {
+	for tagKey, validValues := range requiredTags {
+		tagValue, exists := tags[tagKey]
+		if !exists {
+			debugf("ListQueues: Queue %s missing required tag: %s", queueURL, tagKey)
+			return false
+		}
+		if !contains(validValues, tagValue) {
+			debugf("ListQueues: Queue %s has invalid value '%s' for tag '%s' (expected: %v)", queueURL, tagValue, tagKey, validValues)
+			return false
+		}
+	}
+	return true
+}
+
+// forgetDeletedQueue evicts a queue that SQS reports as non-existent from the
+// identity and attribute caches so it is not served again.
+func (h *SQSHandler) forgetDeletedQueue(queueURL string) {
+	log.Printf("Queue %s no longer exists, dropping it from the caches", queueURL)
+	queueIdentities.evict(queueURL)
+	if h.attributeCache != nil {
+		h.attributeCache.evict(queueURL)
 	}
-	log.Printf("ListQueues: Successfully returned %d filtered queues (out of %d total)", len(queues), len(result.QueueUrls))
 }
 
 // contains checks if a value exists in a slice (case-insensitive)
@@ -333,18 +468,28 @@ func contains(slice []string, value string) bool {
 
 // GetMessages handles HTTP requests to retrieve messages from a specific SQS queue.
 func (h *SQSHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	queueURL := vars["queueUrl"]
-
-	queueURL = normalizeQueueURL(queueURL)
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+	handler, ok := h.forRequest(w, r)
+	if !ok {
+		return
+	}
 
 	log.Printf("GetMessages: Raw queueUrl from route: %s", queueURL)
 	log.Printf("GetMessages: Full request URL: %s", r.URL.String())
 
-	// Get limit from query parameter, default to 10 (SQS max per call)
-	limit := int32(10)
+	// Get limit from query parameter, defaulting to MESSAGES_DEFAULT_LIMIT;
+	// a limit over MESSAGES_MAX_LIMIT is rejected
+	defaultLimit, maxLimit := messagesLimitsFromEnv()
+	limit := int32(defaultLimit)
 	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
-		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 && parsedLimit <= 10 {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			if parsedLimit > maxLimit {
+				http.Error(w, fmt.Sprintf("limit must not exceed %d", maxLimit), http.StatusBadRequest)
+				return
+			}
 			limit = int32(parsedLimit)
 		}
 	}
@@ -361,178 +506,357 @@ func (h *SQSHandler) GetMessages(w http.ResponseWriter, r *http.Request) {
 	// Receive enough messages to cover the requested offset window before
 	// slicing below. Live SQS hard-caps a single ReceiveMessage at 10 and does
 	// not return a stable ordered set across calls, so deep offsets are not
-	// reachable for live queues (inherent SQS limitation). Demo/mock clients
-	// hold the full message set, so they can serve the whole offset window.
-	// Compute in int and clamp before the int32 cast to avoid overflow on a
-	// large offset wrapping MaxNumberOfMessages negative.
-	maxReceive := 10
-	if h.isDemo {
+	// reachable for live queues (inherent SQS limitation); live receives stop
+	// at MESSAGES_MAX_LIMIT. Demo/mock clients hold the full message set, so
+	// they can serve the whole offset window. Compute in int and clamp before
+	// the int32 cast to avoid overflow on a large offset wrapping
+	// MaxNumberOfMessages negative.
+	maxReceive := max(sqsMaxReceive, maxLimit)
+	if handler.isDemo {
 		maxReceive = 1000
 	}
 	receiveCount := offset + int(limit)
 	if receiveCount > maxReceive {
+		log.Printf("GetMessages: Offset window %d+%d exceeds the %d messages receivable, the page may be short", offset, limit, maxReceive)
 		receiveCount = maxReceive
 	}
 	if receiveCount < 1 {
 		receiveCount = 1
 	}
 
+	// Only messages that waited at least this long before first pickup
+	minLatencyMs := int64(-1)
+	if latencyParam := r.URL.Query().Get("minLatencyMs"); latencyParam != "" {
+		parsed, err := strconv.ParseInt(latencyParam, 10, 64)
+		if err != nil || parsed < 0 {
+			http.Error(w, "minLatencyMs must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		minLatencyMs = parsed
+	}
+
+	// Optionally order by a message attribute (e.g. a priority) instead of
+	// the send time
+	sortAttr := r.URL.Query().Get("sortAttr")
+	sortAttrType, ok := parseSortAttrType(r.URL.Query().Get("sortAttrType"))
+	if !ok {
+		http.Error(w, "sortAttrType must be number or string", http.StatusBadRequest)
+		return
+	}
+
+	// FIFO sequence gap detection wraps the response as {"messages", "gaps"}
+	detectGaps := r.URL.Query().Get("detectGaps") == "true"
+	if detectGaps && !isFIFOQueue(queueURL) {
+		http.Error(w, "detectGaps only applies to FIFO queues", http.StatusBadRequest)
+		return
+	}
+
+	// Optionally override the queue's visibility timeout; 0 peeks
+	var visibilityTimeout *int32
+	if timeoutParam := r.URL.Query().Get("visibilityTimeout"); timeoutParam != "" {
+		seconds, err := strconv.Atoi(timeoutParam)
+		if err == nil {
+			err = ValidateVisibilityTimeout(seconds)
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("visibilityTimeout must be between 0 and %d", maxVisibilityTimeoutSeconds), http.StatusBadRequest)
+			return
+		}
+		timeout := int32(seconds)
+		visibilityTimeout = &timeout
+	}
+
 	log.Printf("GetMessages: Fetching up to %d messages (offset %d, limit %d) for queue %s", receiveCount, offset, limit, queueURL)
 	// Use the request context so the long-poll respects client disconnects and
 	// server deadlines instead of outliving the HTTP request.
 	ctx := r.Context()
 
-	result, err := h.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+	// "All" also returns message system attributes such as AWSTraceHeader.
+	input := &sqs.ReceiveMessageInput{
 		QueueUrl:              aws.String(queueURL),
-		MaxNumberOfMessages:   int32(receiveCount),
 		WaitTimeSeconds:       1,
 		AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
 		MessageAttributeNames: []string{"All"},
-	})
+	}
+	if visibilityTimeout != nil {
+		input.VisibilityTimeout = *visibilityTimeout
+	}
+
+	// A receive attempt ID makes a retried FIFO receive return the same batch.
+	// SQS only honours it for FIFO queues, so ignore it for standard ones.
+	if attemptID := r.URL.Query().Get("receiveAttemptId"); attemptID != "" && isFIFOQueue(queueURL) {
+		input.ReceiveRequestAttemptId = aws.String(attemptID)
+	}
+
+	receivedAt := handler.clock()
+	received, err := handler.receivePage(ctx, input, receiveCount)
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSQSError(w, r, err, queueURL)
 		return
 	}
+	// The SDK does not send a zero VisibilityTimeout, so a peek received
+	// with the queue's default and is undone here once the page is complete
+	if visibilityTimeout != nil && *visibilityTimeout == 0 {
+		ReleaseMessages(ctx, handler.Client, queueURL, received)
+	}
+
+	// SQS digests are opt-in to keep normal responses small
+	includeMD5 := r.URL.Query().Get("includeMd5") == "true"
 
 	messages := []internal_types.Message{}
-	for _, msg := range result.Messages {
-		message := internal_types.Message{
-			MessageId:     aws.ToString(msg.MessageId),
-			Body:          aws.ToString(msg.Body),
-			ReceiptHandle: aws.ToString(msg.ReceiptHandle),
-			Attributes:    make(map[string]string),
+	for _, msg := range received {
+		message := ConvertMessage(msg)
+		if includeMD5 {
+			message.MD5OfBody = aws.ToString(msg.MD5OfBody)
+			message.MD5OfMessageAttributes = aws.ToString(msg.MD5OfMessageAttributes)
 		}
+		messages = append(messages, message)
+	}
 
-		for k, v := range msg.Attributes {
-			message.Attributes[k] = v
-		}
+	clockSkewDetected := SetMessageAges(messages, receivedAt)
+	if clockSkewDetected {
+		writeClockSkewWarning(w.Header(), queueURL)
+	}
 
-		messages = append(messages, message)
+	// Tell the UI when received messages reappear, for a countdown
+	if len(messages) > 0 {
+		SetVisibleAgainAt(messages, receivedAt, handler.effectiveVisibilityTimeout(ctx, queueURL, visibilityTimeout))
 	}
 
-	// Sort messages by SentTimestamp in descending order (newest first)
-	// This ensures consistent chronological ordering regardless of SQS return order
-	sort.Slice(messages, func(i, j int) bool {
-		timeI := getTimestampFromMessage(messages[i])
-		timeJ := getTimestampFromMessage(messages[j])
-		return timeI > timeJ // Descending order (newest first)
-	})
+	// Sort by SentTimestamp (newest first unless MESSAGE_SORT_ORDER or ?order=
+	// say otherwise) for consistent ordering regardless of SQS return order,
+	// then by ?sortAttr= when given, keeping send order among ties
+	order := ResolveSortOrder(r.URL.Query().Get("order"))
+	SortMessages(messages, order)
+	if sortAttr != "" {
+		SortMessagesByAttribute(messages, received, sortAttr, sortAttrType, order)
+	}
+
+	if minLatencyMs >= 0 {
+		messages = filterByMinLatency(messages, minLatencyMs)
+	}
+
+	// Presence filters ignore attribute values; repeated parameters must all hold
+	hasAttrs, missingAttrs := r.URL.Query()["hasAttr"], r.URL.Query()["missingAttr"]
+	if len(hasAttrs) > 0 || len(missingAttrs) > 0 {
+		messages = filterByAttributePresence(messages, received, hasAttrs, missingAttrs)
+	}
+
+	// DLQ messages can be narrowed to the queue they failed from
+	if originalQueue := r.URL.Query().Get("originalQueue"); originalQueue != "" {
+		messages = filterByOriginalQueue(messages, received, originalQueue)
+	}
+
+	// Gaps are looked for across everything received, not just the page
+	var gaps []sequenceGap
+	if detectGaps {
+		gaps = detectSequenceGaps(messages)
+	}
 
-	// Apply offset if specified (primarily for testing with mock client)
+	// Offset selects the window start in the sorted list and limit its length
+	// (primarily for testing with mock client).
 	// Note: This doesn't work with real SQS as SQS doesn't support offset-based pagination
-	if offset > 0 {
-		if offset >= len(messages) {
-			messages = []internal_types.Message{}
-		} else {
-			messages = messages[offset:]
+	messages = pageWindow(messages, offset, int(limit))
+
+	// Extract the requested summary field only for the page being returned
+	if summaryField := r.URL.Query().Get("summaryField"); summaryField != "" {
+		for i := range messages {
+			if messages[i].ContentType == contentTypeJSON {
+				messages[i].Summary = extractSummary(messages[i].Body, summaryField)
+			}
 		}
 	}
 
-	// Apply limit to sliced messages if needed
-	if len(messages) > int(limit) {
-		messages = messages[:limit]
+	if maskAccountIDsEnabled() {
+		for i := range messages {
+			messages[i] = maskMessage(messages[i])
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(messages); err != nil {
+	// Gap detection and ?envelope=true wrap the list, adding the skew flag
+	if detectGaps || r.URL.Query().Get("envelope") == "true" {
+		response := map[string]interface{}{
+			"messages":          messages,
+			"clockSkewDetected": clockSkewDetected,
+		}
+		if detectGaps {
+			response["gaps"] = gaps
+		}
+		writeJSON(w, r, response)
+		return
+	}
+
+	if err := streamJSONList(w, r, messages); err != nil {
 		log.Printf("Error encoding messages response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
 }
 
 // SendMessage handles HTTP requests to send a new message to an SQS queue.
 func (h *SQSHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	queueURL := vars["queueUrl"]
-
-	queueURL = normalizeQueueURL(queueURL)
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
 
 	var payload struct {
 		Body string `json:"body"`
+		// Attributes are plain strings or {"dataType", "value"} objects
+		Attributes             map[string]sendAttribute `json:"attributes"`
+		TraceHeader            string                   `json:"traceHeader"`
+		MessageGroupID         string                   `json:"messageGroupId"`
+		MessageDeduplicationID string                   `json:"messageDeduplicationId"`
+		// MaxDepth refuses the send with 409 when the queue already holds
+		// at least this many visible messages
+		MaxDepth *int `json:"maxDepth"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if !decodeLimitedJSON(w, r, &payload) {
+		return
+	}
+
+	attributes, typeErr := typedMessageAttributes(withDefaultAttributes(payload.Attributes))
+	if typeErr != nil {
+		log.Printf("SendMessage: Refusing message for queue %s: %v", queueURL, typeErr)
+		writeAttributeTypeError(w, r, typeErr)
 		return
 	}
 
 	ctx := context.Background()
 
-	result, err := h.Client.SendMessage(ctx, &sqs.SendMessageInput{
-		QueueUrl:    aws.String(queueURL),
-		MessageBody: aws.String(payload.Body),
-	})
+	input := &sqs.SendMessageInput{
+		QueueUrl:          aws.String(queueURL),
+		MessageBody:       aws.String(payload.Body),
+		MessageAttributes: attributes,
+	}
+	if size := sqsMessageSize(payload.Body, input.MessageAttributes); size.exceedsLimit() {
+		log.Printf("SendMessage: Refusing %d byte message for queue %s", size.TotalBytes, queueURL)
+		writeMessageTooLarge(w, r, size, nil)
+		return
+	}
+	input.MessageSystemAttributes = traceHeaderAttributes(payload.TraceHeader)
+	// Group and deduplication IDs only apply to FIFO queues
+	if isFIFOQueue(queueURL) {
+		if payload.MessageGroupID != "" {
+			input.MessageGroupId = aws.String(payload.MessageGroupID)
+		}
+		if payload.MessageDeduplicationID != "" {
+			input.MessageDeduplicationId = aws.String(payload.MessageDeduplicationID)
+		}
+	}
 
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if !h.checkFIFOSendParameters(w, r, queueURL, aws.ToString(input.MessageGroupId), aws.ToString(input.MessageDeduplicationId)) {
+		return
+	}
+	if !h.checkMaxDepth(w, r, queueURL, payload.MaxDepth) {
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(map[string]string{
-		"messageId": aws.ToString(result.MessageId),
-	}); err != nil {
-		log.Printf("Error encoding send message response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	result, err := h.Client.SendMessage(ctx, input)
+
+	if err != nil {
+		log.Printf("SendMessage: Error sending to queue %s: %v", queueURL, err)
+		writeSQSError(w, r, err, queueURL)
 		return
 	}
+	setRequestID(w, result.ResultMetadata)
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:    statusSent,
+		MessageId: aws.ToString(result.MessageId),
+	})
 }
 
-// DeleteMessage handles HTTP requests to delete a message from an SQS queue using its receipt handle.
+// DeleteMessage handles HTTP requests to delete a message from an SQS queue
+// using its receipt handle. It answers 204 No Content, or 200 with an
+// OperationResult when called with ?result=true.
 func (h *SQSHandler) DeleteMessage(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	queueURL := vars["queueUrl"]
-
-	queueURL = normalizeQueueURL(queueURL)
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
 	receiptHandle := vars["receiptHandle"]
 
 	ctx := context.Background()
 
-	_, err := h.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+	result, err := h.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
 		QueueUrl:      aws.String(queueURL),
 		ReceiptHandle: aws.String(receiptHandle),
 	})
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSQSError(w, r, err, queueURL)
 		return
 	}
+	setRequestID(w, result.ResultMetadata)
 
-	w.WriteHeader(http.StatusNoContent)
+	if r.URL.Query().Get("result") != "true" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:        statusDeleted,
+		AffectedCount: affected(1),
+	})
 }
 
 // RetryMessage handles HTTP requests to retry a DLQ message by sending it to the target queue and deleting it from the source.
+// An optional "patch" list of JSON Patch (RFC 6902) operations is applied to the body first; a patch that fails to apply,
+// or a body that isn't JSON, is answered with 422 and nothing is sent.
 func (h *SQSHandler) RetryMessage(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	sourceQueueURL := vars["queueUrl"]
-
-	sourceQueueURL = normalizeQueueURL(sourceQueueURL)
+	sourceQueueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
 
 	var payload struct {
 		Message        internal_types.Message `json:"message"`
 		TargetQueueURL string                 `json:"targetQueueUrl"`
+		Patch          []patchOperation       `json:"patch"`
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if !decodeLimitedJSON(w, r, &payload) {
 		return
 	}
 
+	// The allow-list check and the send use the same normalized URL
+	targetURL := normalizeQueueURL(payload.TargetQueueURL)
+	if !retryTargetAllowed(targetURL) {
+		log.Printf("RetryMessage: Refusing target %s, not allowed by RETRY_TARGET_ALLOW", targetURL)
+		http.Error(w, "targetQueueUrl is not allowed by RETRY_TARGET_ALLOW", http.StatusForbidden)
+		return
+	}
+
+	body := payload.Message.Body
+	if len(payload.Patch) > 0 {
+		patched, err := applyJSONPatch(body, payload.Patch)
+		if err != nil {
+			log.Printf("RetryMessage: Error applying patch: %v", err)
+			writeJSONStatus(w, r, http.StatusUnprocessableEntity, map[string]interface{}{
+				"error": err.Error(),
+			})
+			return
+		}
+		body = patched
+	}
+
 	ctx := context.Background()
 
-	// Send message to target queue
+	// Send message to target queue, keeping its trace context
 	result, err := h.Client.SendMessage(ctx, &sqs.SendMessageInput{
-		QueueUrl:    aws.String(payload.TargetQueueURL),
-		MessageBody: aws.String(payload.Message.Body),
+		QueueUrl:                aws.String(targetURL),
+		MessageBody:             aws.String(body),
+		MessageSystemAttributes: traceHeaderAttributes(payload.Message.TraceHeader),
 	})
 
 	if err != nil {
 		log.Printf("RetryMessage: Error sending to target queue: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSQSError(w, r, err, targetURL)
 		return
 	}
+	setRequestID(w, result.ResultMetadata)
 
 	// Delete from source queue (DLQ)
 	_, err = h.Client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
@@ -545,15 +869,10 @@ func (h *SQSHandler) RetryMessage(w http.ResponseWriter, r *http.Request) {
 		// Don't fail the request, message was successfully retried
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(map[string]string{
-		"messageId": aws.ToString(result.MessageId),
-		"status":    "retried",
-	}); err != nil {
-		log.Printf("Error encoding retry response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:    statusRetried,
+		MessageId: aws.ToString(result.MessageId),
+	})
 }
 
 // GetAWSContext handles HTTP requests to retrieve AWS context information including region and mode.
@@ -565,10 +884,13 @@ func (h *SQSHandler) GetAWSContext(w http.ResponseWriter, r *http.Request) {
 		Region    string `json:"region,omitempty"`
 		Profile   string `json:"profile,omitempty"`
 		AccountID string `json:"accountId,omitempty"`
+		// DefaultQueue is the queue name or URL the UI selects on load
+		DefaultQueue string `json:"defaultQueue,omitempty"`
 	}
 
 	context := AWSContext{
-		Mode: "Demo",
+		Mode:         "Demo",
+		DefaultQueue: defaultQueueFromEnv(),
 	}
 
 	if !h.isDemo {
@@ -592,12 +914,7 @@ func (h *SQSHandler) GetAWSContext(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(context); err != nil {
-		log.Printf("GetAWSContext: Error encoding response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
-	}
+	writeJSON(w, r, context)
 
 	log.Printf("GetAWSContext: Successfully returned context (mode: %s)", context.Mode)
 }
@@ -622,10 +939,22 @@ func getTimestampFromMessage(message internal_types.Message) int64 {
 
 // GetQueueStatistics returns statistics for a queue
 func (h *SQSHandler) GetQueueStatistics(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	queueURL := vars["queueUrl"]
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
 
-	queueURL = normalizeQueueURL(queueURL)
+	// DLQ aggregates and body statistics are computed over a non-consuming
+	// sample of this size
+	sampleSize := defaultDLQSampleSize
+	if sizeParam := r.URL.Query().Get("sampleSize"); sizeParam != "" {
+		parsed, err := strconv.Atoi(sizeParam)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "sampleSize must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		sampleSize = min(parsed, maxDLQSampleSize)
+	}
 
 	log.Printf("GetQueueStatistics: Fetching statistics for queue %s", queueURL)
 	ctx := context.Background()
@@ -638,40 +967,40 @@ func (h *SQSHandler) GetQueueStatistics(w http.ResponseWriter, r *http.Request)
 
 	if err != nil {
 		log.Printf("GetQueueStatistics: Error fetching queue attributes: %v", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeSQSError(w, r, err, queueURL)
 		return
 	}
+	setRequestID(w, attrs.ResultMetadata)
 
 	// Extract queue name from ARN
-	queueName := queueURL
-	if arn, ok := attrs.Attributes["QueueArn"]; ok {
-		parts := strings.Split(arn, ":")
-		if len(parts) > 0 {
-			queueName = parts[len(parts)-1]
-		}
-	}
+	queueName := queueIdentities.derive(queueURL, attrs.Attributes["QueueArn"]).Name
 
 	// Check if it's a DLQ
-	isDLQ := strings.HasSuffix(queueName, "-dlq") ||
-		strings.HasSuffix(queueName, "-DLQ") ||
-		attrs.Attributes["RedriveAllowPolicy"] != ""
+	isDLQ := isDeadLetterQueue(queueName, attrs.Attributes)
+
+	displayName := queueName
+	if maskAccountIDsEnabled() {
+		displayName = maskAccountIDs(queueName)
+	}
 
 	// Build statistics response
 	stats := map[string]interface{}{
-		"queueName":        queueName,
+		"queueName":        displayName,
 		"totalMessages":    parseIntSafe(attrs.Attributes["ApproximateNumberOfMessages"]),
 		"messagesInFlight": parseIntSafe(attrs.Attributes["ApproximateNumberOfMessagesNotVisible"]),
 		"messagesDelayed":  parseIntSafe(attrs.Attributes["ApproximateNumberOfMessagesDelayed"]),
 		"isDLQ":            isDLQ,
 	}
 
-	// Add timestamps if available
-	if created := attrs.Attributes["CreatedTimestamp"]; created != "" {
-		stats["createdTimestamp"] = parseIntSafe(created) * 1000
+	// Add timestamps if available, as epoch millis plus an ISO-8601 string
+	if created, ok := epochTimestamp(attrs.Attributes["CreatedTimestamp"]); ok {
+		stats["createdTimestamp"] = created.UnixMilli()
+		stats["createdTime"] = created.UTC().Format(time.RFC3339)
 	}
 
-	if modified := attrs.Attributes["LastModifiedTimestamp"]; modified != "" {
-		stats["lastModifiedTimestamp"] = parseIntSafe(modified) * 1000
+	if modified, ok := epochTimestamp(attrs.Attributes["LastModifiedTimestamp"]); ok {
+		stats["lastModifiedTimestamp"] = modified.UnixMilli()
+		stats["lastModifiedTime"] = modified.UTC().Format(time.RFC3339)
 	}
 
 	// Calculate message age if possible
@@ -679,50 +1008,92 @@ func (h *SQSHandler) GetQueueStatistics(w http.ResponseWriter, r *http.Request)
 		stats["oldestMessageAge"] = parseIntSafe(oldestAge) * 1000
 	}
 
+	// Summarize who may do what, from the queue's access policy
+	policy, err := summarizePolicy(attrs.Attributes["Policy"])
+	if err != nil {
+		log.Printf("GetQueueStatistics: Could not parse policy of queue %s: %v", queueURL, err)
+		stats["policyError"] = err.Error()
+	}
+	if maskAccountIDsEnabled() {
+		policy = maskPolicySummary(policy)
+	}
+	stats["policy"] = policy
+
+	// FIFO queues expose their deduplication and throughput settings
+	if isFIFOQueue(queueURL) || attrs.Attributes["FifoQueue"] == "true" {
+		stats["fifo"] = map[string]interface{}{
+			"contentBasedDeduplication": attrs.Attributes["ContentBasedDeduplication"] == "true",
+			"deduplicationScope":        attrs.Attributes["DeduplicationScope"],
+			"fifoThroughputLimit":       attrs.Attributes["FifoThroughputLimit"],
+		}
+	}
+
+	// Sample messages, across several receives if needed, for DLQ-specific
+	// stats and, on any queue with ?includeBodyStats=true, body sizes
+	includeBodyStats := isDLQ || r.URL.Query().Get("includeBodyStats") == "true"
+	var sampled []types.Message
+	var sampleErr error
+	if includeBodyStats {
+		sampled, sampleErr = h.sampleMessages(ctx, queueURL, sampleSize)
+		if sampleErr != nil {
+			log.Printf("GetQueueStatistics: Error sampling messages: %v", sampleErr)
+		} else {
+			stats["bodyStatistics"] = bodySizeStatistics(sampled)
+		}
+	}
+
 	// For DLQ, try to get additional statistics
-	if isDLQ {
-		// Sample a few messages to calculate DLQ-specific stats
-		messages, err := h.Client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
-			QueueUrl:              aws.String(queueURL),
-			MaxNumberOfMessages:   10,
-			AttributeNames:        []types.QueueAttributeName{types.QueueAttributeNameAll},
-			MessageAttributeNames: []string{"All"},
-		})
-
-		if err == nil && len(messages.Messages) > 0 {
-			totalReceiveCount := 0
-			maxReceiveCount := 0
-			errorTypes := make(map[string]int)
-
-			for _, msg := range messages.Messages {
-				if receiveCount := msg.Attributes["ApproximateReceiveCount"]; receiveCount != "" {
-					count := parseIntSafe(receiveCount)
-					totalReceiveCount += count
-					if count > maxReceiveCount {
-						maxReceiveCount = count
-					}
+	if isDLQ && sampleErr == nil && len(sampled) > 0 {
+		totalReceiveCount := 0
+		maxReceiveCount := 0
+		errorTypes := make(map[string]int)
+
+		for _, msg := range sampled {
+			if receiveCount := msg.Attributes["ApproximateReceiveCount"]; receiveCount != "" {
+				count := parseIntSafe(receiveCount)
+				totalReceiveCount += count
+				if count > maxReceiveCount {
+					maxReceiveCount = count
 				}
+			}
 
-				// Try to extract error type from message attributes
-				if errorType, ok := msg.MessageAttributes["ErrorType"]; ok && errorType.StringValue != nil {
-					errorTypes[*errorType.StringValue]++
-				}
+			// Try to extract error type from message attributes
+			if errorType, ok := msg.MessageAttributes["ErrorType"]; ok && errorType.StringValue != nil {
+				errorTypes[*errorType.StringValue]++
 			}
+		}
 
-			stats["dlqStatistics"] = map[string]interface{}{
-				"sampleSize":          len(messages.Messages),
-				"averageReceiveCount": float64(totalReceiveCount) / float64(len(messages.Messages)),
-				"maxReceiveCount":     maxReceiveCount,
-				"errorTypes":          errorTypes,
+		// Break down a chosen string attribute (ErrorType by default)
+		groupAttribute := r.URL.Query().Get("groupAttribute")
+		if groupAttribute == "" {
+			groupAttribute = defaultGroupAttribute
+		}
+		groupTop := defaultGroupTop
+		if topParam := r.URL.Query().Get("groupTop"); topParam != "" {
+			if parsed, err := strconv.Atoi(topParam); err == nil && parsed > 0 {
+				groupTop = parsed
 			}
 		}
+		groupValues, distinctValues := attributeBreakdown(sampled, groupAttribute, groupTop)
+
+		// sampleSize is what was actually sampled; with queueDepth the UI
+		// can caveat percentages drawn from a partial sample
+		stats["dlqStatistics"] = map[string]interface{}{
+			"sampleSize":          len(sampled),
+			"requestedSampleSize": sampleSize,
+			"queueDepth":          parseIntSafe(attrs.Attributes["ApproximateNumberOfMessages"]),
+			"averageReceiveCount": float64(totalReceiveCount) / float64(len(sampled)),
+			"maxReceiveCount":     maxReceiveCount,
+			"errorTypes":          errorTypes,
+			"attributeBreakdown": map[string]interface{}{
+				"attribute":      groupAttribute,
+				"distinctValues": distinctValues,
+				"values":         groupValues,
+			},
+		}
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(stats); err != nil {
-		log.Printf("Error encoding statistics response: %v", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-	}
+	writeJSON(w, r, stats)
 }
 
 // Helper function to safely parse int from string
@@ -732,3 +1103,22 @@ func parseIntSafe(s string) int {
 	}
 	return 0
 }
+
+// epochMillisThreshold separates epoch seconds from epoch millis: as seconds it
+// is the year 5138, as millis it is early 1973.
+const epochMillisThreshold = 100_000_000_000
+
+// epochTimestamp parses a queue timestamp attribute. SQS reports
+// CreatedTimestamp and LastModifiedTimestamp in epoch seconds, but some
+// SQS-compatible emulators return millis, so values past the threshold are
+// treated as millis.
+func epochTimestamp(s string) (time.Time, bool) {
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || value <= 0 {
+		return time.Time{}, false
+	}
+	if value >= epochMillisThreshold {
+		return time.UnixMilli(value), true
+	}
+	return time.Unix(value, 0), true
+}
@@ -3,15 +3,20 @@ package sqs
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
 	"github.com/cjunks94/go-sqs-ui/internal/types"
 	"github.com/cjunks94/go-sqs-ui/test/helpers"
 	"github.com/gorilla/mux"
@@ -613,7 +618,7 @@ func TestSQSHandler_GetMessagesWithPagination(t *testing.T) {
 			expectedCount:  5,
 		},
 		{
-			name:        "limit exceeding max (should cap at 10)",
+			name:        "limit exceeding max is rejected",
 			queryParams: "?limit=50",
 			setupMock: func(mock *helpers.MockSQSClient) {
 				for i := 1; i <= 20; i++ {
@@ -622,8 +627,7 @@ func TestSQSHandler_GetMessagesWithPagination(t *testing.T) {
 						fmt.Sprintf("Message body %d", i))
 				}
 			},
-			expectedStatus: http.StatusOK,
-			expectedCount:  10, // Should cap at max
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:        "invalid limit parameter",
@@ -691,6 +695,64 @@ func TestSQSHandler_GetMessagesWithPagination(t *testing.T) {
 }
 
 // Test new endpoint for queue statistics
+func TestSQSHandler_ListQueues_DemoTagFilter(t *testing.T) {
+	listNames := func(t *testing.T, client *demo.DemoSQSClient) []string {
+		t.Helper()
+		handler := &SQSHandler{Client: client, isDemo: true}
+		rr := httptest.NewRecorder()
+		handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+		var queues []types.Queue
+		if err := json.NewDecoder(rr.Body).Decode(&queues); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		names := []string{}
+		for _, q := range queues {
+			names = append(names, q.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t.Run("default filter hides non-matching demo queues", func(t *testing.T) {
+		got := strings.Join(listNames(t, demo.NewDemoSQSClient()), ",")
+		expected := "demo-audit.fifo,demo-deadletter-queue,demo-events.fifo,demo-orders-queue,demo-payments-queue"
+		if got != expected {
+			t.Errorf("expected queues %s, got %s", expected, got)
+		}
+	})
+
+	t.Run("retagged queue passes the filter", func(t *testing.T) {
+		client := demo.NewDemoSQSClient()
+		client.SetQueueTags("https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue", map[string]string{
+			"businessunit": "degrees", "product": "amt", "env": "prod",
+		})
+		got := strings.Join(listNames(t, client), ",")
+		expected := "demo-analytics-queue,demo-audit.fifo,demo-deadletter-queue,demo-events.fifo,demo-orders-queue,demo-payments-queue"
+		if got != expected {
+			t.Errorf("expected queues %s, got %s", expected, got)
+		}
+	})
+}
+
+// attributesClient layers extra queue attributes over the MockSQSClient's
+// fixed GetQueueAttributes response.
+type attributesClient struct {
+	*helpers.MockSQSClient
+	extra map[string]string
+}
+
+func (c *attributesClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	out, err := c.MockSQSClient.GetQueueAttributes(ctx, params, optFns...)
+	if err != nil {
+		return out, err
+	}
+	for k, v := range c.extra {
+		out.Attributes[k] = v
+	}
+	return out, nil
+}
+
 func TestSQSHandler_GetQueueStatistics(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -791,6 +853,139 @@ func TestSQSHandler_GetQueueStatistics(t *testing.T) {
 	}
 }
 
+func TestSQSHandler_GetQueueStatistics_Timestamps(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name             string
+		created          string
+		modified         string
+		expectedCreated  string
+		expectedModified string
+		expectedMillis   float64
+	}{
+		{
+			name:             "epoch seconds from SQS",
+			created:          "1700000000",
+			modified:         "1700003600",
+			expectedCreated:  "2023-11-14T22:13:20Z",
+			expectedModified: "2023-11-14T23:13:20Z",
+			expectedMillis:   1700000000000,
+		},
+		{
+			name:             "epoch millis from an emulator",
+			created:          "1700000000000",
+			modified:         "1700003600000",
+			expectedCreated:  "2023-11-14T22:13:20Z",
+			expectedModified: "2023-11-14T23:13:20Z",
+			expectedMillis:   1700000000000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &attributesClient{
+				MockSQSClient: helpers.NewMockSQSClient(),
+				extra: map[string]string{
+					"CreatedTimestamp":      tt.created,
+					"LastModifiedTimestamp": tt.modified,
+				},
+			}
+			handler := &SQSHandler{Client: client}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/statistics", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetQueueStatistics(rr, req)
+
+			var stats map[string]interface{}
+			if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if stats["createdTime"] != tt.expectedCreated {
+				t.Errorf("createdTime: expected %s, got %v", tt.expectedCreated, stats["createdTime"])
+			}
+			if stats["lastModifiedTime"] != tt.expectedModified {
+				t.Errorf("lastModifiedTime: expected %s, got %v", tt.expectedModified, stats["lastModifiedTime"])
+			}
+			if stats["createdTimestamp"] != tt.expectedMillis {
+				t.Errorf("createdTimestamp: expected %.0f, got %v", tt.expectedMillis, stats["createdTimestamp"])
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetQueueStatistics_FIFO(t *testing.T) {
+	tests := []struct {
+		name       string
+		client     SQSClientInterface
+		queueURL   string
+		expectFIFO bool
+	}{
+		{
+			name:       "demo FIFO queue",
+			client:     demo.NewDemoSQSClient(),
+			queueURL:   "https://sqs.us-east-1.amazonaws.com/123456789012/demo-events.fifo",
+			expectFIFO: true,
+		},
+		{
+			name: "FifoQueue attribute without suffix",
+			client: &attributesClient{
+				MockSQSClient: helpers.NewMockSQSClient(),
+				extra: map[string]string{
+					"FifoQueue":                 "true",
+					"ContentBasedDeduplication": "false",
+					"DeduplicationScope":        "messageGroup",
+					"FifoThroughputLimit":       "perMessageGroupId",
+				},
+			},
+			queueURL:   "http://localhost:4566/000000000000/emulated-orders",
+			expectFIFO: true,
+		},
+		{
+			name:     "standard queue",
+			client:   demo.NewDemoSQSClient(),
+			queueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: tt.client}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/statistics", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": tt.queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetQueueStatistics(rr, req)
+
+			var stats struct {
+				FIFO *struct {
+					ContentBasedDeduplication bool   `json:"contentBasedDeduplication"`
+					DeduplicationScope        string `json:"deduplicationScope"`
+					FifoThroughputLimit       string `json:"fifoThroughputLimit"`
+				} `json:"fifo"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+
+			if !tt.expectFIFO {
+				if stats.FIFO != nil {
+					t.Errorf("expected no fifo block for a standard queue, got %+v", stats.FIFO)
+				}
+				return
+			}
+			if stats.FIFO == nil {
+				t.Fatal("expected a fifo block")
+			}
+			if stats.FIFO.ContentBasedDeduplication || stats.FIFO.DeduplicationScope != "messageGroup" || stats.FIFO.FifoThroughputLimit != "perMessageGroupId" {
+				t.Errorf("unexpected fifo block %+v", stats.FIFO)
+			}
+		})
+	}
+}
+
 // Test enhanced message retrieval with offset for pagination
 func TestSQSHandler_GetMessagesWithOffset(t *testing.T) {
 	tests := []struct {
@@ -825,6 +1020,22 @@ func TestSQSHandler_GetMessagesWithOffset(t *testing.T) {
 			expectedStart:  26,
 			expectedEnd:    30,
 		},
+		{
+			name:           "single message left past offset",
+			queryParams:    "?limit=5&offset=29",
+			totalMessages:  30,
+			expectedStatus: http.StatusOK,
+			expectedStart:  30,
+			expectedEnd:    30,
+		},
+		{
+			name:           "offset at end of messages",
+			queryParams:    "?limit=10&offset=30",
+			totalMessages:  30,
+			expectedStatus: http.StatusOK,
+			expectedStart:  0,
+			expectedEnd:    0,
+		},
 		{
 			name:           "offset beyond available messages",
 			queryParams:    "?limit=10&offset=50",
@@ -896,6 +1107,7 @@ func TestSQSHandler_RetryMessage(t *testing.T) {
 		name                string
 		queueURL            string
 		requestBody         interface{}
+		retryTargetAllow    string
 		setupMock           func(*helpers.MockSQSClient)
 		expectedStatus      int
 		expectedSendCalls   int
@@ -932,6 +1144,28 @@ func TestSQSHandler_RetryMessage(t *testing.T) {
 			expectedSendQueue:   targetQueueURL,
 			expectedDeleteQueue: sourceQueueURL,
 		},
+		{
+			name:                "should retry when target matches RETRY_TARGET_ALLOW",
+			queueURL:            sourceQueueURL,
+			requestBody:         validPayload,
+			retryTargetAllow:    "demo-payments-*, demo-orders-*",
+			setupMock:           func(mock *helpers.MockSQSClient) {},
+			expectedStatus:      http.StatusOK,
+			expectedSendCalls:   1,
+			expectedDeleteCalls: 1,
+			expectedSendQueue:   targetQueueURL,
+			expectedDeleteQueue: sourceQueueURL,
+		},
+		{
+			name:                "should return 403 when target is not in RETRY_TARGET_ALLOW",
+			queueURL:            sourceQueueURL,
+			requestBody:         validPayload,
+			retryTargetAllow:    "demo-payments-*",
+			setupMock:           func(mock *helpers.MockSQSClient) {},
+			expectedStatus:      http.StatusForbidden,
+			expectedSendCalls:   0,
+			expectedDeleteCalls: 0,
+		},
 		{
 			name:                "should return 400 when payload is malformed JSON",
 			queueURL:            sourceQueueURL,
@@ -969,6 +1203,7 @@ func TestSQSHandler_RetryMessage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("RETRY_TARGET_ALLOW", tt.retryTargetAllow)
 			mockClient := helpers.NewMockSQSClient()
 			tt.setupMock(mockClient)
 
@@ -1212,12 +1447,22 @@ func TestNewSQSHandler_CustomEndpoint(t *testing.T) {
 	}
 }
 
+func TestNewSQSHandler_ConflictingForceFlags(t *testing.T) {
+	t.Setenv("FORCE_DEMO_MODE", "true")
+	t.Setenv("FORCE_LIVE_MODE", "true")
+
+	if _, err := NewSQSHandler(); err == nil {
+		t.Error("expected an error when both force flags are set")
+	}
+}
+
 func TestNormalizeQueueURL(t *testing.T) {
 	cases := map[string]string{
 		"https:/sqs.us-east-1.amazonaws.com/1/q": "https://sqs.us-east-1.amazonaws.com/1/q",
 		"http:/localhost:9324/000000000000/q":    "http://localhost:9324/000000000000/q",
 		"https://already.ok/q":                   "https://already.ok/q",
 		"http://already.ok/q":                    "http://already.ok/q",
+		"  https://padded.ok/q\n":                "https://padded.ok/q",
 	}
 	for in, want := range cases {
 		if got := normalizeQueueURL(in); got != want {
@@ -1225,3 +1470,280 @@ func TestNormalizeQueueURL(t *testing.T) {
 		}
 	}
 }
+
+// callRecordingClient records every SQS call and fails it.
+type callRecordingClient struct {
+	calls []string
+}
+
+func (c *callRecordingClient) record(op string) error {
+	c.calls = append(c.calls, op)
+	return fmt.Errorf("%s should not have been called", op)
+}
+
+func (c *callRecordingClient) ListQueues(ctx context.Context, params *awssqs.ListQueuesInput, optFns ...func(*awssqs.Options)) (*awssqs.ListQueuesOutput, error) {
+	return nil, c.record("ListQueues")
+}
+
+func (c *callRecordingClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	return nil, c.record("GetQueueAttributes")
+}
+
+func (c *callRecordingClient) ListQueueTags(ctx context.Context, params *awssqs.ListQueueTagsInput, optFns ...func(*awssqs.Options)) (*awssqs.ListQueueTagsOutput, error) {
+	return nil, c.record("ListQueueTags")
+}
+
+func (c *callRecordingClient) ReceiveMessage(ctx context.Context, params *awssqs.ReceiveMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.ReceiveMessageOutput, error) {
+	return nil, c.record("ReceiveMessage")
+}
+
+func (c *callRecordingClient) SendMessage(ctx context.Context, params *awssqs.SendMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.SendMessageOutput, error) {
+	return nil, c.record("SendMessage")
+}
+
+func (c *callRecordingClient) SendMessageBatch(ctx context.Context, params *awssqs.SendMessageBatchInput, optFns ...func(*awssqs.Options)) (*awssqs.SendMessageBatchOutput, error) {
+	return nil, c.record("SendMessageBatch")
+}
+
+func (c *callRecordingClient) DeleteMessage(ctx context.Context, params *awssqs.DeleteMessageInput, optFns ...func(*awssqs.Options)) (*awssqs.DeleteMessageOutput, error) {
+	return nil, c.record("DeleteMessage")
+}
+
+func (c *callRecordingClient) ChangeMessageVisibility(ctx context.Context, params *awssqs.ChangeMessageVisibilityInput, optFns ...func(*awssqs.Options)) (*awssqs.ChangeMessageVisibilityOutput, error) {
+	return nil, c.record("ChangeMessageVisibility")
+}
+
+func (c *callRecordingClient) ListDeadLetterSourceQueues(ctx context.Context, params *awssqs.ListDeadLetterSourceQueuesInput, optFns ...func(*awssqs.Options)) (*awssqs.ListDeadLetterSourceQueuesOutput, error) {
+	return nil, c.record("ListDeadLetterSourceQueues")
+}
+
+func (c *callRecordingClient) DeleteQueue(ctx context.Context, params *awssqs.DeleteQueueInput, optFns ...func(*awssqs.Options)) (*awssqs.DeleteQueueOutput, error) {
+	return nil, c.record("DeleteQueue")
+}
+
+func TestSQSHandler_BlankQueueURL(t *testing.T) {
+	client := &callRecordingClient{}
+	handler := &SQSHandler{Client: client, S3: helpers.NewMockS3Client()}
+
+	handlers := map[string]http.HandlerFunc{
+		"GetMessages":           handler.GetMessages,
+		"SendMessage":           handler.SendMessage,
+		"DeleteMessage":         handler.DeleteMessage,
+		"RetryMessage":          handler.RetryMessage,
+		"MoveMessages":          handler.MoveMessages,
+		"ConsumeMessages":       handler.ConsumeMessages,
+		"GetDeadLetterSources":  handler.GetDeadLetterSources,
+		"DeleteQueue":           handler.DeleteQueue,
+		"GetQueueStatistics":    handler.GetQueueStatistics,
+		"GetQueueThroughput":    handler.GetQueueThroughput,
+		"GetMessageBody":        handler.GetMessageBody,
+		"RefreshReceiptHandles": handler.RefreshReceiptHandles,
+		"SendTemplateMessages":  handler.SendTemplateMessages,
+		"ImportMessages":        handler.ImportMessages,
+		"ArchiveToS3":           handler.ArchiveToS3,
+		"CreateSnapshot":        handler.CreateSnapshot,
+		"GetSnapshotPage":       handler.GetSnapshotPage,
+		"CreateAlarm":           handler.CreateAlarm,
+		"ListAlarms":            handler.ListAlarms,
+	}
+
+	for _, queueURL := range []string{"", "   ", "\t\n"} {
+		for name, handle := range handlers {
+			t.Run(fmt.Sprintf("%s/%q", name, queueURL), func(t *testing.T) {
+				req := httptest.NewRequest("POST", "/api/queues/x", strings.NewReader(`{"body":"hello"}`))
+				req = mux.SetURLVars(req, map[string]string{
+					"queueUrl":      queueURL,
+					"receiptHandle": "handle",
+					"messageId":     "msg-1",
+					"snapshotId":    "snap",
+				})
+				rr := httptest.NewRecorder()
+				handle(rr, req)
+
+				if rr.Code != http.StatusBadRequest {
+					t.Errorf("expected 400, got %d", rr.Code)
+				}
+				if !strings.Contains(rr.Body.String(), "queueUrl required") {
+					t.Errorf("expected 'queueUrl required', got %q", rr.Body.String())
+				}
+			})
+		}
+	}
+
+	if len(client.calls) > 0 {
+		t.Errorf("expected no SQS calls, got %v", client.calls)
+	}
+}
+
+func TestSQSHandler_GetMessages_ReceiveAttemptID(t *testing.T) {
+	tests := []struct {
+		name      string
+		queueURL  string
+		expectSet bool
+	}{
+		{
+			name:      "passed through for FIFO queues",
+			queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo",
+			expectSet: true,
+		},
+		{
+			name:      "ignored for standard queues",
+			queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/orders",
+			expectSet: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddMessage(tt.queueURL, "msg-1", "body")
+			handler := &SQSHandler{Client: mockClient}
+
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, getMessagesReq(tt.queueURL, "?receiveAttemptId=attempt-42"))
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rr.Code)
+			}
+			if len(mockClient.ReceiveMessageCalls) != 1 {
+				t.Fatalf("expected 1 ReceiveMessage call, got %d", len(mockClient.ReceiveMessageCalls))
+			}
+
+			got := aws.ToString(mockClient.ReceiveMessageCalls[0].ReceiveRequestAttemptId)
+			if tt.expectSet && got != "attempt-42" {
+				t.Errorf("expected ReceiveRequestAttemptId attempt-42, got %q", got)
+			}
+			if !tt.expectSet && got != "" {
+				t.Errorf("expected no ReceiveRequestAttemptId for a standard queue, got %q", got)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_TraceHeader(t *testing.T) {
+	const ordersQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	const notificationsQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-notifications-queue"
+	const traceHeader = "Root=1-67891233-abcdef012345678912345678;Parent=463ac35c9f6413ad;Sampled=1"
+
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+	findMessage := func(t *testing.T, queueURL string, match func(types.Message) bool) *types.Message {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		handler.GetMessages(rr, getMessagesReq(queueURL, ""))
+		for _, msg := range decodeMessages(t, rr) {
+			if match(msg) {
+				return &msg
+			}
+		}
+		return nil
+	}
+
+	t.Run("surfaces the seeded trace header", func(t *testing.T) {
+		msg := findMessage(t, ordersQueue, func(m types.Message) bool { return m.MessageId == "ord-001" })
+		if msg == nil {
+			t.Fatal("ord-001 not returned")
+		}
+		if msg.TraceHeader == "" || msg.TraceHeader != msg.Attributes["AWSTraceHeader"] {
+			t.Errorf("expected traceHeader to mirror the AWSTraceHeader attribute, got %q", msg.TraceHeader)
+		}
+
+		other := findMessage(t, ordersQueue, func(m types.Message) bool { return m.MessageId == "ord-002" })
+		if other == nil || other.TraceHeader != "" {
+			t.Errorf("expected no traceHeader on a message without one")
+		}
+	})
+
+	t.Run("round-trips on send", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"body": "traced", "traceHeader": traceHeader})
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages", bytes.NewReader(body))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": notificationsQueue})
+		rr := httptest.NewRecorder()
+		handler.SendMessage(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+
+		msg := findMessage(t, notificationsQueue, func(m types.Message) bool { return m.Body == "traced" })
+		if msg == nil {
+			t.Fatal("sent message not returned")
+		}
+		if msg.TraceHeader != traceHeader {
+			t.Errorf("expected traceHeader %q, got %q", traceHeader, msg.TraceHeader)
+		}
+	})
+}
+
+func TestSQSHandler_SendMessage_FIFODeduplication(t *testing.T) {
+	const fifoURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders.fifo"
+
+	handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+	send := func() string {
+		t.Helper()
+		req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages",
+			strings.NewReader(`{"body": "order", "messageGroupId": "orders", "messageDeduplicationId": "order-42"}`))
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": fifoURL})
+		rr := httptest.NewRecorder()
+		handler.SendMessage(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+		var response map[string]string
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response["messageId"]
+	}
+
+	first, second := send(), send()
+	if first == "" || first != second {
+		t.Errorf("expected the duplicate send to return the original ID, got %q and %q", first, second)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.GetMessages(rr, getMessagesReq(fifoURL, ""))
+	if msgs := decodeMessages(t, rr); len(msgs) != 1 {
+		t.Errorf("expected 1 enqueued message, got %d", len(msgs))
+	}
+}
+
+func TestSQSHandler_GetMessages_IncludeMD5(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	for _, include := range []bool{false, true} {
+		t.Run(fmt.Sprintf("includeMd5=%v", include), func(t *testing.T) {
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+
+			path := "/api/queues/{queueUrl}/messages"
+			if include {
+				path += "?includeMd5=true"
+			}
+			req := httptest.NewRequest("GET", path, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+
+			var messages []types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode messages: %v", err)
+			}
+			if len(messages) == 0 {
+				t.Fatal("expected demo messages")
+			}
+
+			for _, msg := range messages {
+				if !include {
+					if msg.MD5OfBody != "" {
+						t.Errorf("expected no md5OfBody without includeMd5, got %s", msg.MD5OfBody)
+					}
+					continue
+				}
+				digest := md5.Sum([]byte(msg.Body))
+				if expected := hex.EncodeToString(digest[:]); msg.MD5OfBody != expected {
+					t.Errorf("message %s: expected md5OfBody %s, got %s", msg.MessageId, expected, msg.MD5OfBody)
+				}
+			}
+		})
+	}
+}
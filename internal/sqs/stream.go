@@ -0,0 +1,112 @@
+package sqs
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// defaultStreamFlushEvery is how many list elements are encoded between
+// flushes when STREAM_FLUSH_EVERY is not set.
+const defaultStreamFlushEvery = 100
+
+// streamFlushEvery returns the configured number of elements written between
+// flushes of a streamed JSON list.
+func streamFlushEvery() int {
+	if v := os.Getenv("STREAM_FLUSH_EVERY"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return defaultStreamFlushEvery
+}
+
+// jsonListWriter writes a JSON array to a response one element at a time,
+// flushing to the client every streamFlushEvery elements. Elements are
+// indented when the request asks for pretty output. Nothing reaches the client
+// before the first flush, so a handler can still answer with an error until
+// then.
+type jsonListWriter struct {
+	bw         *bufio.Writer
+	enc        *json.Encoder
+	flusher    http.Flusher
+	flushEvery int
+	count      int
+}
+
+// newJSONListWriter starts a JSON array on w.
+func newJSONListWriter(w http.ResponseWriter, r *http.Request) (*jsonListWriter, error) {
+	w.Header().Set("Content-Type", "application/json")
+
+	bw := bufio.NewWriter(w)
+	flusher, _ := w.(http.Flusher)
+	if _, err := bw.WriteString("["); err != nil {
+		return nil, err
+	}
+	return &jsonListWriter{
+		bw:         bw,
+		enc:        newJSONEncoder(bw, r),
+		flusher:    flusher,
+		flushEvery: streamFlushEvery(),
+	}, nil
+}
+
+// Add appends item to the array.
+func (l *jsonListWriter) Add(item any) error {
+	if l.count > 0 {
+		if _, err := l.bw.WriteString(","); err != nil {
+			return err
+		}
+	}
+	if err := l.enc.Encode(item); err != nil {
+		return err
+	}
+	l.count++
+	if l.count%l.flushEvery == 0 {
+		return l.Flush()
+	}
+	return nil
+}
+
+// Flush sends what has been written so far to the client.
+func (l *jsonListWriter) Flush() error {
+	if err := l.bw.Flush(); err != nil {
+		return err
+	}
+	if l.flusher != nil {
+		l.flusher.Flush()
+	}
+	return nil
+}
+
+// Close ends the array and flushes it.
+func (l *jsonListWriter) Close() error {
+	if _, err := l.bw.WriteString("]\n"); err != nil {
+		return err
+	}
+	return l.Flush()
+}
+
+// streamJSONList writes items as a JSON array one element at a time instead of
+// marshalling the whole slice up front, so encoding thousands of messages does
+// not build a second full copy of the response in memory. It stops at the
+// first write error or when the request context is cancelled; the response is
+// already committed by then, so callers should only log the returned error.
+func streamJSONList[T any](w http.ResponseWriter, r *http.Request, items []T) error {
+	list, err := newJSONListWriter(w, r)
+	if err != nil {
+		return err
+	}
+	ctx := r.Context()
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := list.Add(item); err != nil {
+			return err
+		}
+	}
+	return list.Close()
+}
@@ -0,0 +1,113 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+func generateMessages(n int) []types.Message {
+	messages := make([]types.Message, n)
+	for i := range messages {
+		messages[i] = types.Message{
+			MessageId:     fmt.Sprintf("msg-%d", i),
+			Body:          fmt.Sprintf(`{"orderId":"%d","status":"pending","items":[{"sku":"WIDGET-001","quantity":2}]}`, i),
+			ReceiptHandle: fmt.Sprintf("receipt-msg-%d", i),
+			Attributes: map[string]string{
+				"SentTimestamp":           fmt.Sprintf("%d", 1640995200000+i),
+				"ApproximateReceiveCount": "1",
+			},
+		}
+	}
+	return messages
+}
+
+func TestStreamJSONList(t *testing.T) {
+	t.Setenv("STREAM_FLUSH_EVERY", "3")
+
+	for _, n := range []int{0, 1, 7} {
+		t.Run(fmt.Sprintf("%d messages", n), func(t *testing.T) {
+			messages := generateMessages(n)
+			rr := httptest.NewRecorder()
+
+			if err := streamJSONList(rr, httptest.NewRequest("GET", "/api/queues", nil), messages); err != nil {
+				t.Fatalf("streamJSONList failed: %v", err)
+			}
+
+			if ct := rr.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected application/json, got %q", ct)
+			}
+
+			var decoded []types.Message
+			if err := json.Unmarshal(rr.Body.Bytes(), &decoded); err != nil {
+				t.Fatalf("streamed output is not valid JSON: %v\n%s", err, rr.Body.String())
+			}
+			if len(decoded) != n {
+				t.Fatalf("expected %d messages, got %d", n, len(decoded))
+			}
+			for i := range decoded {
+				if decoded[i].MessageId != messages[i].MessageId {
+					t.Errorf("element %d: expected %s, got %s", i, messages[i].MessageId, decoded[i].MessageId)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamJSONList_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/queues", nil).WithContext(ctx)
+	if err := streamJSONList(rr, req, generateMessages(5)); err == nil {
+		t.Error("expected an error when the context is already cancelled")
+	}
+}
+
+// discardResponseWriter is an http.ResponseWriter that throws the body away so
+// benchmarks measure the encoder rather than a growing recorder buffer.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (d *discardResponseWriter) Header() http.Header         { return d.header }
+func (d *discardResponseWriter) Write(p []byte) (int, error) { return io.Discard.Write(p) }
+func (d *discardResponseWriter) WriteHeader(int)             {}
+
+func BenchmarkEncodeMessages_Buffered(b *testing.B) {
+	messages := generateMessages(10000)
+	w := &discardResponseWriter{header: http.Header{}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := json.Marshal(messages)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := w.Write(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeMessages_Streaming(b *testing.B) {
+	messages := generateMessages(10000)
+	w := &discardResponseWriter{header: http.Header{}}
+	req := httptest.NewRequest("GET", "/api/queues", nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := streamJSONList(w, req, messages); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// extractSummary returns the value at a dot-separated path (e.g.
+// "metadata.device") in a JSON object body, or "" when the body is not JSON or
+// the path does not resolve. Scalars are rendered as-is and objects or arrays
+// as compact JSON.
+func extractSummary(body, path string) string {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+
+	var current interface{}
+	if err := decoder.Decode(&current); err != nil {
+		return ""
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		if current, ok = object[key]; !ok {
+			return ""
+		}
+	}
+
+	switch value := current.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number, bool:
+		return fmt.Sprint(value)
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
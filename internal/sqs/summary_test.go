@@ -0,0 +1,97 @@
+package sqs
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+)
+
+func TestExtractSummary(t *testing.T) {
+	body := `{"orderId": "12345", "amount": 99.99, "paid": true, "metadata": {"device": "mobile", "tags": ["a", "b"]}}`
+
+	tests := []struct {
+		name     string
+		body     string
+		path     string
+		expected string
+	}{
+		{"top-level string", body, "orderId", "12345"},
+		{"number keeps its text", body, "amount", "99.99"},
+		{"boolean", body, "paid", "true"},
+		{"nested path", body, "metadata.device", "mobile"},
+		{"array rendered as JSON", body, "metadata.tags", `["a","b"]`},
+		{"missing key", body, "customerId", ""},
+		{"path through a scalar", body, "orderId.value", ""},
+		{"non-JSON body", "plain text", "orderId", ""},
+		{"JSON array body", `[{"orderId": "1"}]`, "orderId", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractSummary(tt.body, tt.path); got != tt.expected {
+				t.Errorf("extractSummary(%q) = %q, want %q", tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessages_SummaryField(t *testing.T) {
+	tests := []struct {
+		name      string
+		queueURL  string
+		query     string
+		messageID string
+		expected  string
+	}{
+		{
+			name:      "top-level field from an order",
+			queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue",
+			query:     "?summaryField=orderId",
+			messageID: "ord-001",
+			expected:  "12345",
+		},
+		{
+			name:      "nested field from an analytics event",
+			queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue",
+			query:     "?summaryField=metadata.device",
+			messageID: "ana-001",
+			expected:  "mobile",
+		},
+		{
+			name:      "nested field absent from another event",
+			queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/demo-analytics-queue",
+			query:     "?summaryField=metadata.device",
+			messageID: "ana-002",
+			expected:  "",
+		},
+		{
+			name:      "no summary without the parameter",
+			queueURL:  "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue",
+			messageID: "ord-001",
+			expected:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+			rr := httptest.NewRecorder()
+			handler.GetMessages(rr, getMessagesReq(tt.queueURL, tt.query))
+
+			found := false
+			for _, msg := range decodeMessages(t, rr) {
+				if msg.MessageId != tt.messageID {
+					continue
+				}
+				found = true
+				if msg.Summary != tt.expected {
+					t.Errorf("expected summary %q, got %q", tt.expected, msg.Summary)
+				}
+			}
+			if !found {
+				t.Fatalf("message %s not returned", tt.messageID)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package sqs
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// tagFilterKeys are the tags that can be overridden per request.
+var tagFilterKeys = []string{"businessunit", "product", "env"}
+
+// maxTagFilterValues caps how many values one override may list.
+const maxTagFilterValues = 20
+
+// tagValuePattern matches the characters AWS allows in tag values.
+var tagValuePattern = regexp.MustCompile(`^[\p{L}\p{Z}\p{N}_.:/=+\-@]{1,256}$`)
+
+// tagFilterForRequest applies per-request overrides on top of the env-driven
+// tag filter:
+//   - tagFilter=disabled skips filtering; tagFilter=enabled forces it on
+//   - businessunit=, product= and env= replace the required values for that tag
+//     (comma-separated) and enable filtering
+//
+// Without overrides the result matches tagFilterFromEnv. When filtering is
+// disabled server-side, only the overridden tags are required.
+func tagFilterForRequest(query url.Values) (bool, map[string][]string, error) {
+	disabled, requiredTags := tagFilterFromEnv()
+
+	switch mode := query.Get("tagFilter"); mode {
+	case "":
+	case "disabled":
+		return true, map[string][]string{}, nil
+	case "enabled":
+		if disabled {
+			requiredTags = requiredTagsFromEnv()
+			disabled = false
+		}
+	default:
+		return false, nil, fmt.Errorf("invalid tagFilter %q: expected enabled or disabled", mode)
+	}
+
+	overridden := map[string][]string{}
+	for _, key := range tagFilterKeys {
+		raw, present := query[key]
+		if !present {
+			continue
+		}
+		values, err := parseTagFilterValues(key, strings.Join(raw, ","))
+		if err != nil {
+			return false, nil, err
+		}
+		overridden[key] = values
+	}
+
+	if len(overridden) == 0 {
+		return disabled, requiredTags, nil
+	}
+
+	if disabled {
+		requiredTags = map[string][]string{}
+	}
+	for key, values := range overridden {
+		requiredTags[key] = values
+	}
+	return false, requiredTags, nil
+}
+
+// parseTagFilterValues splits and validates a comma-separated override.
+func parseTagFilterValues(key, raw string) ([]string, error) {
+	values := []string{}
+	for _, value := range strings.Split(raw, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if !tagValuePattern.MatchString(value) {
+			return nil, fmt.Errorf("invalid value %q for tag filter %s", value, key)
+		}
+		values = append(values, value)
+	}
+
+	if len(values) == 0 {
+		return nil, fmt.Errorf("tag filter %s needs at least one value", key)
+	}
+	if len(values) > maxTagFilterValues {
+		return nil, fmt.Errorf("tag filter %s accepts at most %d values", key, maxTagFilterValues)
+	}
+	return values, nil
+}
+
+// includeQueuesWithoutTags reports whether TAG_FETCH_FAILURE_MODE=include:
+// a queue whose tags cannot be fetched is still listed, flagged
+// tagsUnavailable, instead of being skipped (the default, "skip").
+func includeQueuesWithoutTags() bool {
+	return os.Getenv("TAG_FETCH_FAILURE_MODE") == "include"
+}
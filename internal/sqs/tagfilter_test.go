@@ -0,0 +1,172 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+)
+
+func TestSQSHandler_ListQueues_TagFilterOverrides(t *testing.T) {
+	tests := []struct {
+		name           string
+		disableEnv     string
+		query          string
+		expectedStatus int
+		expected       string
+	}{
+		{
+			name:           "server default env=stg",
+			expectedStatus: http.StatusOK,
+			expected:       "demo-audit.fifo,demo-deadletter-queue,demo-events.fifo,demo-orders-queue",
+		},
+		{
+			name:           "env=prod override",
+			query:          "?env=prod",
+			expectedStatus: http.StatusOK,
+			expected:       "demo-payments-queue",
+		},
+		{
+			name:           "multiple values",
+			query:          "?env=prod,dev",
+			expectedStatus: http.StatusOK,
+			expected:       "demo-notifications-queue,demo-payments-queue",
+		},
+		{
+			name:           "product override keeps the server env",
+			query:          "?product=insights",
+			expectedStatus: http.StatusOK,
+			expected:       "demo-analytics-queue",
+		},
+		{
+			name:           "tagFilter=disabled",
+			query:          "?tagFilter=disabled",
+			expectedStatus: http.StatusOK,
+			expected:       "demo-analytics-queue,demo-audit.fifo,demo-deadletter-queue,demo-events.fifo,demo-notifications-queue,demo-orders-queue,demo-payments-queue",
+		},
+		{
+			name:           "override filters when the server disables filtering",
+			disableEnv:     "true",
+			query:          "?env=dev",
+			expectedStatus: http.StatusOK,
+			expected:       "demo-notifications-queue",
+		},
+		{
+			name:           "invalid tagFilter mode",
+			query:          "?tagFilter=sometimes",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid tag value",
+			query:          "?env=" + url.QueryEscape("prod;drop"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "empty override",
+			query:          "?env=,",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DISABLE_TAG_FILTER", tt.disableEnv)
+			t.Setenv("FILTER_ENV", "stg")
+
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+			rr := httptest.NewRecorder()
+			handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues"+tt.query, nil))
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var queues []types.Queue
+			if err := json.NewDecoder(rr.Body).Decode(&queues); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			names := []string{}
+			for _, q := range queues {
+				names = append(names, q.Name)
+			}
+			sort.Strings(names)
+
+			if got := strings.Join(names, ","); got != tt.expected {
+				t.Errorf("expected queues %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+// flakyTagsClient fails ListQueueTags for one queue.
+type flakyTagsClient struct {
+	*helpers.MockSQSClient
+	failingURL string
+}
+
+func (c *flakyTagsClient) ListQueueTags(ctx context.Context, params *awssqs.ListQueueTagsInput, optFns ...func(*awssqs.Options)) (*awssqs.ListQueueTagsOutput, error) {
+	if aws.ToString(params.QueueUrl) == c.failingURL {
+		return nil, errors.New("throttled")
+	}
+	return c.MockSQSClient.ListQueueTags(ctx, params, optFns...)
+}
+
+func TestSQSHandler_ListQueues_TagFetchFailureMode(t *testing.T) {
+	const healthyURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	const failingURL = "https://sqs.us-east-1.amazonaws.com/123456789012/payments"
+
+	tests := []struct {
+		name          string
+		mode          string
+		expectedNames string
+	}{
+		{name: "default skips the queue", expectedNames: "orders"},
+		{name: "skip mode", mode: "skip", expectedNames: "orders"},
+		{name: "include mode flags the queue", mode: "include", expectedNames: "orders,payments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DISABLE_TAG_FILTER", "")
+			t.Setenv("TAG_FETCH_FAILURE_MODE", tt.mode)
+
+			mock := helpers.NewMockSQSClient()
+			mock.AddQueue(healthyURL)
+			mock.AddQueue(failingURL)
+			handler := &SQSHandler{Client: &flakyTagsClient{MockSQSClient: mock, failingURL: failingURL}}
+
+			rr := httptest.NewRecorder()
+			handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+			var queues []types.Queue
+			if err := json.NewDecoder(rr.Body).Decode(&queues); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			var names []string
+			for _, queue := range queues {
+				names = append(names, queue.Name)
+				if expected := queue.URL == failingURL; queue.TagsUnavailable != expected {
+					t.Errorf("%s: expected tagsUnavailable %v, got %v", queue.Name, expected, queue.TagsUnavailable)
+				}
+			}
+			if got := strings.Join(names, ","); got != tt.expectedNames {
+				t.Errorf("expected %s, got %s", tt.expectedNames, got)
+			}
+		})
+	}
+}
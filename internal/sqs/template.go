@@ -0,0 +1,146 @@
+package sqs
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"text/template"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// maxTemplateCount caps how many messages one template request may send.
+const maxTemplateCount = 100
+
+// templateData is what a body template is rendered with: {{.Index}} is the
+// zero-based message index and {{.Vars.name}} a request variable.
+type templateData struct {
+	Index int
+	Vars  map[string]interface{}
+}
+
+// templateFailure reports a rendered message that was not sent and why.
+type templateFailure struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// SendTemplateMessages handles HTTP requests to send count messages whose
+// bodies are rendered from a Go text/template, for generating sequenced test
+// messages. All bodies are rendered before anything is sent, so a template
+// error sends nothing.
+func (h *SQSHandler) SendTemplateMessages(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	var payload struct {
+		Template       string                 `json:"template"`
+		Count          int                    `json:"count"`
+		Variables      map[string]interface{} `json:"variables"`
+		MessageGroupID string                 `json:"messageGroupId"`
+		// TraceHeader is sent as every message's AWSTraceHeader
+		TraceHeader string `json:"traceHeader"`
+		// MaxDepth refuses the whole request with 409 when the queue already
+		// holds at least this many visible messages
+		MaxDepth *int `json:"maxDepth"`
+	}
+
+	if !decodeLimitedJSON(w, r, &payload) {
+		return
+	}
+	if payload.Template == "" {
+		http.Error(w, "template is required", http.StatusBadRequest)
+		return
+	}
+	if payload.Count <= 0 || payload.Count > maxTemplateCount {
+		http.Error(w, fmt.Sprintf("count must be between 1 and %d", maxTemplateCount), http.StatusBadRequest)
+		return
+	}
+
+	tmpl, err := template.New("body").Option("missingkey=error").Parse(payload.Template)
+	if err != nil {
+		http.Error(w, "invalid template: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entries := make([]types.SendMessageBatchRequestEntry, 0, payload.Count)
+	for i := 0; i < payload.Count; i++ {
+		var body bytes.Buffer
+		if err := tmpl.Execute(&body, templateData{Index: i, Vars: payload.Variables}); err != nil {
+			http.Error(w, fmt.Sprintf("rendering message %d: %v", i, err), http.StatusBadRequest)
+			return
+		}
+		if body.Len() == 0 {
+			http.Error(w, fmt.Sprintf("message %d rendered an empty body", i), http.StatusBadRequest)
+			return
+		}
+		attributes := withDefaultAttributeValues(nil)
+		if size := sqsMessageSize(body.String(), attributes); size.exceedsLimit() {
+			writeMessageTooLarge(w, r, size, map[string]interface{}{"index": i})
+			return
+		}
+
+		entry := types.SendMessageBatchRequestEntry{
+			Id:                      aws.String(strconv.Itoa(i)),
+			MessageBody:             aws.String(body.String()),
+			MessageAttributes:       attributes,
+			MessageSystemAttributes: traceHeaderAttributes(payload.TraceHeader),
+		}
+		if isFIFOQueue(queueURL) && payload.MessageGroupID != "" {
+			entry.MessageGroupId = aws.String(payload.MessageGroupID)
+		}
+		entries = append(entries, entry)
+	}
+
+	// Depth is checked once for the whole request, after rendering succeeded
+	if !h.checkMaxDepth(w, r, queueURL, payload.MaxDepth) {
+		return
+	}
+
+	messageIDs := []string{}
+	failed := []templateFailure{}
+	for _, batch := range sendBatches(entries, func(e types.SendMessageBatchRequestEntry) types.SendMessageBatchRequestEntry { return e }) {
+		result, err := h.Client.SendMessageBatch(r.Context(), &sqs.SendMessageBatchInput{
+			QueueUrl: aws.String(queueURL),
+			Entries:  batch,
+		})
+		if err != nil {
+			log.Printf("SendTemplateMessages: Error sending batch to queue %s: %v", queueURL, err)
+			for _, entry := range batch {
+				index, _ := strconv.Atoi(aws.ToString(entry.Id))
+				failed = append(failed, templateFailure{Index: index, Error: err.Error()})
+			}
+			continue
+		}
+		noteRequestID(r.Context(), result.ResultMetadata)
+
+		for _, s := range result.Successful {
+			messageIDs = append(messageIDs, aws.ToString(s.MessageId))
+		}
+		for _, f := range result.Failed {
+			index, _ := strconv.Atoi(aws.ToString(f.Id))
+			failed = append(failed, templateFailure{
+				Index: index,
+				Error: fmt.Sprintf("%s: %s", aws.ToString(f.Code), aws.ToString(f.Message)),
+			})
+		}
+	}
+
+	log.Printf("SendTemplateMessages: Sent %d of %d templated messages to queue %s", len(messageIDs), len(entries), queueURL)
+
+	writeOperationResult(w, r, internal_types.OperationResult{
+		Status:        statusSent,
+		AffectedCount: affected(len(messageIDs)),
+		Details: map[string]interface{}{
+			"messageIds": messageIDs,
+			"failed":     failed,
+		},
+	})
+}
@@ -0,0 +1,127 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func templateReq(queueURL, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/api/queues/{queueUrl}/messages/template", strings.NewReader(body))
+	return mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+}
+
+func TestSQSHandler_SendTemplateMessages_Demo(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/load-test"
+
+	client := demo.NewDemoSQSClient()
+	handler := &SQSHandler{Client: client, isDemo: true}
+
+	rr := httptest.NewRecorder()
+	handler.SendTemplateMessages(rr, templateReq(queueURL,
+		`{"template":"{\"seq\":{{.Index}},\"env\":\"{{.Vars.env}}\"}","count":12,"variables":{"env":"stg"}}`))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response struct {
+		Status        string `json:"status"`
+		AffectedCount int    `json:"affectedCount"`
+		Details       struct {
+			MessageIDs []string          `json:"messageIds"`
+			Failed     []templateFailure `json:"failed"`
+		} `json:"details"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Details.MessageIDs) != 12 || len(response.Details.Failed) != 0 {
+		t.Fatalf("expected 12 sent and none failed, got %d sent, %v", len(response.Details.MessageIDs), response.Details.Failed)
+	}
+
+	result, _ := client.ReceiveMessage(context.Background(), &awssqs.ReceiveMessageInput{
+		QueueUrl: aws.String(queueURL), MaxNumberOfMessages: 100,
+	})
+	bodies := map[string]bool{}
+	for _, msg := range result.Messages {
+		bodies[aws.ToString(msg.Body)] = true
+	}
+	if len(bodies) != 12 {
+		t.Fatalf("expected 12 distinct bodies, got %d", len(bodies))
+	}
+	for _, expected := range []string{`{"seq":0,"env":"stg"}`, `{"seq":11,"env":"stg"}`} {
+		if !bodies[expected] {
+			t.Errorf("expected body %s to be enqueued", expected)
+		}
+	}
+}
+
+func TestSQSHandler_SendTemplateMessages_Invalid(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/load-test"
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid json", `{`},
+		{"missing template", `{"count":1}`},
+		{"count too large", `{"template":"x","count":101}`},
+		{"zero count", `{"template":"x"}`},
+		{"parse error", `{"template":"{{.Index","count":1}`},
+		{"missing variable", `{"template":"{{.Vars.missing}}","count":1,"variables":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			handler := &SQSHandler{Client: mockClient}
+
+			rr := httptest.NewRecorder()
+			handler.SendTemplateMessages(rr, templateReq(queueURL, tt.body))
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if len(mockClient.SendMessageBatchCalls) != 0 {
+				t.Errorf("expected nothing sent, got %d batches", len(mockClient.SendMessageBatchCalls))
+			}
+		})
+	}
+}
+
+func TestSQSHandler_SendTemplateMessages_BatchError(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/load-test"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.SetError("SendMessageBatch", errors.New("AccessDenied"))
+	handler := &SQSHandler{Client: mockClient}
+
+	rr := httptest.NewRecorder()
+	handler.SendTemplateMessages(rr, templateReq(queueURL, `{"template":"m{{.Index}}","count":3}`))
+
+	var response struct {
+		Status        string `json:"status"`
+		AffectedCount int    `json:"affectedCount"`
+		Details       struct {
+			MessageIDs []string          `json:"messageIds"`
+			Failed     []templateFailure `json:"failed"`
+		} `json:"details"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(response.Details.MessageIDs) != 0 || len(response.Details.Failed) != 3 || response.Details.Failed[2].Index != 2 {
+		t.Errorf("expected all 3 reported failed, got %+v", response)
+	}
+}
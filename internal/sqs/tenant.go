@@ -0,0 +1,103 @@
+package sqs
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// Request headers carrying a tenant's AWS credentials in MULTI_TENANT mode.
+const (
+	accessKeyIDHeader     = "X-AWS-Access-Key-Id"
+	secretAccessKeyHeader = "X-AWS-Secret-Access-Key"
+	sessionTokenHeader    = "X-AWS-Session-Token"
+)
+
+// tenantClientFactory builds an SQS client for one tenant's credentials.
+type tenantClientFactory func(creds aws.Credentials) SQSClientInterface
+
+// MultiTenantMessage is the error returned for routes refused in
+// MULTI_TENANT mode.
+const MultiTenantMessage = "not available in multi-tenant mode"
+
+// MultiTenantEnabled reports whether MULTI_TENANT is set, in which case
+// ListQueues and GetMessages act with the credentials each request carries
+// instead of the server's. Every other route that reaches SQS or keeps
+// server-side state is refused, so no tenant acts with the server's
+// credentials or sees another tenant's data.
+func MultiTenantEnabled() bool {
+	return os.Getenv("MULTI_TENANT") == "true"
+}
+
+// newMultiTenantHandler builds the handler for MULTI_TENANT mode. It never
+// falls back to demo mode: the server's own config only supplies the region
+// and endpoint settings that tenant clients share.
+func newMultiTenantHandler() (*SQSHandler, error) {
+	cfg, err := loadAWSConfig(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Region == "" {
+		cfg.Region = resolveRegion()
+	}
+
+	log.Printf("Using multi-tenant mode (MULTI_TENANT=true)")
+	return &SQSHandler{
+		Client: sqs.NewFromConfig(cfg, withCustomEndpoint),
+		config: cfg,
+		isDemo: false,
+	}, nil
+}
+
+// withCustomEndpoint points a client at SQS_ENDPOINT_URL when it is set.
+func withCustomEndpoint(o *sqs.Options) {
+	if endpoint := os.Getenv("SQS_ENDPOINT_URL"); endpoint != "" {
+		o.BaseEndpoint = aws.String(endpoint)
+	}
+}
+
+// newTenantClient builds an SQS client that signs with static tenant
+// credentials, sharing the server config otherwise.
+func (h *SQSHandler) newTenantClient(creds aws.Credentials) SQSClientInterface {
+	cfg := h.config.Copy()
+	cfg.Credentials = credentials.StaticCredentialsProvider{Value: creds}
+	if cfg.Region == "" {
+		cfg.Region = resolveRegion()
+	}
+	return sqs.NewFromConfig(cfg, withCustomEndpoint)
+}
+
+// forRequest returns the handler that serves r. Outside MULTI_TENANT mode
+// that is h itself. Otherwise it is a request-scoped handler whose client
+// uses the credentials in the X-AWS-* headers, without the shared attribute
+// cache so tenants never see each other's data; requests without an access
+// key and secret are answered with 401 and ok is false. The credentials are
+// never logged.
+func (h *SQSHandler) forRequest(w http.ResponseWriter, r *http.Request) (handler *SQSHandler, ok bool) {
+	if !MultiTenantEnabled() {
+		return h, true
+	}
+
+	creds := aws.Credentials{
+		AccessKeyID:     strings.TrimSpace(r.Header.Get(accessKeyIDHeader)),
+		SecretAccessKey: strings.TrimSpace(r.Header.Get(secretAccessKeyHeader)),
+		SessionToken:    strings.TrimSpace(r.Header.Get(sessionTokenHeader)),
+		Source:          "request headers",
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		http.Error(w, "AWS credentials required in "+accessKeyIDHeader+" and "+secretAccessKeyHeader, http.StatusUnauthorized)
+		return nil, false
+	}
+
+	newClient := h.tenantClients
+	if newClient == nil {
+		newClient = h.newTenantClient
+	}
+	return &SQSHandler{Client: newClient(creds), config: h.config}, true
+}
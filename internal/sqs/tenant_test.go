@@ -0,0 +1,181 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+// tenantRequest returns a request carrying the given tenant credentials.
+func tenantRequest(path, accessKey, secret string) *http.Request {
+	req := httptest.NewRequest("GET", path, nil)
+	if accessKey != "" {
+		req.Header.Set(accessKeyIDHeader, accessKey)
+	}
+	if secret != "" {
+		req.Header.Set(secretAccessKeyHeader, secret)
+	}
+	req.Header.Set(sessionTokenHeader, "token-"+accessKey)
+	return req
+}
+
+func TestSQSHandler_MultiTenant_ListQueues(t *testing.T) {
+	t.Setenv("MULTI_TENANT", "true")
+	t.Setenv("DISABLE_TAG_FILTER", "true")
+
+	serverClient := helpers.NewMockSQSClient()
+	serverClient.AddQueue("https://sqs.us-east-1.amazonaws.com/000000000000/server-queue")
+
+	var built []aws.Credentials
+	handler := &SQSHandler{Client: serverClient}
+	handler.tenantClients = func(creds aws.Credentials) SQSClientInterface {
+		built = append(built, creds)
+		client := helpers.NewMockSQSClient()
+		client.AddQueue("https://sqs.us-east-1.amazonaws.com/111111111111/" + creds.AccessKeyID + "-queue")
+		return client
+	}
+
+	for _, accessKey := range []string{"AKIATENANTA", "AKIATENANTB"} {
+		rr := httptest.NewRecorder()
+		handler.ListQueues(rr, tenantRequest("/api/queues", accessKey, "secret-"+accessKey))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+
+		var queues []types.Queue
+		if err := json.NewDecoder(rr.Body).Decode(&queues); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(queues) != 1 || queues[0].Name != accessKey+"-queue" {
+			t.Errorf("expected only the %s tenant queue, got %+v", accessKey, queues)
+		}
+	}
+
+	if len(built) != 2 {
+		t.Fatalf("expected a client per request, got %d", len(built))
+	}
+	if built[1].AccessKeyID != "AKIATENANTB" || built[1].SecretAccessKey != "secret-AKIATENANTB" || built[1].SessionToken != "token-AKIATENANTB" {
+		t.Errorf("expected the request credentials, got %+v", built[1])
+	}
+}
+
+func TestSQSHandler_MultiTenant_MissingCredentials(t *testing.T) {
+	t.Setenv("MULTI_TENANT", "true")
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/111111111111/orders"
+
+	tests := []struct {
+		name      string
+		accessKey string
+		secret    string
+	}{
+		{name: "no credentials"},
+		{name: "no secret", accessKey: "AKIATENANTA"},
+		{name: "no access key", secret: "secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			built := 0
+			handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+			handler.tenantClients = func(creds aws.Credentials) SQSClientInterface {
+				built++
+				return helpers.NewMockSQSClient()
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ListQueues(rr, tenantRequest("/api/queues", tt.accessKey, tt.secret))
+			if rr.Code != http.StatusUnauthorized {
+				t.Errorf("ListQueues: expected status 401, got %d", rr.Code)
+			}
+
+			req := mux.SetURLVars(tenantRequest("/api/queues/{queueUrl}/messages", tt.accessKey, tt.secret), map[string]string{"queueUrl": queueURL})
+			rr = httptest.NewRecorder()
+			handler.GetMessages(rr, req)
+			if rr.Code != http.StatusUnauthorized {
+				t.Errorf("GetMessages: expected status 401, got %d", rr.Code)
+			}
+
+			if built != 0 {
+				t.Errorf("expected no tenant client, got %d", built)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_MultiTenant_GetMessages(t *testing.T) {
+	t.Setenv("MULTI_TENANT", "true")
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/111111111111/orders"
+
+	tenantClient := helpers.NewMockSQSClient()
+	tenantClient.AddQueue(queueURL)
+	tenantClient.AddMessage(queueURL, "tenant-msg", "hello")
+
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+	handler.tenantClients = func(creds aws.Credentials) SQSClientInterface {
+		return tenantClient
+	}
+
+	req := mux.SetURLVars(tenantRequest("/api/queues/{queueUrl}/messages", "AKIATENANTA", "secret"), map[string]string{"queueUrl": queueURL})
+	rr := httptest.NewRecorder()
+	handler.GetMessages(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var messages []types.Message
+	if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(messages) != 1 || messages[0].MessageId != "tenant-msg" {
+		t.Errorf("expected the tenant message, got %+v", messages)
+	}
+}
+
+func TestSQSHandler_MultiTenant_DisabledIgnoresHeaders(t *testing.T) {
+	t.Setenv("MULTI_TENANT", "")
+	t.Setenv("DISABLE_TAG_FILTER", "true")
+
+	serverClient := helpers.NewMockSQSClient()
+	serverClient.AddQueue("https://sqs.us-east-1.amazonaws.com/000000000000/server-queue")
+	handler := &SQSHandler{Client: serverClient}
+	handler.tenantClients = func(creds aws.Credentials) SQSClientInterface {
+		t.Error("expected no tenant client outside multi-tenant mode")
+		return serverClient
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ListQueues(rr, tenantRequest("/api/queues", "AKIATENANTA", "secret"))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+}
+
+func TestNewSQSHandler_MultiTenant(t *testing.T) {
+	t.Setenv("MULTI_TENANT", "true")
+	t.Setenv("FORCE_LIVE_MODE", "")
+	t.Setenv("SQS_ENDPOINT_URL", "")
+
+	t.Run("never demo", func(t *testing.T) {
+		t.Setenv("FORCE_DEMO_MODE", "")
+		handler, err := NewSQSHandler()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if handler.isDemo {
+			t.Error("multi-tenant mode must not fall back to demo mode")
+		}
+	})
+
+	t.Run("conflicts with forced demo mode", func(t *testing.T) {
+		t.Setenv("FORCE_DEMO_MODE", "true")
+		if _, err := NewSQSHandler(); err == nil {
+			t.Error("expected an error with FORCE_DEMO_MODE")
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package sqs
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+const (
+	// defaultThroughputInterval separates the two attribute snapshots.
+	defaultThroughputInterval = 2 * time.Second
+	// maxThroughputInterval caps ?intervalMs= so a request can't hang around.
+	maxThroughputInterval = 30 * time.Second
+)
+
+// queueDepthSample is one snapshot of a queue's approximate counts.
+type queueDepthSample struct {
+	Visible  int `json:"visible"`
+	InFlight int `json:"inFlight"`
+}
+
+// ThroughputEstimate is a rough rate estimate derived from two snapshots.
+type ThroughputEstimate struct {
+	IntervalMs          int64            `json:"intervalMs"`
+	First               queueDepthSample `json:"first"`
+	Second              queueDepthSample `json:"second"`
+	InPerSecond         float64          `json:"inPerSecond"`
+	OutPerSecond        float64          `json:"outPerSecond"`
+	NetBacklogPerSecond float64          `json:"netBacklogPerSecond"`
+	Note                string           `json:"note"`
+}
+
+const throughputNote = "Rough estimate from two approximate attribute samples; SQS counts are eventually consistent and short intervals are noisy."
+
+// GetQueueThroughput handles HTTP requests to estimate a queue's enqueue and
+// dequeue rates. It samples ApproximateNumberOfMessages (visible) and
+// ApproximateNumberOfMessagesNotVisible (in flight) twice, ?intervalMs= apart
+// (default 2s), and derives:
+//   - in:  growth of visible + in-flight, i.e. arrivals not yet deleted
+//   - out: shrinkage of the visible backlog, i.e. messages received or deleted
+//
+// Both are clamped at zero, so concurrent arrivals and consumption partly
+// cancel out. Treat the result as a rough estimate.
+func (h *SQSHandler) GetQueueThroughput(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	interval := defaultThroughputInterval
+	if intervalParam := r.URL.Query().Get("intervalMs"); intervalParam != "" {
+		ms, err := strconv.Atoi(intervalParam)
+		if err != nil || ms <= 0 {
+			http.Error(w, "intervalMs must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		interval = time.Duration(ms) * time.Millisecond
+		if interval > maxThroughputInterval {
+			interval = maxThroughputInterval
+		}
+	}
+
+	ctx := r.Context()
+
+	first, err := h.sampleQueueDepth(ctx, queueURL)
+	if err != nil {
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+	start := time.Now()
+
+	select {
+	case <-ctx.Done():
+		log.Printf("GetQueueThroughput: Request cancelled while sampling %s", queueURL)
+		return
+	case <-time.After(interval):
+	}
+
+	second, err := h.sampleQueueDepth(ctx, queueURL)
+	if err != nil {
+		writeSQSError(w, r, err, queueURL)
+		return
+	}
+	elapsed := time.Since(start).Seconds()
+
+	deltaVisible := second.Visible - first.Visible
+	deltaTotal := (second.Visible + second.InFlight) - (first.Visible + first.InFlight)
+
+	estimate := ThroughputEstimate{
+		IntervalMs:          interval.Milliseconds(),
+		First:               first,
+		Second:              second,
+		InPerSecond:         float64(max(deltaTotal, 0)) / elapsed,
+		OutPerSecond:        float64(max(-deltaVisible, 0)) / elapsed,
+		NetBacklogPerSecond: float64(deltaVisible) / elapsed,
+		Note:                throughputNote,
+	}
+
+	writeJSON(w, r, estimate)
+}
+
+// sampleQueueDepth reads the approximate visible and in-flight counts.
+func (h *SQSHandler) sampleQueueDepth(ctx context.Context, queueURL string) (queueDepthSample, error) {
+	attrs, err := h.Client.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl: aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{
+			types.QueueAttributeNameApproximateNumberOfMessages,
+			types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+		},
+	})
+	if err != nil {
+		return queueDepthSample{}, err
+	}
+	noteRequestID(ctx, attrs.ResultMetadata)
+
+	return queueDepthSample{
+		Visible:  parseIntSafe(attrs.Attributes["ApproximateNumberOfMessages"]),
+		InFlight: parseIntSafe(attrs.Attributes["ApproximateNumberOfMessagesNotVisible"]),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package sqs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	awssqs "github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+// depthSequenceClient returns successive visible/in-flight counts on each
+// GetQueueAttributes call, repeating the last one when the sequence runs out.
+type depthSequenceClient struct {
+	*helpers.MockSQSClient
+	samples []queueDepthSample
+	calls   int
+}
+
+func (c *depthSequenceClient) GetQueueAttributes(ctx context.Context, params *awssqs.GetQueueAttributesInput, optFns ...func(*awssqs.Options)) (*awssqs.GetQueueAttributesOutput, error) {
+	sample := c.samples[min(c.calls, len(c.samples)-1)]
+	c.calls++
+	return &awssqs.GetQueueAttributesOutput{
+		Attributes: map[string]string{
+			"ApproximateNumberOfMessages":           strconv.Itoa(sample.Visible),
+			"ApproximateNumberOfMessagesNotVisible": strconv.Itoa(sample.InFlight),
+		},
+	}, nil
+}
+
+func TestSQSHandler_GetQueueThroughput(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name      string
+		samples   []queueDepthSample
+		expectIn  bool
+		expectOut bool
+	}{
+		{
+			name:     "backlog growing",
+			samples:  []queueDepthSample{{Visible: 5, InFlight: 0}, {Visible: 15, InFlight: 2}},
+			expectIn: true,
+		},
+		{
+			name:      "backlog draining",
+			samples:   []queueDepthSample{{Visible: 20, InFlight: 0}, {Visible: 8, InFlight: 4}},
+			expectOut: true,
+		},
+		{
+			name:    "idle",
+			samples: []queueDepthSample{{Visible: 3, InFlight: 1}, {Visible: 3, InFlight: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &depthSequenceClient{MockSQSClient: helpers.NewMockSQSClient(), samples: tt.samples}
+			handler := &SQSHandler{Client: client}
+
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/throughput?intervalMs=10", nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+			handler.GetQueueThroughput(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+			}
+			if client.calls != 2 {
+				t.Errorf("expected 2 attribute samples, got %d", client.calls)
+			}
+
+			var estimate ThroughputEstimate
+			if err := json.NewDecoder(rr.Body).Decode(&estimate); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if (estimate.InPerSecond > 0) != tt.expectIn {
+				t.Errorf("unexpected inPerSecond %v", estimate.InPerSecond)
+			}
+			if (estimate.OutPerSecond > 0) != tt.expectOut {
+				t.Errorf("unexpected outPerSecond %v", estimate.OutPerSecond)
+			}
+			if estimate.IntervalMs != 10 || estimate.Note == "" {
+				t.Errorf("unexpected interval/note: %+v", estimate)
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetQueueThroughput_Cancelled(t *testing.T) {
+	client := &depthSequenceClient{MockSQSClient: helpers.NewMockSQSClient(), samples: []queueDepthSample{{Visible: 1}}}
+	handler := &SQSHandler{Client: client}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/throughput", nil).WithContext(ctx)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"})
+	rr := httptest.NewRecorder()
+	handler.GetQueueThroughput(rr, req)
+
+	if client.calls != 1 {
+		t.Errorf("expected sampling to stop after cancellation, got %d calls", client.calls)
+	}
+}
+
+func TestSQSHandler_GetQueueThroughput_InvalidInterval(t *testing.T) {
+	handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/throughput?intervalMs=soon", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"})
+	rr := httptest.NewRecorder()
+	handler.GetQueueThroughput(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,155 @@
+package sqs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// maxUIMetadataBytes caps the metadata stored for one queue.
+const maxUIMetadataBytes = 4 * 1024
+
+// uiMetadataStore holds the UI-only metadata (colors, notes) users attach to
+// queues, keyed by queue URL. It is unrelated to AWS tags. When
+// UI_METADATA_FILE is set the store is loaded from that file on first use and
+// rewritten on every change. The zero value is ready to use.
+type uiMetadataStore struct {
+	mu      sync.Mutex
+	loaded  bool
+	path    string
+	entries map[string]json.RawMessage
+}
+
+// load reads the persisted metadata once. A missing file starts empty; an
+// unreadable one is logged and ignored so the UI keeps working.
+func (s *uiMetadataStore) load() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	s.entries = make(map[string]json.RawMessage)
+	s.path = os.Getenv("UI_METADATA_FILE")
+	if s.path == "" {
+		return
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("UI metadata: Cannot read %s: %v", s.path, err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		log.Printf("UI metadata: Ignoring invalid %s: %v", s.path, err)
+		s.entries = make(map[string]json.RawMessage)
+	}
+}
+
+// save writes the metadata to UI_METADATA_FILE, replacing it atomically.
+func (s *uiMetadataStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(s.entries)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".ui-metadata-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// get returns the metadata for queueURL, or nil when none is set.
+func (s *uiMetadataStore) get(queueURL string) json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+	return s.entries[queueURL]
+}
+
+// put replaces the metadata for queueURL. An empty object clears it.
+func (s *uiMetadataStore) put(queueURL string, metadata json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+	if bytes.Equal(metadata, []byte("{}")) {
+		delete(s.entries, queueURL)
+	} else {
+		s.entries[queueURL] = metadata
+	}
+	return s.save()
+}
+
+// GetUIMetadata handles HTTP requests for a queue's UI metadata, answering an
+// empty object for queues without any.
+func (h *SQSHandler) GetUIMetadata(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	metadata := h.uiMetadata.get(queueURL)
+	if metadata == nil {
+		metadata = json.RawMessage("{}")
+	}
+	writeJSON(w, r, metadata)
+}
+
+// PutUIMetadata handles HTTP requests to replace a queue's UI metadata with a
+// JSON object of at most 4 KiB. Sending {} clears it.
+func (h *SQSHandler) PutUIMetadata(w http.ResponseWriter, r *http.Request) {
+	queueURL, ok := queueURLFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUIMetadataBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("metadata must not exceed %d bytes", maxUIMetadataBytes), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		http.Error(w, "metadata must be a JSON object", http.StatusBadRequest)
+		return
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, data); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.uiMetadata.put(queueURL, compact.Bytes()); err != nil {
+		log.Printf("PutUIMetadata: Error saving metadata for queue %s: %v", queueURL, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, r, json.RawMessage(compact.Bytes()))
+}
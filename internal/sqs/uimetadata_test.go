@@ -0,0 +1,124 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func putUIMetadata(t *testing.T, handler *SQSHandler, queueURL, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("PUT", "/api/queues/{queueUrl}/ui-metadata", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+	rr := httptest.NewRecorder()
+	handler.PutUIMetadata(rr, req)
+	return rr
+}
+
+func getUIMetadata(t *testing.T, handler *SQSHandler, queueURL string) string {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/ui-metadata", nil)
+	req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+	rr := httptest.NewRecorder()
+	handler.GetUIMetadata(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rr.Code)
+	}
+	return strings.TrimSpace(rr.Body.String())
+}
+
+func TestSQSHandler_UIMetadata_ListQueues(t *testing.T) {
+	t.Setenv("DISABLE_TAG_FILTER", "true")
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	const otherURL = "https://sqs.us-east-1.amazonaws.com/123456789012/payments"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+	mockClient.AddQueue(otherURL)
+	handler := &SQSHandler{Client: mockClient}
+
+	if rr := putUIMetadata(t, handler, queueURL, `{"color": "#ff0000", "note": "on call"}`); rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ListQueues(rr, httptest.NewRequest("GET", "/api/queues", nil))
+
+	var queues []types.Queue
+	if err := json.NewDecoder(rr.Body).Decode(&queues); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(queues) != 2 {
+		t.Fatalf("expected 2 queues, got %d", len(queues))
+	}
+	for _, queue := range queues {
+		switch queue.URL {
+		case queueURL:
+			if got := string(queue.UIMetadata); got != `{"color":"#ff0000","note":"on call"}` {
+				t.Errorf("expected stored metadata, got %s", got)
+			}
+		case otherURL:
+			if queue.UIMetadata != nil {
+				t.Errorf("expected no metadata for %s, got %s", otherURL, queue.UIMetadata)
+			}
+		}
+	}
+
+	// An empty object clears the metadata
+	putUIMetadata(t, handler, queueURL, `{}`)
+	if got := getUIMetadata(t, handler, queueURL); got != "{}" {
+		t.Errorf("expected cleared metadata, got %s", got)
+	}
+}
+
+func TestSQSHandler_UIMetadata(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+
+	t.Run("unknown queue returns empty metadata", func(t *testing.T) {
+		handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+		if got := getUIMetadata(t, handler, queueURL); got != "{}" {
+			t.Errorf("expected {}, got %s", got)
+		}
+	})
+
+	t.Run("invalid metadata rejected", func(t *testing.T) {
+		handler := &SQSHandler{Client: helpers.NewMockSQSClient()}
+		tests := []struct {
+			body           string
+			expectedStatus int
+		}{
+			{body: `["not", "an", "object"]`, expectedStatus: http.StatusBadRequest},
+			{body: `null`, expectedStatus: http.StatusBadRequest},
+			{body: `{"note": "` + strings.Repeat("x", maxUIMetadataBytes) + `"}`, expectedStatus: http.StatusRequestEntityTooLarge},
+		}
+		for _, tt := range tests {
+			if rr := putUIMetadata(t, handler, queueURL, tt.body); rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		}
+		if got := getUIMetadata(t, handler, queueURL); got != "{}" {
+			t.Errorf("expected nothing stored, got %s", got)
+		}
+	})
+
+	t.Run("persisted to UI_METADATA_FILE", func(t *testing.T) {
+		t.Setenv("UI_METADATA_FILE", filepath.Join(t.TempDir(), "ui-metadata.json"))
+
+		writer := &SQSHandler{Client: helpers.NewMockSQSClient()}
+		if rr := putUIMetadata(t, writer, queueURL, `{"color": "blue"}`); rr.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+
+		reader := &SQSHandler{Client: helpers.NewMockSQSClient()}
+		if got := getUIMetadata(t, reader, queueURL); got != `{"color":"blue"}` {
+			t.Errorf("expected persisted metadata, got %s", got)
+		}
+	})
+}
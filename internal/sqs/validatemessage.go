@@ -0,0 +1,175 @@
+package sqs
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxMessageBytes is the SQS limit for a body plus its message attributes.
+	maxMessageBytes = 256 * 1024
+	// maxMessageAttributes is the SQS limit on attributes per message.
+	maxMessageAttributes = 10
+	// maxAttributeNameLength is the SQS limit on an attribute name.
+	maxAttributeNameLength = 256
+	// maxFIFOIDLength is the SQS limit on group and deduplication IDs.
+	maxFIFOIDLength = 128
+	// stringAttributeType is the data type given to attributes sent as plain
+	// strings, counted towards the message size.
+	stringAttributeType = "String"
+)
+
+var (
+	// attributeNamePattern is the SQS attribute name alphabet.
+	attributeNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+	// fifoIDPattern is the SQS alphabet for group and deduplication IDs
+	// (alphanumerics and punctuation).
+	fifoIDPattern = regexp.MustCompile(`^[A-Za-z0-9!"#$%&'()*+,\-./:;<=>?@\[\\\]^_` + "`" + `{|}~]+$`)
+)
+
+// messageViolation is one SQS constraint a message would break.
+type messageViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// messageDraft is a message checked by ValidateMessage. Attributes are sent
+// as String message attributes.
+type messageDraft struct {
+	QueueURL               string            `json:"queueUrl"`
+	Body                   string            `json:"body"`
+	Attributes             map[string]string `json:"attributes"`
+	MessageGroupID         string            `json:"messageGroupId"`
+	MessageDeduplicationID string            `json:"messageDeduplicationId"`
+}
+
+// validSQSText reports whether s only uses the characters SQS accepts in
+// bodies and attribute values: #x9, #xA, #xD, #x20 to #xD7FF, #xE000 to
+// #xFFFD and #x10000 to #x10FFFF.
+func validSQSText(s string) bool {
+	for _, r := range s {
+		switch {
+		case r == 0x9, r == 0xA, r == 0xD:
+		case r >= 0x20 && r <= 0xD7FF:
+		case r >= 0xE000 && r <= 0xFFFD:
+		case r >= 0x10000 && r <= 0x10FFFF:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// validateAttributeName returns why name is not a valid SQS attribute name,
+// or "" when it is.
+func validateAttributeName(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case name == "":
+		return "name is empty"
+	case len(name) > maxAttributeNameLength:
+		return fmt.Sprintf("name exceeds %d characters", maxAttributeNameLength)
+	case !attributeNamePattern.MatchString(name):
+		return "name may only contain letters, digits, '_', '-' and '.'"
+	case strings.HasPrefix(lower, "aws.") || strings.HasPrefix(lower, "amazon."):
+		return "names starting with AWS. or Amazon. are reserved"
+	case strings.HasPrefix(name, ".") || strings.HasSuffix(name, "."):
+		return "name may not start or end with '.'"
+	case strings.Contains(name, ".."):
+		return "name may not contain consecutive periods"
+	}
+	return ""
+}
+
+// validateFIFOID returns why id is not a valid group or deduplication ID, or
+// "" when it is.
+func validateFIFOID(id string) string {
+	if len(id) > maxFIFOIDLength {
+		return fmt.Sprintf("exceeds %d characters", maxFIFOIDLength)
+	}
+	if !fifoIDPattern.MatchString(id) {
+		return "may only contain alphanumeric characters and punctuation"
+	}
+	return ""
+}
+
+// validateMessage checks a draft against the SQS send constraints without
+// calling AWS. Violations are ordered by field.
+func validateMessage(draft messageDraft) []messageViolation {
+	violations := []messageViolation{}
+	add := func(field, format string, args ...interface{}) {
+		violations = append(violations, messageViolation{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if draft.Body == "" {
+		add("body", "body is empty")
+	} else if !validSQSText(draft.Body) {
+		add("body", "body contains characters SQS does not accept")
+	}
+
+	if len(draft.Attributes) > maxMessageAttributes {
+		add("attributes", "%d attributes exceeds the limit of %d", len(draft.Attributes), maxMessageAttributes)
+	}
+	names := make([]string, 0, len(draft.Attributes))
+	for name := range draft.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := draft.Attributes[name]
+
+		field := "attributes." + name
+		if problem := validateAttributeName(name); problem != "" {
+			add(field, "%s", problem)
+		}
+		if value == "" {
+			add(field, "value is empty")
+		} else if !validSQSText(value) {
+			add(field, "value contains characters SQS does not accept")
+		}
+	}
+	if size := sqsMessageSize(draft.Body, stringMessageAttributes(draft.Attributes)); size.exceedsLimit() {
+		add("body", "%s", tooLargeMessage(size))
+	}
+
+	if isFIFOQueue(draft.QueueURL) {
+		if draft.MessageGroupID == "" {
+			add("messageGroupId", "messageGroupId is required for FIFO queues")
+		} else if problem := validateFIFOID(draft.MessageGroupID); problem != "" {
+			add("messageGroupId", "messageGroupId %s", problem)
+		}
+		if draft.MessageDeduplicationID != "" {
+			if problem := validateFIFOID(draft.MessageDeduplicationID); problem != "" {
+				add("messageDeduplicationId", "messageDeduplicationId %s", problem)
+			}
+		}
+	}
+
+	return violations
+}
+
+// ValidateMessage handles HTTP requests to check a message against SQS
+// constraints before sending. It makes no AWS call and answers 200 when the
+// message is valid or 422 with the violations.
+func (h *SQSHandler) ValidateMessage(w http.ResponseWriter, r *http.Request) {
+	var draft messageDraft
+	if !decodeLimitedJSON(w, r, &draft) {
+		return
+	}
+	draft.QueueURL = normalizeQueueURL(draft.QueueURL)
+
+	violations := validateMessage(draft)
+
+	status := http.StatusOK
+	if len(violations) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	writeJSONStatus(w, r, status, map[string]interface{}{
+		"valid":      len(violations) == 0,
+		"violations": violations,
+	})
+}
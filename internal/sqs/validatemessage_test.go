@@ -0,0 +1,112 @@
+package sqs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSQSHandler_ValidateMessage(t *testing.T) {
+	const standardQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	const fifoQueue = "https://sqs.us-east-1.amazonaws.com/123456789012/orders.fifo"
+
+	tooManyAttributes := map[string]string{}
+	for i := 0; i < 11; i++ {
+		tooManyAttributes[fmt.Sprintf("attr%d", i)] = "v"
+	}
+
+	tests := []struct {
+		name           string
+		draft          messageDraft
+		expectedStatus int
+		expectedFields []string
+	}{
+		{
+			name:           "valid standard message",
+			draft:          messageDraft{QueueURL: standardQueue, Body: `{"id":1}`, Attributes: map[string]string{"source": "ui"}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "valid FIFO message",
+			draft:          messageDraft{QueueURL: fifoQueue, Body: "hello", MessageGroupID: "group-1"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "oversize body",
+			draft:          messageDraft{QueueURL: standardQueue, Body: strings.Repeat("x", maxMessageBytes+1)},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []string{"body"},
+		},
+		{
+			name: "attributes push the message over the limit",
+			draft: messageDraft{
+				QueueURL:   standardQueue,
+				Body:       strings.Repeat("x", maxMessageBytes-10),
+				Attributes: map[string]string{"trace": "0123456789"},
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []string{"body"},
+		},
+		{
+			name:           "too many attributes",
+			draft:          messageDraft{QueueURL: standardQueue, Body: "hello", Attributes: tooManyAttributes},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []string{"attributes"},
+		},
+		{
+			name:           "invalid attribute name and empty value",
+			draft:          messageDraft{QueueURL: standardQueue, Body: "hello", Attributes: map[string]string{"AWS.trace": "x", "bad name": "x", "empty": ""}},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []string{"attributes.AWS.trace", "attributes.bad name", "attributes.empty"},
+		},
+		{
+			name:           "missing FIFO group id",
+			draft:          messageDraft{QueueURL: fifoQueue, Body: "hello"},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []string{"messageGroupId"},
+		},
+		{
+			name:           "empty body",
+			draft:          messageDraft{QueueURL: standardQueue},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedFields: []string{"body"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No client: validation must not call AWS
+			handler := &SQSHandler{}
+
+			payload, _ := json.Marshal(tt.draft)
+			rr := httptest.NewRecorder()
+			handler.ValidateMessage(rr, httptest.NewRequest("POST", "/api/validate-message", strings.NewReader(string(payload))))
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+
+			var response struct {
+				Valid      bool               `json:"valid"`
+				Violations []messageViolation `json:"violations"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+
+			if response.Valid != (tt.expectedStatus == http.StatusOK) {
+				t.Errorf("unexpected valid flag %v", response.Valid)
+			}
+			fields := []string{}
+			for _, violation := range response.Violations {
+				fields = append(fields, violation.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.expectedFields, ",") {
+				t.Errorf("expected violations on %v, got %+v", tt.expectedFields, response.Violations)
+			}
+		})
+	}
+}
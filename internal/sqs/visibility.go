@@ -0,0 +1,61 @@
+package sqs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// maxVisibilityTimeoutSeconds is the longest visibility timeout SQS accepts.
+const maxVisibilityTimeoutSeconds = 43200
+
+// ValidateVisibilityTimeout checks a visibility timeout override in seconds.
+// As elsewhere in this package, 0 means peeking without hiding the messages.
+func ValidateVisibilityTimeout(seconds int) error {
+	if seconds < 0 || seconds > maxVisibilityTimeoutSeconds {
+		return fmt.Errorf("visibilityTimeout must be between 0 and %d", maxVisibilityTimeoutSeconds)
+	}
+	return nil
+}
+
+// VisibilityTimeoutAttribute returns the queue's default visibility timeout
+// in seconds from its attributes, or 0 when missing or invalid.
+func VisibilityTimeoutAttribute(attributes map[string]string) int {
+	seconds, err := strconv.Atoi(attributes["VisibilityTimeout"])
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return seconds
+}
+
+// SetVisibleAgainAt stamps messages received at receivedAt with when they
+// reappear after timeoutSeconds. A zero timeout hid nothing and leaves them
+// unstamped.
+func SetVisibleAgainAt(messages []internal_types.Message, receivedAt time.Time, timeoutSeconds int) {
+	if timeoutSeconds <= 0 {
+		return
+	}
+	visibleAgainAt := receivedAt.Add(time.Duration(timeoutSeconds) * time.Second).UnixMilli()
+	for i := range messages {
+		messages[i].VisibleAgainAt = &visibleAgainAt
+	}
+}
+
+// effectiveVisibilityTimeout returns the timeout a receive applied: the
+// override when given, otherwise the queue's default. A failed attribute
+// fetch is logged and counts as 0, leaving messages unstamped.
+func (h *SQSHandler) effectiveVisibilityTimeout(ctx context.Context, queueURL string, override *int32) int {
+	if override != nil {
+		return int(*override)
+	}
+	attributes, err := h.queueAttributes(ctx, queueURL)
+	if err != nil {
+		log.Printf("Error fetching visibility timeout for queue %s: %v", queueURL, err)
+		return 0
+	}
+	return VisibilityTimeoutAttribute(attributes)
+}
@@ -0,0 +1,110 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+	"github.com/cjunks94/go-sqs-ui/test/helpers"
+	"github.com/gorilla/mux"
+)
+
+func TestSQSHandler_GetMessages_VisibleAgainAt(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedSeconds int
+	}{
+		{name: "queue default", query: "", expectedStatus: http.StatusOK, expectedSeconds: 30},
+		{name: "override", query: "&visibilityTimeout=120", expectedStatus: http.StatusOK, expectedSeconds: 120},
+		{name: "peek", query: "&visibilityTimeout=0", expectedStatus: http.StatusOK, expectedSeconds: 0},
+		{name: "out of range", query: "&visibilityTimeout=43201", expectedStatus: http.StatusBadRequest},
+		{name: "not a number", query: "&visibilityTimeout=soon", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &SQSHandler{Client: demo.NewDemoSQSClient(), isDemo: true}
+			req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?limit=10"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+			rr := httptest.NewRecorder()
+
+			before := time.Now()
+			handler.GetMessages(rr, req)
+			after := time.Now()
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var messages []internal_types.Message
+			if err := json.NewDecoder(rr.Body).Decode(&messages); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(messages) == 0 {
+				t.Fatal("expected demo messages")
+			}
+			timeout := time.Duration(tt.expectedSeconds) * time.Second
+			for _, message := range messages {
+				if tt.expectedSeconds == 0 {
+					if message.VisibleAgainAt != nil {
+						t.Errorf("expected no visibleAgainAt when peeking, got %d", *message.VisibleAgainAt)
+					}
+					continue
+				}
+				if message.VisibleAgainAt == nil {
+					t.Fatalf("expected visibleAgainAt on %s", message.MessageId)
+				}
+				if at := *message.VisibleAgainAt; at < before.Add(timeout).UnixMilli() || at > after.Add(timeout).UnixMilli() {
+					t.Errorf("expected visibleAgainAt %v after the receive, got %d", timeout, at)
+				}
+			}
+		})
+	}
+}
+
+func TestSQSHandler_GetMessages_PeekReleasesMessages(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	for _, tt := range []struct {
+		query          string
+		expectedResets int
+	}{
+		{query: "&visibilityTimeout=0", expectedResets: 2},
+		{query: "&visibilityTimeout=60", expectedResets: 0},
+		{query: "", expectedResets: 0},
+	} {
+		mock := helpers.NewMockSQSClient()
+		mock.AddQueue(queueURL)
+		mock.AddMessage(queueURL, "msg-1", "first")
+		mock.AddMessage(queueURL, "msg-2", "second")
+		handler := &SQSHandler{Client: mock}
+
+		req := httptest.NewRequest("GET", "/api/queues/{queueUrl}/messages?limit=10"+tt.query, nil)
+		req = mux.SetURLVars(req, map[string]string{"queueUrl": queueURL})
+		rr := httptest.NewRecorder()
+		handler.GetMessages(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%q: expected status 200, got %d: %s", tt.query, rr.Code, rr.Body.String())
+		}
+		if got := len(mock.ChangeMessageVisibilityCalls); got != tt.expectedResets {
+			t.Errorf("%q: expected %d visibility resets, got %d", tt.query, tt.expectedResets, got)
+		}
+		for _, call := range mock.ChangeMessageVisibilityCalls {
+			if call.VisibilityTimeout != 0 {
+				t.Errorf("%q: expected a reset to 0, got %d", tt.query, call.VisibilityTimeout)
+			}
+		}
+	}
+}
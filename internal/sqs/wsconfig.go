@@ -0,0 +1,71 @@
+package sqs
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+const (
+	// defaultReconnectBaseDelayMs matches the fixed delay the UI used before
+	// reconnection was server-driven.
+	defaultReconnectBaseDelayMs = 5000
+	// defaultReconnectMaxDelayMs caps the backed-off reconnection delay.
+	defaultReconnectMaxDelayMs = 60000
+	// defaultReconnectJitter is the fraction of each delay randomized.
+	defaultReconnectJitter = 0.2
+	// defaultReconnectMaxAttempts of 0 keeps reconnecting indefinitely.
+	defaultReconnectMaxAttempts = 0
+)
+
+// ReconnectConfig is the WebSocket reconnection policy recommended to
+// clients: the delay starts at BaseDelayMs and doubles per failed attempt up
+// to MaxDelayMs, each delay varied by up to Jitter of itself, giving up after
+// MaxAttempts (0 never gives up).
+type ReconnectConfig struct {
+	BaseDelayMs int     `json:"baseDelayMs"`
+	MaxDelayMs  int     `json:"maxDelayMs"`
+	Jitter      float64 `json:"jitter"`
+	MaxAttempts int     `json:"maxAttempts"`
+}
+
+// envInt returns the integer in the named variable when it is at least min,
+// falling back to fallback when unset or invalid.
+func envInt(name string, min, fallback int) int {
+	if value := os.Getenv(name); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= min {
+			return n
+		}
+		log.Printf("Invalid %s %q, using %d", name, value, fallback)
+	}
+	return fallback
+}
+
+// reconnectConfigFromEnv reads the reconnection policy from
+// WS_RECONNECT_BASE_DELAY_MS, WS_RECONNECT_MAX_DELAY_MS, WS_RECONNECT_JITTER
+// and WS_RECONNECT_MAX_ATTEMPTS. A max delay below the base delay is raised
+// to it.
+func reconnectConfigFromEnv() ReconnectConfig {
+	cfg := ReconnectConfig{
+		BaseDelayMs: envInt("WS_RECONNECT_BASE_DELAY_MS", 1, defaultReconnectBaseDelayMs),
+		MaxDelayMs:  envInt("WS_RECONNECT_MAX_DELAY_MS", 1, defaultReconnectMaxDelayMs),
+		Jitter:      defaultReconnectJitter,
+		MaxAttempts: envInt("WS_RECONNECT_MAX_ATTEMPTS", 0, defaultReconnectMaxAttempts),
+	}
+	if value := os.Getenv("WS_RECONNECT_JITTER"); value != "" {
+		if jitter, err := strconv.ParseFloat(value, 64); err == nil && jitter >= 0 && jitter <= 1 {
+			cfg.Jitter = jitter
+		} else {
+			log.Printf("Invalid WS_RECONNECT_JITTER %q, using %g", value, defaultReconnectJitter)
+		}
+	}
+	cfg.MaxDelayMs = max(cfg.MaxDelayMs, cfg.BaseDelayMs)
+	return cfg
+}
+
+// GetWebSocketConfig handles HTTP requests for the WebSocket reconnection
+// policy, so operators tune client reconnection centrally.
+func (h *SQSHandler) GetWebSocketConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, r, reconnectConfigFromEnv())
+}
@@ -0,0 +1,68 @@
+package sqs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSQSHandler_GetWebSocketConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected ReconnectConfig
+	}{
+		{
+			name:     "defaults when unset",
+			expected: ReconnectConfig{BaseDelayMs: 5000, MaxDelayMs: 60000, Jitter: 0.2, MaxAttempts: 0},
+		},
+		{
+			name: "configured values",
+			env: map[string]string{
+				"WS_RECONNECT_BASE_DELAY_MS": "1000",
+				"WS_RECONNECT_MAX_DELAY_MS":  "30000",
+				"WS_RECONNECT_JITTER":        "0.5",
+				"WS_RECONNECT_MAX_ATTEMPTS":  "12",
+			},
+			expected: ReconnectConfig{BaseDelayMs: 1000, MaxDelayMs: 30000, Jitter: 0.5, MaxAttempts: 12},
+		},
+		{
+			name: "invalid values fall back",
+			env: map[string]string{
+				"WS_RECONNECT_BASE_DELAY_MS": "0",
+				"WS_RECONNECT_JITTER":        "2",
+				"WS_RECONNECT_MAX_ATTEMPTS":  "-1",
+			},
+			expected: ReconnectConfig{BaseDelayMs: 5000, MaxDelayMs: 60000, Jitter: 0.2, MaxAttempts: 0},
+		},
+		{
+			name:     "max delay raised to base delay",
+			env:      map[string]string{"WS_RECONNECT_BASE_DELAY_MS": "90000"},
+			expected: ReconnectConfig{BaseDelayMs: 90000, MaxDelayMs: 90000, Jitter: 0.2, MaxAttempts: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"WS_RECONNECT_BASE_DELAY_MS", "WS_RECONNECT_MAX_DELAY_MS", "WS_RECONNECT_JITTER", "WS_RECONNECT_MAX_ATTEMPTS"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			handler := &SQSHandler{}
+			rr := httptest.NewRecorder()
+			handler.GetWebSocketConfig(rr, httptest.NewRequest("GET", "/api/ws-config", nil))
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d", rr.Code)
+			}
+			var got ReconnectConfig
+			if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
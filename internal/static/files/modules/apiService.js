@@ -1,3 +1,5 @@
+import { withBasePath } from './basePath.js';
+
 /**
  * API Service for HTTP requests
  * Handles all communication with the backend API
@@ -5,7 +7,7 @@
 export class APIService {
   static async request(url, options = {}) {
     try {
-      const response = await fetch(url, {
+      const response = await fetch(withBasePath(url), {
         ...options,
         headers: {
           'Content-Type': 'application/json',
@@ -45,7 +47,7 @@ export class APIService {
 
   static async deleteMessage(queueUrl, receiptHandle) {
     const response = await fetch(
-      `/api/queues/${encodeURIComponent(queueUrl)}/messages/${encodeURIComponent(receiptHandle)}`,
+      withBasePath(`/api/queues/${encodeURIComponent(queueUrl)}/messages/${encodeURIComponent(receiptHandle)}`),
       {
         method: 'DELETE',
       }
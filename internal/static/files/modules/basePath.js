@@ -0,0 +1,9 @@
+/**
+ * Base Path
+ * Prefixes absolute API/WebSocket paths with the server's BASE_PATH, which
+ * config.js publishes as window.SQS_UI_BASE_PATH ("" when served at the root)
+ */
+export function withBasePath(path) {
+  const basePath = (typeof window !== 'undefined' && window.SQS_UI_BASE_PATH) || '';
+  return `${basePath}${path}`;
+}
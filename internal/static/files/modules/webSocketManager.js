@@ -1,3 +1,5 @@
+import { withBasePath } from './basePath.js';
+
 /**
  * WebSocket Manager
  * Handles WebSocket connections and real-time message updates
@@ -12,7 +14,7 @@ export class WebSocketManager {
 
   connect() {
     const protocol = window.location.protocol === 'https:' ? 'wss:' : 'ws:';
-    this.ws = new WebSocket(`${protocol}//${window.location.host}/ws`);
+    this.ws = new WebSocket(`${protocol}//${window.location.host}${withBasePath('/ws')}`);
 
     this.ws.onopen = () => {
       // WebSocket connected
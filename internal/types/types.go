@@ -1,11 +1,27 @@
 // Package types provides common data structures for SQS queue and message representation.
 package types
 
+import "encoding/json"
+
 // Queue represents an AWS SQS queue with its metadata and attributes.
 type Queue struct {
 	Name       string            `json:"name"`
 	URL        string            `json:"url"`
 	Attributes map[string]string `json:"attributes"`
+	// DisplayURL is URL with the account ID masked, set only when
+	// MASK_ACCOUNT_IDS is enabled; URL itself stays usable.
+	DisplayURL string `json:"displayUrl,omitempty"`
+	// UIMetadata is the UI-only metadata (color, note) stored for the queue,
+	// omitted when none is set.
+	UIMetadata json.RawMessage `json:"uiMetadata,omitempty"`
+	// TagsUnavailable marks a queue listed without passing the tag filter
+	// because its tags could not be fetched (TAG_FETCH_FAILURE_MODE=include).
+	TagsUnavailable bool `json:"tagsUnavailable,omitempty"`
+	// Region, AccountID and QueueName are parsed from the QueueArn
+	// attribute; omitted when it is missing or malformed.
+	Region    string `json:"region,omitempty"`
+	AccountID string `json:"accountId,omitempty"`
+	QueueName string `json:"queueName,omitempty"`
 }
 
 // Message represents an AWS SQS message with its body, ID, receipt handle, and attributes.
@@ -14,4 +30,30 @@ type Message struct {
 	Body          string            `json:"body"`
 	ReceiptHandle string            `json:"receiptHandle"`
 	Attributes    map[string]string `json:"attributes"`
+	TraceHeader   string            `json:"traceHeader,omitempty"`
+	ContentType   string            `json:"contentType,omitempty"`
+	Summary       string            `json:"summary,omitempty"`
+	// MD5OfBody and MD5OfMessageAttributes are only set when requested with
+	// ?includeMd5=true.
+	MD5OfBody              string `json:"md5OfBody,omitempty"`
+	MD5OfMessageAttributes string `json:"md5OfMessageAttributes,omitempty"`
+	// FirstReceiveLatencyMs is the wait between send and first receive; nil
+	// when either timestamp attribute is missing.
+	FirstReceiveLatencyMs *int64 `json:"firstReceiveLatencyMs,omitempty"`
+	// AgeSeconds is how long ago the message was sent, never negative; nil
+	// when SentTimestamp is missing.
+	AgeSeconds *int64 `json:"ageSeconds,omitempty"`
+	// VisibleAgainAt is when (Unix milliseconds) the message reappears in the
+	// queue after this receive hid it; nil when the receive only peeked.
+	VisibleAgainAt *int64 `json:"visibleAgainAt,omitempty"`
+}
+
+// OperationResult is the response shape shared by the mutating handlers.
+// Status names what happened ("sent", "deleted", "retried", ...); the
+// optional fields are set when they apply to the operation.
+type OperationResult struct {
+	Status        string      `json:"status"`
+	MessageId     string      `json:"messageId,omitempty"`
+	AffectedCount *int        `json:"affectedCount,omitempty"`
+	Details       interface{} `json:"details,omitempty"`
 }
@@ -0,0 +1,69 @@
+package websocket
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultBackoffAfterErrors is how many consecutive poll errors trigger a backoff.
+	defaultBackoffAfterErrors = 3
+	// defaultMaxPollErrors is how many consecutive poll errors, across
+	// backoffs, end a subscription.
+	defaultMaxPollErrors = 20
+)
+
+// defaultBackoffSchedule is the escalating pause applied to successive backoffs
+// on the same subscription; the last entry repeats until a poll succeeds.
+var defaultBackoffSchedule = []time.Duration{10 * time.Second, 30 * time.Second, 60 * time.Second}
+
+// backoffAfterErrorsFromEnv reads WS_BACKOFF_AFTER_ERRORS, falling back to the
+// default for missing or non-positive values.
+func backoffAfterErrorsFromEnv() int {
+	if n, err := strconv.Atoi(os.Getenv("WS_BACKOFF_AFTER_ERRORS")); err == nil && n > 0 {
+		return n
+	}
+	return defaultBackoffAfterErrors
+}
+
+// maxPollErrorsFromEnv reads WS_MAX_POLL_ERRORS, falling back to the default
+// for missing or non-positive values.
+func maxPollErrorsFromEnv() int {
+	if n, err := strconv.Atoi(os.Getenv("WS_MAX_POLL_ERRORS")); err == nil && n > 0 {
+		return n
+	}
+	return defaultMaxPollErrors
+}
+
+// backoffScheduleFromEnv reads WS_BACKOFF_SCHEDULE, a comma-separated list of
+// whole seconds (e.g. "10,30,60"). Invalid entries are skipped; an empty result
+// falls back to the default schedule.
+func backoffScheduleFromEnv() []time.Duration {
+	raw := os.Getenv("WS_BACKOFF_SCHEDULE")
+	if raw == "" {
+		return defaultBackoffSchedule
+	}
+
+	var schedule []time.Duration
+	for _, part := range strings.Split(raw, ",") {
+		seconds, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || seconds <= 0 {
+			continue
+		}
+		schedule = append(schedule, time.Duration(seconds)*time.Second)
+	}
+	if len(schedule) == 0 {
+		return defaultBackoffSchedule
+	}
+	return schedule
+}
+
+// backoffDelay returns the pause for the n-th consecutive backoff (zero-based).
+func (wsm *WebSocketManager) backoffDelay(n int) time.Duration {
+	if n >= len(wsm.backoffSchedule) {
+		n = len(wsm.backoffSchedule) - 1
+	}
+	return wsm.backoffSchedule[n]
+}
@@ -0,0 +1,20 @@
+package websocket
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// defaultWriteTimeout bounds each frame write, so a client that stops reading
+// is disconnected instead of stalling its pollers.
+const defaultWriteTimeout = 10 * time.Second
+
+// writeTimeoutFromEnv reads WS_WRITE_TIMEOUT_SECONDS, falling back to the
+// default for missing or non-positive values.
+func writeTimeoutFromEnv() time.Duration {
+	if n, err := strconv.Atoi(os.Getenv("WS_WRITE_TIMEOUT_SECONDS")); err == nil && n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	return defaultWriteTimeout
+}
@@ -0,0 +1,71 @@
+package websocket
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	internal_sqs "github.com/cjunks94/go-sqs-ui/internal/sqs"
+)
+
+// depthFetchTimeout bounds the attribute fetch behind includeDepth, so a slow
+// GetQueueAttributes delays the initial_messages frame by at most this long.
+const depthFetchTimeout = 2 * time.Second
+
+// queueDepth is the approximate queue depth at subscription time.
+type queueDepth struct {
+	messages int
+	inFlight int
+}
+
+// fetchQueueDepth fetches the queue's approximate depth in the background,
+// alongside the first poll. The channel yields nil when the fetch fails; the
+// error is logged and the initial frame goes out without the depth fields.
+func (wsm *WebSocketManager) fetchQueueDepth(ctx context.Context, queueURL string) <-chan *queueDepth {
+	result := make(chan *queueDepth, 1)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, depthFetchTimeout)
+		defer cancel()
+
+		attrs, err := wsm.sqsClient.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+			QueueUrl: aws.String(queueURL),
+			AttributeNames: []types.QueueAttributeName{
+				types.QueueAttributeNameApproximateNumberOfMessages,
+				types.QueueAttributeNameApproximateNumberOfMessagesNotVisible,
+			},
+		})
+		if err != nil {
+			log.Printf("Error fetching depth for queue %s: %v", queueURL, err)
+			result <- nil
+			return
+		}
+
+		messages, _ := strconv.Atoi(attrs.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)])
+		inFlight, _ := strconv.Atoi(attrs.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesNotVisible)])
+		result <- &queueDepth{messages: messages, inFlight: inFlight}
+	}()
+
+	return result
+}
+
+// fetchVisibilityTimeout returns the queue's default visibility timeout in
+// seconds, or -1 when the fetch fails so the next poll retries it.
+func (wsm *WebSocketManager) fetchVisibilityTimeout(ctx context.Context, queueURL string) int {
+	ctx, cancel := context.WithTimeout(ctx, depthFetchTimeout)
+	defer cancel()
+
+	attrs, err := wsm.sqsClient.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(queueURL),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameVisibilityTimeout},
+	})
+	if err != nil {
+		log.Printf("Error fetching visibility timeout for queue %s: %v", queueURL, err)
+		return -1
+	}
+	return internal_sqs.VisibilityTimeoutAttribute(attrs.Attributes)
+}
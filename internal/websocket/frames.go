@@ -0,0 +1,34 @@
+package websocket
+
+import (
+	"os"
+	"strconv"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// defaultMaxFrameMessages caps the messages carried by one stream frame, so a
+// backed-up queue's initial load is not sent as a single huge frame.
+const defaultMaxFrameMessages = 50
+
+// maxFrameMessagesFromEnv reads WS_MAX_FRAME_MESSAGES, falling back to the
+// default for missing or non-positive values.
+func maxFrameMessagesFromEnv() int {
+	if n, err := strconv.Atoi(os.Getenv("WS_MAX_FRAME_MESSAGES")); err == nil && n > 0 {
+		return n
+	}
+	return defaultMaxFrameMessages
+}
+
+// splitMessages splits messages into chunks of at most size, keeping their
+// order. An empty batch is a single empty chunk.
+func splitMessages(messages []internal_types.Message, size int) [][]internal_types.Message {
+	if len(messages) <= size {
+		return [][]internal_types.Message{messages}
+	}
+	chunks := make([][]internal_types.Message, 0, (len(messages)+size-1)/size)
+	for start := 0; start < len(messages); start += size {
+		chunks = append(chunks, messages[start:min(start+size, len(messages))])
+	}
+	return chunks
+}
@@ -0,0 +1,114 @@
+package websocket
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/gorilla/websocket"
+
+	internal_types "github.com/cjunks94/go-sqs-ui/internal/types"
+)
+
+// defaultQueueListInterval is how often a subscribeQueues poller re-lists the
+// queues. Listing fetches tags and attributes per queue, so it runs less
+// often than message polling.
+const defaultQueueListInterval = 15 * time.Second
+
+// defaultQueueListLimit matches the REST ListQueues default.
+const defaultQueueListLimit = 20
+
+// queueListEntry is one queue in a "queues" frame.
+type queueListEntry struct {
+	Name                string `json:"name"`
+	URL                 string `json:"url"`
+	ApproximateMessages int    `json:"approximateMessages"`
+	ApproximateInFlight int    `json:"approximateInFlight"`
+}
+
+// subscribeQueues starts streaming the filtered queue list to the connection,
+// replacing any existing queue list subscription.
+func (wsm *WebSocketManager) subscribeQueues(conn *websocket.Conn, limit int32) {
+	wsm.connectionsMu.Lock()
+	defer wsm.connectionsMu.Unlock()
+
+	if _, exists := wsm.connections[conn]; !exists {
+		return
+	}
+	if cancel, subscribed := wsm.queueListSubs[conn]; subscribed {
+		cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wsm.queueListSubs[conn] = cancel
+
+	go wsm.pollQueueList(ctx, conn, limit)
+}
+
+// unsubscribeQueues stops the connection's queue list poller, if any.
+func (wsm *WebSocketManager) unsubscribeQueues(conn *websocket.Conn) {
+	wsm.connectionsMu.Lock()
+	defer wsm.connectionsMu.Unlock()
+
+	if cancel, subscribed := wsm.queueListSubs[conn]; subscribed {
+		cancel()
+		delete(wsm.queueListSubs, conn)
+	}
+}
+
+// pollQueueList lists the queues, with the same tag filtering as the REST
+// ListQueues endpoint, every queueListInterval and sends a "queues" frame
+// whenever the list or any depth differs from the last frame sent.
+func (wsm *WebSocketManager) pollQueueList(ctx context.Context, conn *websocket.Conn, limit int32) {
+	ticker := time.NewTicker(wsm.queueListInterval)
+	defer ticker.Stop()
+
+	var last []queueListEntry
+	sent := false
+
+	for {
+		queues, err := wsm.handler.ListFilteredQueues(ctx, limit)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("Error listing queues for stream: %v", err)
+		} else {
+			entries := queueListEntries(queues)
+			if !sent || !slices.Equal(entries, last) {
+				if err := wsm.writeJSON(conn, map[string]interface{}{
+					"type":   "queues",
+					"queues": entries,
+				}); err != nil {
+					return
+				}
+				last, sent = entries, true
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// queueListEntries reduces listed queues to their name, URL and depth.
+func queueListEntries(queues []internal_types.Queue) []queueListEntry {
+	entries := make([]queueListEntry, 0, len(queues))
+	for _, queue := range queues {
+		messages, _ := strconv.Atoi(queue.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessages)])
+		inFlight, _ := strconv.Atoi(queue.Attributes[string(types.QueueAttributeNameApproximateNumberOfMessagesNotVisible)])
+		entries = append(entries, queueListEntry{
+			Name:                queue.Name,
+			URL:                 queue.URL,
+			ApproximateMessages: messages,
+			ApproximateInFlight: inFlight,
+		})
+	}
+	return entries
+}
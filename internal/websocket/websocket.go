@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -82,20 +83,61 @@ var upgrader = websocket.Upgrader{
 
 // WebSocketManager manages WebSocket connections and real-time SQS message streaming.
 type WebSocketManager struct {
-	sqsClient     internal_sqs.SQSClientInterface
+	sqsClient internal_sqs.SQSClientInterface
+	// handler lists queues for subscribeQueues, sharing the REST handler's
+	// configuration and caches
+	handler       *internal_sqs.SQSHandler
 	connections   map[*websocket.Conn]map[string]context.CancelFunc
 	connectionsMu sync.RWMutex
+	// queueListSubs cancels each connection's subscribeQueues poller
+	// (guarded by connectionsMu)
+	queueListSubs map[*websocket.Conn]context.CancelFunc
 	// Track sent messages per connection per queue
 	sentMessages   map[*websocket.Conn]map[string]map[string]bool
 	sentMessagesMu sync.RWMutex
+	// writers serialize and interleave writes per connection (guarded by
+	// connectionsMu); pollers and the read loop both write frames
+	writers map[*websocket.Conn]*connWriter
+	// writeQueueSize bounds the frames queued for each connection's writer
+	writeQueueSize int
+	// Polling cadence and the backoff applied after repeated poll errors
+	pollInterval       time.Duration
+	backoffAfterErrors int
+	backoffSchedule    []time.Duration
+	// maxPollErrors consecutive poll errors end a subscription
+	maxPollErrors int
+	// writeTimeout is the deadline for each write; a slow reader is disconnected
+	writeTimeout time.Duration
+	// queueListInterval is how often subscribeQueues re-lists the queues
+	queueListInterval time.Duration
+	// maxFrameMessages caps the messages per frame; larger batches are split
+	maxFrameMessages int
 }
 
 // NewWebSocketManager creates a new WebSocket manager with the given SQS client.
 func NewWebSocketManager(sqsClient internal_sqs.SQSClientInterface) *WebSocketManager {
+	return NewWebSocketManagerForHandler(&internal_sqs.SQSHandler{Client: sqsClient})
+}
+
+// NewWebSocketManagerForHandler creates a WebSocket manager that uses the
+// handler's SQS client and lists queues through the handler itself.
+func NewWebSocketManagerForHandler(handler *internal_sqs.SQSHandler) *WebSocketManager {
 	return &WebSocketManager{
-		sqsClient:    sqsClient,
-		connections:  make(map[*websocket.Conn]map[string]context.CancelFunc),
-		sentMessages: make(map[*websocket.Conn]map[string]map[string]bool),
+		sqsClient:     handler.Client,
+		handler:       handler,
+		connections:   make(map[*websocket.Conn]map[string]context.CancelFunc),
+		queueListSubs: make(map[*websocket.Conn]context.CancelFunc),
+		sentMessages:  make(map[*websocket.Conn]map[string]map[string]bool),
+		writers:       make(map[*websocket.Conn]*connWriter),
+
+		pollInterval:       internal_sqs.StreamPollInterval,
+		backoffAfterErrors: backoffAfterErrorsFromEnv(),
+		backoffSchedule:    backoffScheduleFromEnv(),
+		maxPollErrors:      maxPollErrorsFromEnv(),
+		writeTimeout:       writeTimeoutFromEnv(),
+		queueListInterval:  defaultQueueListInterval,
+		maxFrameMessages:   maxFrameMessagesFromEnv(),
+		writeQueueSize:     writeQueueSizeFromEnv(),
 	}
 }
 
@@ -110,6 +152,7 @@ func (wsm *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Requ
 
 	wsm.connectionsMu.Lock()
 	wsm.connections[conn] = make(map[string]context.CancelFunc)
+	wsm.writers[conn] = wsm.startWriter(conn)
 	wsm.connectionsMu.Unlock()
 
 	wsm.sentMessagesMu.Lock()
@@ -133,6 +176,17 @@ func (wsm *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Requ
 		var msg struct {
 			Type     string `json:"type"`
 			QueueURL string `json:"queueUrl"`
+			// Order is "desc" (newest first) or "asc"; empty uses MESSAGE_SORT_ORDER
+			Order string `json:"order"`
+			// AttributeNames limits the system attributes polled; empty means All
+			AttributeNames []string `json:"attributeNames"`
+			// IncludeDepth adds the approximate queue depth to initial_messages
+			IncludeDepth bool `json:"includeDepth"`
+			// VisibilityTimeout overrides the queue's visibility timeout for
+			// each poll; 0 peeks
+			VisibilityTimeout *int32 `json:"visibilityTimeout"`
+			// Limit caps the queues listed by subscribeQueues (default 20)
+			Limit int32 `json:"limit"`
 		}
 
 		if err := conn.ReadJSON(&msg); err != nil {
@@ -147,8 +201,46 @@ func (wsm *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Requ
 			break
 		}
 
-		if msg.Type == "subscribe" && msg.QueueURL != "" {
-			wsm.subscribeToQueue(conn, msg.QueueURL)
+		switch msg.Type {
+		case "subscribe":
+			if msg.QueueURL == "" {
+				continue
+			}
+			attributeNames, err := internal_sqs.ParseAttributeNames(msg.AttributeNames)
+			if err == nil && msg.VisibilityTimeout != nil {
+				err = internal_sqs.ValidateVisibilityTimeout(int(*msg.VisibilityTimeout))
+			}
+			if err != nil {
+				if err := wsm.writeJSON(conn, map[string]interface{}{
+					"type":     "error",
+					"queueUrl": msg.QueueURL,
+					"error":    err.Error(),
+				}); err != nil {
+					log.Printf("Error sending subscribe error: %v", err)
+				}
+				continue
+			}
+			wsm.subscribeToQueue(conn, msg.QueueURL, subscriptionOptions{
+				order:             internal_sqs.ResolveSortOrder(msg.Order),
+				attributeNames:    attributeNames,
+				includeDepth:      msg.IncludeDepth,
+				visibilityTimeout: msg.VisibilityTimeout,
+			})
+		case "subscribeQueues":
+			limit := msg.Limit
+			if limit <= 0 {
+				limit = defaultQueueListLimit
+			}
+			wsm.subscribeQueues(conn, limit)
+		case "unsubscribeQueues":
+			wsm.unsubscribeQueues(conn)
+		case "listSubscriptions":
+			if err := wsm.writeJSON(conn, map[string]interface{}{
+				"type":   "subscriptions",
+				"queues": wsm.subscriptions(conn),
+			}); err != nil {
+				log.Printf("Error sending subscriptions: %v", err)
+			}
 		}
 	}
 }
@@ -162,6 +254,14 @@ func (wsm *WebSocketManager) cleanupConnection(conn *websocket.Conn) {
 		}
 		delete(wsm.connections, conn)
 	}
+	if writer, exists := wsm.writers[conn]; exists {
+		writer.stop()
+		delete(wsm.writers, conn)
+	}
+	if cancel, subscribed := wsm.queueListSubs[conn]; subscribed {
+		cancel()
+		delete(wsm.queueListSubs, conn)
+	}
 	wsm.connectionsMu.Unlock()
 
 	wsm.sentMessagesMu.Lock()
@@ -179,14 +279,56 @@ func (wsm *WebSocketManager) pingConnection(conn *websocket.Conn) {
 	defer ticker.Stop()
 
 	for range ticker.C {
-		if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+		if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsm.writeTimeout)); err != nil {
 			return
 		}
 	}
 }
 
-// subscribeToQueue starts polling the specified queue and streaming messages to the WebSocket connection.
-func (wsm *WebSocketManager) subscribeToQueue(conn *websocket.Conn, queueURL string) {
+// writeJSON sends a frame through the connection's writer, so concurrent
+// pollers never interleave writes and take turns on the socket. It returns
+// once the frame is written.
+func (wsm *WebSocketManager) writeJSON(conn *websocket.Conn, v interface{}) error {
+	wsm.connectionsMu.RLock()
+	writer := wsm.writers[conn]
+	wsm.connectionsMu.RUnlock()
+
+	if writer == nil {
+		return errConnectionClosed
+	}
+	return writer.send(v)
+}
+
+// subscriptions returns the queue URLs the connection is subscribed to, sorted.
+func (wsm *WebSocketManager) subscriptions(conn *websocket.Conn) []string {
+	wsm.connectionsMu.RLock()
+	defer wsm.connectionsMu.RUnlock()
+
+	queues := make([]string, 0, len(wsm.connections[conn]))
+	for queueURL := range wsm.connections[conn] {
+		queues = append(queues, queueURL)
+	}
+	sort.Strings(queues)
+	return queues
+}
+
+// subscriptionOptions are the per-subscription settings from the subscribe frame.
+type subscriptionOptions struct {
+	// order is the message sort order (internal_sqs.SortNewestFirst or SortOldestFirst)
+	order string
+	// attributeNames are the system attributes requested on every poll
+	attributeNames []types.QueueAttributeName
+	// includeDepth adds approximateMessages/approximateInFlight to the
+	// initial_messages frame
+	includeDepth bool
+	// visibilityTimeout overrides the queue's visibility timeout; nil uses
+	// the queue default
+	visibilityTimeout *int32
+}
+
+// subscribeToQueue starts polling the specified queue and streaming messages,
+// per the subscription options, to the WebSocket connection.
+func (wsm *WebSocketManager) subscribeToQueue(conn *websocket.Conn, queueURL string, opts subscriptionOptions) {
 	wsm.connectionsMu.Lock()
 	defer wsm.connectionsMu.Unlock()
 
@@ -206,97 +348,217 @@ func (wsm *WebSocketManager) subscribeToQueue(conn *websocket.Conn, queueURL str
 		ctx, cancel := context.WithCancel(context.Background())
 		queues[queueURL] = cancel
 
-		go wsm.pollQueue(ctx, conn, queueURL)
+		go wsm.pollQueue(ctx, conn, queueURL, opts)
+	}
+}
+
+// endSubscription removes the subscription polled under ctx and cancels it.
+// A subscription already replaced by a resubscribe has its ctx cancelled and
+// is left alone.
+func (wsm *WebSocketManager) endSubscription(ctx context.Context, conn *websocket.Conn, queueURL string) {
+	wsm.connectionsMu.Lock()
+	defer wsm.connectionsMu.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+	if cancel, subscribed := wsm.connections[conn][queueURL]; subscribed {
+		delete(wsm.connections[conn], queueURL)
+		cancel()
 	}
+
+	wsm.sentMessagesMu.Lock()
+	delete(wsm.sentMessages[conn], queueURL)
+	wsm.sentMessagesMu.Unlock()
 }
 
 // pollQueue continuously polls an SQS queue and sends new messages to the WebSocket connection.
-func (wsm *WebSocketManager) pollQueue(ctx context.Context, conn *websocket.Conn, queueURL string) {
-	ticker := time.NewTicker(5 * time.Second)
+func (wsm *WebSocketManager) pollQueue(ctx context.Context, conn *websocket.Conn, queueURL string, opts subscriptionOptions) {
+	ticker := time.NewTicker(wsm.pollInterval)
 	defer ticker.Stop()
 
 	// Send initial load of messages
 	isInitialLoad := true
 
+	// The depth fetch runs alongside the first poll and is only awaited when
+	// the initial frame is written
+	var depth <-chan *queueDepth
+	if opts.includeDepth {
+		depth = wsm.fetchQueueDepth(ctx, queueURL)
+	}
+	initialFrame := func(messages []internal_types.Message) map[string]interface{} {
+		frame := map[string]interface{}{
+			"type":     "initial_messages",
+			"queueUrl": queueURL,
+			"messages": messages,
+		}
+		if depth != nil {
+			if d := <-depth; d != nil {
+				frame["approximateMessages"] = d.messages
+				frame["approximateInFlight"] = d.inFlight
+			}
+			depth = nil
+		}
+		return frame
+	}
+
+	// The queue's default visibility timeout is fetched once, on the first
+	// poll that receives messages, unless the subscription overrides it
+	queueVisibilityTimeout := -1
+	visibilityTimeout := func() int {
+		if opts.visibilityTimeout != nil {
+			return int(*opts.visibilityTimeout)
+		}
+		if queueVisibilityTimeout < 0 {
+			queueVisibilityTimeout = wsm.fetchVisibilityTimeout(ctx, queueURL)
+		}
+		return max(queueVisibilityTimeout, 0)
+	}
+
+	// Consecutive poll errors, and how many backoffs have been issued since
+	// the last successful poll (selects the step in the backoff schedule).
+	// failedPolls counts errors since the last success across backoffs.
+	consecutiveErrors := 0
+	backoffs := 0
+	failedPolls := 0
+
 	// Poll immediately for initial load
 	pollFunc := func() bool {
-		result, err := wsm.sqsClient.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		input := &sqs.ReceiveMessageInput{
 			QueueUrl:            aws.String(queueURL),
 			MaxNumberOfMessages: 10,
 			WaitTimeSeconds:     1,
-			AttributeNames:      []types.QueueAttributeName{types.QueueAttributeNameAll},
-		})
+			AttributeNames:      opts.attributeNames,
+		}
+		if opts.visibilityTimeout != nil {
+			input.VisibilityTimeout = *opts.visibilityTimeout
+		}
+		receivedAt := wsm.handler.Now()
+		result, err := wsm.sqsClient.ReceiveMessage(ctx, input)
 
 		if err != nil {
 			if ctx.Err() != nil {
 				return true // Exit
 			}
 			log.Printf("Error polling queue %s: %v", queueURL, err)
+
+			// A missing or forbidden queue won't recover, and a queue that
+			// keeps failing is given up on, so the client knows to stop
+			failedPolls++
+			if internal_sqs.IsFatalPollError(err) || failedPolls >= wsm.maxPollErrors {
+				wsm.endSubscription(ctx, conn, queueURL)
+				if err := wsm.writeJSON(conn, map[string]interface{}{
+					"type":     "error",
+					"queueUrl": queueURL,
+					"error":    err.Error(),
+					"fatal":    true,
+				}); err != nil {
+					log.Printf("Error sending fatal poll error: %v", err)
+				}
+				return true // Exit
+			}
+
+			consecutiveErrors++
+			if consecutiveErrors < wsm.backoffAfterErrors {
+				return false // Continue
+			}
+
+			delay := wsm.backoffDelay(backoffs)
+			backoffs++
+			consecutiveErrors = 0
+
+			if err := wsm.writeJSON(conn, map[string]interface{}{
+				"type":              "backoff",
+				"queueUrl":          queueURL,
+				"retryAfterSeconds": int(delay / time.Second),
+			}); err != nil {
+				return true // Exit
+			}
+
+			// Pause polling, then restart the ticker so the next poll is a full
+			// interval after resuming
+			select {
+			case <-ctx.Done():
+				return true // Exit
+			case <-time.After(delay):
+			}
+			ticker.Reset(wsm.pollInterval)
 			return false // Continue
 		}
 
+		consecutiveErrors = 0
+		backoffs = 0
+		failedPolls = 0
+
+		// A zero VisibilityTimeout is not sent by the SDK, so a peek is
+		// undone once the messages are received
+		if opts.visibilityTimeout != nil && *opts.visibilityTimeout == 0 {
+			internal_sqs.ReleaseMessages(ctx, wsm.sqsClient, queueURL, result.Messages)
+		}
+
 		if len(result.Messages) > 0 {
 			wsm.sentMessagesMu.RLock()
 			sentMap := wsm.sentMessages[conn][queueURL]
 			wsm.sentMessagesMu.RUnlock()
 
 			messages := []internal_types.Message{}
-			newMessageIds := []string{}
 
 			for _, msg := range result.Messages {
 				messageId := aws.ToString(msg.MessageId)
 
 				// Only include messages we haven't sent before (unless it's the initial load)
 				if isInitialLoad || !sentMap[messageId] {
-					message := internal_types.Message{
-						MessageId:     messageId,
-						Body:          aws.ToString(msg.Body),
-						ReceiptHandle: aws.ToString(msg.ReceiptHandle),
-						Attributes:    make(map[string]string),
-					}
-
-					for k, v := range msg.Attributes {
-						message.Attributes[k] = v
-					}
-
-					messages = append(messages, message)
-					newMessageIds = append(newMessageIds, messageId)
+					messages = append(messages, internal_sqs.ConvertMessage(msg))
 				}
 			}
 
-			// Only send if we have new messages or it's the initial load
+			clockSkewDetected := internal_sqs.SetMessageAges(messages, receivedAt)
+			internal_sqs.SetVisibleAgainAt(messages, receivedAt, visibilityTimeout())
+			internal_sqs.SortMessages(messages, opts.order)
+
+			// Only send if we have new messages or it's the initial load.
+			// Batches over maxFrameMessages are split across frames: the
+			// first keeps the frame type, the rest are "messages" frames, and
+			// all but the last are marked "partial" and the last "final".
 			if len(messages) > 0 {
-				messageType := "messages"
-				if isInitialLoad {
-					messageType = "initial_messages"
-				}
+				chunks := splitMessages(messages, wsm.maxFrameMessages)
+				for i, chunk := range chunks {
+					frame := map[string]interface{}{
+						"type":     "messages",
+						"queueUrl": queueURL,
+						"messages": chunk,
+					}
+					if isInitialLoad && i == 0 {
+						frame = initialFrame(chunk)
+					}
+					frame["clockSkewDetected"] = clockSkewDetected
+					if len(chunks) > 1 {
+						if i < len(chunks)-1 {
+							frame["partial"] = true
+						} else {
+							frame["final"] = true
+						}
+					}
 
-				if err := conn.WriteJSON(map[string]interface{}{
-					"type":     messageType,
-					"queueUrl": queueURL,
-					"messages": messages,
-				}); err != nil {
-					return true // Exit
-				}
+					if err := wsm.writeJSON(conn, frame); err != nil {
+						return true // Exit
+					}
 
-				// Update sent messages tracking
-				wsm.sentMessagesMu.Lock()
-				if wsm.sentMessages[conn] != nil && wsm.sentMessages[conn][queueURL] != nil {
-					for _, id := range newMessageIds {
-						wsm.sentMessages[conn][queueURL][id] = true
+					// Update sent messages tracking
+					wsm.sentMessagesMu.Lock()
+					if wsm.sentMessages[conn] != nil && wsm.sentMessages[conn][queueURL] != nil {
+						for _, message := range chunk {
+							wsm.sentMessages[conn][queueURL][message.MessageId] = true
+						}
 					}
+					wsm.sentMessagesMu.Unlock()
 				}
-				wsm.sentMessagesMu.Unlock()
 			}
 
 			isInitialLoad = false
 		} else if isInitialLoad {
 			// Send empty initial load if no messages
-			if err := conn.WriteJSON(map[string]interface{}{
-				"type":     "initial_messages",
-				"queueUrl": queueURL,
-				"messages": []internal_types.Message{},
-			}); err != nil {
+			if err := wsm.writeJSON(conn, initialFrame([]internal_types.Message{})); err != nil {
 				return true // Exit
 			}
 			isInitialLoad = false
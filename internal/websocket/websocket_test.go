@@ -6,10 +6,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/smithy-go"
+	"github.com/cjunks94/go-sqs-ui/internal/demo"
+	internal_sqs "github.com/cjunks94/go-sqs-ui/internal/sqs"
 	"github.com/cjunks94/go-sqs-ui/test/helpers"
 	"github.com/gorilla/websocket"
 )
@@ -180,6 +185,438 @@ func TestWebSocketManager_SubscribeToQueue(t *testing.T) {
 	}
 }
 
+func TestWebSocketManager_SubscribeSortOrder(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	tests := []struct {
+		name     string
+		envOrder string
+		order    string
+		expected string
+	}{
+		{name: "newest first by default", expected: "msg-3,msg-2,msg-1"},
+		{name: "env default oldest first", envOrder: "asc", expected: "msg-1,msg-2,msg-3"},
+		{name: "subscribe flag overrides env", envOrder: "asc", order: "desc", expected: "msg-3,msg-2,msg-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MESSAGE_SORT_ORDER", tt.envOrder)
+
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			mockClient.AddMessageWithTimestamp(queueURL, "msg-2", "b", "1700000002000")
+			mockClient.AddMessageWithTimestamp(queueURL, "msg-1", "a", "1700000001000")
+			mockClient.AddMessageWithTimestamp(queueURL, "msg-3", "c", "1700000003000")
+
+			wsManager := NewWebSocketManager(mockClient)
+			server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+			defer server.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			if err := conn.WriteJSON(map[string]interface{}{
+				"type":     "subscribe",
+				"queueUrl": queueURL,
+				"order":    tt.order,
+			}); err != nil {
+				t.Fatalf("Failed to subscribe: %v", err)
+			}
+
+			if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+				t.Fatalf("Failed to set read deadline: %v", err)
+			}
+			var frame struct {
+				Type     string `json:"type"`
+				Messages []struct {
+					MessageID string `json:"messageId"`
+				} `json:"messages"`
+			}
+			if err := conn.ReadJSON(&frame); err != nil {
+				t.Fatalf("Failed to read initial messages: %v", err)
+			}
+
+			ids := []string{}
+			for _, msg := range frame.Messages {
+				ids = append(ids, msg.MessageID)
+			}
+			if got := strings.Join(ids, ","); got != tt.expected {
+				t.Errorf("expected order %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWebSocketManager_SubscribeAttributeNames(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected string
+	}{
+		{name: "defaults to all", expected: "All"},
+		{name: "only requested attributes", names: []string{"SentTimestamp", "MessageGroupId"}, expected: "SentTimestamp,MessageGroupId"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			mockClient.AddMessage(queueURL, "msg-1", "a")
+
+			wsManager := NewWebSocketManager(mockClient)
+			server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+			defer server.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			if err := conn.WriteJSON(map[string]interface{}{
+				"type":           "subscribe",
+				"queueUrl":       queueURL,
+				"attributeNames": tt.names,
+			}); err != nil {
+				t.Fatalf("Failed to subscribe: %v", err)
+			}
+
+			if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+				t.Fatalf("Failed to set read deadline: %v", err)
+			}
+			var frame map[string]interface{}
+			if err := conn.ReadJSON(&frame); err != nil {
+				t.Fatalf("Failed to read initial messages: %v", err)
+			}
+
+			calls := mockClient.ReceiveMessageInputs()
+			if len(calls) == 0 {
+				t.Fatal("expected the poller to call ReceiveMessage")
+			}
+			names := []string{}
+			for _, name := range calls[0].AttributeNames {
+				names = append(names, string(name))
+			}
+			if got := strings.Join(names, ","); got != tt.expected {
+				t.Errorf("expected attribute names %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWebSocketManager_SubscribeInvalidAttributeNames(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/orders-dlq"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+
+	wsManager := NewWebSocketManager(mockClient)
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(map[string]interface{}{
+		"type":           "subscribe",
+		"queueUrl":       queueURL,
+		"attributeNames": []string{"SentTimestamp", "Bogus"},
+	}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("Failed to set read deadline: %v", err)
+	}
+	var frame struct {
+		Type     string `json:"type"`
+		QueueURL string `json:"queueUrl"`
+		Error    string `json:"error"`
+	}
+	if err := conn.ReadJSON(&frame); err != nil {
+		t.Fatalf("Failed to read error frame: %v", err)
+	}
+	if frame.Type != "error" || frame.QueueURL != queueURL || !strings.Contains(frame.Error, "Bogus") {
+		t.Errorf("unexpected frame: %+v", frame)
+	}
+}
+
+// failingAttributesClient fails every GetQueueAttributes call.
+type failingAttributesClient struct {
+	*demo.DemoSQSClient
+}
+
+func (c *failingAttributesClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	return nil, fmt.Errorf("access denied")
+}
+
+func TestWebSocketManager_SubscribeIncludeDepth(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+
+	demoClient := demo.NewDemoSQSClient()
+	attrs, err := demoClient.GetQueueAttributes(context.Background(), &sqs.GetQueueAttributesInput{QueueUrl: &queueURL})
+	if err != nil {
+		t.Fatalf("Failed to read demo attributes: %v", err)
+	}
+	expectedMessages, _ := strconv.Atoi(attrs.Attributes["ApproximateNumberOfMessages"])
+
+	tests := []struct {
+		name         string
+		client       internal_sqs.SQSClientInterface
+		includeDepth bool
+		expectDepth  bool
+	}{
+		{name: "depth requested", client: demoClient, includeDepth: true, expectDepth: true},
+		{name: "depth not requested", client: demo.NewDemoSQSClient()},
+		{name: "attribute fetch fails", client: &failingAttributesClient{demo.NewDemoSQSClient()}, includeDepth: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wsManager := NewWebSocketManager(tt.client)
+			server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+			defer server.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			if err := conn.WriteJSON(map[string]interface{}{
+				"type":         "subscribe",
+				"queueUrl":     queueURL,
+				"includeDepth": tt.includeDepth,
+			}); err != nil {
+				t.Fatalf("Failed to subscribe: %v", err)
+			}
+
+			if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+				t.Fatalf("Failed to set read deadline: %v", err)
+			}
+			var frame map[string]interface{}
+			if err := conn.ReadJSON(&frame); err != nil {
+				t.Fatalf("Failed to read initial messages: %v", err)
+			}
+
+			if frame["type"] != "initial_messages" {
+				t.Fatalf("expected initial_messages, got %v", frame["type"])
+			}
+			if messages, _ := frame["messages"].([]interface{}); len(messages) == 0 {
+				t.Errorf("expected the initial frame to carry messages")
+			}
+
+			messages, hasMessages := frame["approximateMessages"]
+			inFlight, hasInFlight := frame["approximateInFlight"]
+			if !tt.expectDepth {
+				if hasMessages || hasInFlight {
+					t.Errorf("expected no depth fields, got %v / %v", messages, inFlight)
+				}
+				return
+			}
+			if messages != float64(expectedMessages) {
+				t.Errorf("expected approximateMessages %d, got %v", expectedMessages, messages)
+			}
+			if inFlight != float64(0) {
+				t.Errorf("expected approximateInFlight 0, got %v", inFlight)
+			}
+		})
+	}
+}
+
+func TestWebSocketManager_SubscribeQueues(t *testing.T) {
+	const addedQueueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/demo-refunds-queue"
+
+	demoClient := demo.NewDemoSQSClient()
+	wsManager := NewWebSocketManager(demoClient)
+	wsManager.queueListInterval = 20 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "subscribeQueues"}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	readQueues := func() map[string]float64 {
+		t.Helper()
+		if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatalf("Failed to set read deadline: %v", err)
+		}
+		var frame struct {
+			Type   string `json:"type"`
+			Queues []struct {
+				URL                 string  `json:"url"`
+				ApproximateMessages float64 `json:"approximateMessages"`
+			} `json:"queues"`
+		}
+		if err := conn.ReadJSON(&frame); err != nil {
+			t.Fatalf("Failed to read queues frame: %v", err)
+		}
+		if frame.Type != "queues" {
+			t.Fatalf("expected a queues frame, got %q", frame.Type)
+		}
+		depths := make(map[string]float64, len(frame.Queues))
+		for _, queue := range frame.Queues {
+			depths[queue.URL] = queue.ApproximateMessages
+		}
+		return depths
+	}
+
+	initial := readQueues()
+	if len(initial) == 0 {
+		t.Fatal("expected the initial frame to list queues")
+	}
+	if _, listed := initial[addedQueueURL]; listed {
+		t.Fatalf("did not expect %s before it was added", addedQueueURL)
+	}
+	// The default tag filter hides the dev notifications queue
+	if _, listed := initial["https://sqs.us-east-1.amazonaws.com/123456789012/demo-notifications-queue"]; listed {
+		t.Error("expected the tag filter to hide demo-notifications-queue")
+	}
+
+	// Unchanged polls send nothing, so the next frame is the one reflecting
+	// the new queue rather than a repeat of the first
+	time.Sleep(5 * wsManager.queueListInterval)
+	demoClient.AddQueue(addedQueueURL, map[string]string{
+		"businessunit": "degrees", "product": "amt", "env": "stg",
+	})
+
+	updated := readQueues()
+	if depth, listed := updated[addedQueueURL]; !listed || depth != 0 {
+		t.Errorf("expected %s with depth 0 in the updated frame, got listed=%v depth=%v", addedQueueURL, listed, depth)
+	}
+	if len(updated) != len(initial)+1 {
+		t.Errorf("expected %d queues after the add, got %d", len(initial)+1, len(updated))
+	}
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "unsubscribeQueues"}); err != nil {
+		t.Fatalf("Failed to unsubscribe: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		wsManager.connectionsMu.RLock()
+		subscribed := len(wsManager.queueListSubs)
+		wsManager.connectionsMu.RUnlock()
+		if subscribed == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("expected unsubscribeQueues to stop the queue list poller")
+}
+
+func TestWebSocketManager_SubscribeQueues_UsesHandler(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/demo-orders-queue"
+	body := "cached"
+
+	demoClient := demo.NewDemoSQSClient()
+	handler := &internal_sqs.SQSHandler{Client: demoClient}
+	handler.EnableAttributeCache()
+	wsManager := NewWebSocketManagerForHandler(handler)
+	wsManager.queueListInterval = 20 * time.Millisecond
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "subscribeQueues"}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("Failed to set read deadline: %v", err)
+	}
+	var frame map[string]interface{}
+	if err := conn.ReadJSON(&frame); err != nil || frame["type"] != "queues" {
+		t.Fatalf("expected a queues frame, got %v (%v)", frame, err)
+	}
+
+	// The handler's attribute cache still holds the old depth, so a poller
+	// sharing it has no change to report
+	if _, err := demoClient.SendMessage(context.Background(), &sqs.SendMessageInput{
+		QueueUrl:    &queueURL,
+		MessageBody: &body,
+	}); err != nil {
+		t.Fatalf("Failed to send: %v", err)
+	}
+	if err := conn.SetReadDeadline(time.Now().Add(10 * wsManager.queueListInterval)); err != nil {
+		t.Fatalf("Failed to set read deadline: %v", err)
+	}
+	if err := conn.ReadJSON(&frame); err == nil {
+		t.Errorf("expected no frame while the depth is cached, got %v", frame)
+	}
+}
+
+func TestWebSocketManager_ListSubscriptions(t *testing.T) {
+	ordersURL := "https://sqs.us-east-1.amazonaws.com/123456789012/orders"
+	paymentsURL := "https://sqs.us-east-1.amazonaws.com/123456789012/payments"
+
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(ordersURL)
+	mockClient.AddQueue(paymentsURL)
+
+	wsManager := NewWebSocketManager(mockClient)
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	for _, frame := range []map[string]interface{}{
+		{"type": "subscribe", "queueUrl": paymentsURL},
+		{"type": "subscribe", "queueUrl": ordersURL},
+		{"type": "listSubscriptions"},
+	} {
+		if err := conn.WriteJSON(frame); err != nil {
+			t.Fatalf("Failed to send %v: %v", frame["type"], err)
+		}
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+		t.Fatalf("Failed to set read deadline: %v", err)
+	}
+
+	// Skip the initial_messages frames from the two subscriptions
+	for {
+		var frame struct {
+			Type   string   `json:"type"`
+			Queues []string `json:"queues"`
+		}
+		if err := conn.ReadJSON(&frame); err != nil {
+			t.Fatalf("Failed to read subscriptions frame: %v", err)
+		}
+		if frame.Type != "subscriptions" {
+			continue
+		}
+
+		if got := strings.Join(frame.Queues, ","); got != ordersURL+","+paymentsURL {
+			t.Errorf("expected both subscribed queues, got %v", frame.Queues)
+		}
+		return
+	}
+}
+
 func TestWebSocketManager_PingPong(t *testing.T) {
 	t.Skip("Ping-pong test is flaky due to timing - ping handler works in practice")
 }
@@ -217,6 +654,453 @@ func TestWebSocketManager_InvalidMessage(t *testing.T) {
 	}
 }
 
+func TestWebSocketManager_BackoffAfterConsecutiveErrors(t *testing.T) {
+	t.Setenv("WS_BACKOFF_AFTER_ERRORS", "3")
+	t.Setenv("WS_BACKOFF_SCHEDULE", "7,20")
+
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/throttled-queue"
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+	mockClient.SetError("ReceiveMessage", fmt.Errorf("ThrottlingException: rate exceeded"))
+
+	wsManager := NewWebSocketManager(mockClient)
+	wsManager.pollInterval = 10 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http")
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Logf("Error closing WebSocket connection: %v", err)
+		}
+	}()
+
+	if err := conn.WriteJSON(map[string]interface{}{
+		"type":     "subscribe",
+		"queueUrl": queueURL,
+	}); err != nil {
+		t.Fatalf("Failed to send subscribe message: %v", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("Failed to set read deadline: %v", err)
+	}
+
+	var frame map[string]interface{}
+	if err := conn.ReadJSON(&frame); err != nil {
+		t.Fatalf("Expected a backoff frame, got error: %v", err)
+	}
+
+	if frame["type"] != "backoff" {
+		t.Errorf("Expected type 'backoff', got %v", frame["type"])
+	}
+	if frame["queueUrl"] != queueURL {
+		t.Errorf("Expected queueUrl %s, got %v", queueURL, frame["queueUrl"])
+	}
+	if frame["retryAfterSeconds"] != float64(7) {
+		t.Errorf("Expected retryAfterSeconds 7, got %v", frame["retryAfterSeconds"])
+	}
+}
+
+func TestWebSocketManager_FatalPollErrorEndsSubscription(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		err  error
+	}{
+		{
+			name: "access denied ends it immediately",
+			err:  &smithy.GenericAPIError{Code: "AccessDenied", Message: "not authorized"},
+		},
+		{
+			name: "transient errors end it after WS_MAX_POLL_ERRORS",
+			env:  map[string]string{"WS_MAX_POLL_ERRORS": "4", "WS_BACKOFF_AFTER_ERRORS": "10"},
+			err:  fmt.Errorf("ThrottlingException: rate exceeded"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/forbidden-queue"
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			mockClient.SetError("ReceiveMessage", tt.err)
+
+			wsManager := NewWebSocketManager(mockClient)
+			wsManager.pollInterval = 10 * time.Millisecond
+
+			server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+			defer server.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			if err := conn.WriteJSON(map[string]interface{}{
+				"type":     "subscribe",
+				"queueUrl": queueURL,
+			}); err != nil {
+				t.Fatalf("Failed to subscribe: %v", err)
+			}
+
+			conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+			var frame map[string]interface{}
+			if err := conn.ReadJSON(&frame); err != nil {
+				t.Fatalf("Expected a fatal error frame, got error: %v", err)
+			}
+			if frame["type"] != "error" || frame["fatal"] != true || frame["queueUrl"] != queueURL {
+				t.Fatalf("Expected a fatal error frame for the queue, got %v", frame)
+			}
+
+			// The subscription is gone and the poller has stopped
+			if err := conn.WriteJSON(map[string]interface{}{"type": "listSubscriptions"}); err != nil {
+				t.Fatalf("Failed to list subscriptions: %v", err)
+			}
+			if err := conn.ReadJSON(&frame); err != nil {
+				t.Fatalf("Failed to read subscriptions: %v", err)
+			}
+			if queues, _ := frame["queues"].([]interface{}); frame["type"] != "subscriptions" || len(queues) != 0 {
+				t.Errorf("Expected no subscriptions, got %v", frame)
+			}
+			polls := len(mockClient.ReceiveMessageInputs())
+			time.Sleep(100 * time.Millisecond)
+			if more := len(mockClient.ReceiveMessageInputs()); more != polls {
+				t.Errorf("Expected the poller to exit, but it polled %d more times", more-polls)
+			}
+		})
+	}
+}
+
+func TestMaxPollErrorsFromEnv(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", defaultMaxPollErrors},
+		{"5", 5},
+		{"0", defaultMaxPollErrors},
+		{"abc", defaultMaxPollErrors},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("WS_MAX_POLL_ERRORS", tt.value)
+		if got := maxPollErrorsFromEnv(); got != tt.expected {
+			t.Errorf("%q: expected %d, got %d", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestWebSocketManager_DisconnectsSlowConsumer(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/bulky-queue"
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+	// One ~20 MB frame is far more than the socket buffers hold
+	body := strings.Repeat("x", 2<<20)
+	for i := 1; i <= 10; i++ {
+		mockClient.AddMessage(queueURL, fmt.Sprintf("msg-%d", i), body)
+	}
+
+	wsManager := NewWebSocketManager(mockClient)
+	wsManager.writeTimeout = 200 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	// Subscribe, then never read
+	if err := conn.WriteJSON(map[string]interface{}{
+		"type":     "subscribe",
+		"queueUrl": queueURL,
+	}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		wsManager.connectionsMu.RLock()
+		open := len(wsManager.connections)
+		wsManager.connectionsMu.RUnlock()
+		if open == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the slow consumer to be disconnected")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestWebSocketManager_SplitsLargeInitialLoad(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/backlog-queue"
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(queueURL)
+	for i := 1; i <= 120; i++ {
+		mockClient.AddMessage(queueURL, fmt.Sprintf("msg-%d", i), "backlog")
+	}
+
+	wsManager := NewWebSocketManager(mockClient)
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(map[string]interface{}{
+		"type":     "subscribe",
+		"queueUrl": queueURL,
+	}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	var frames []map[string]interface{}
+	seen := map[string]bool{}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var frame map[string]interface{}
+		if err := conn.ReadJSON(&frame); err != nil {
+			t.Fatalf("Failed to read frame: %v", err)
+		}
+		frames = append(frames, frame)
+		messages, _ := frame["messages"].([]interface{})
+		for _, msg := range messages {
+			seen[msg.(map[string]interface{})["messageId"].(string)] = true
+		}
+		if frame["final"] == true {
+			break
+		}
+		if frame["partial"] != true {
+			t.Fatalf("Expected a partial frame before the final one, got %v", frame)
+		}
+	}
+
+	// 120 messages at the default cap of 50 arrive as 50 + 50 + 20
+	if len(frames) != 3 {
+		t.Fatalf("Expected 3 frames, got %d", len(frames))
+	}
+	if frames[0]["type"] != "initial_messages" || frames[1]["type"] != "messages" {
+		t.Errorf("Expected initial_messages then messages frames, got %v and %v", frames[0]["type"], frames[1]["type"])
+	}
+	if len(seen) != 120 {
+		t.Errorf("Expected 120 distinct messages across frames, got %d", len(seen))
+	}
+
+	wsManager.sentMessagesMu.RLock()
+	defer wsManager.sentMessagesMu.RUnlock()
+	for _, sent := range wsManager.sentMessages {
+		if len(sent[queueURL]) != 120 {
+			t.Errorf("Expected all 120 messages tracked as sent, got %d", len(sent[queueURL]))
+		}
+	}
+}
+
+func TestMaxFrameMessagesFromEnv(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"", defaultMaxFrameMessages},
+		{"25", 25},
+		{"0", defaultMaxFrameMessages},
+		{"abc", defaultMaxFrameMessages},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("WS_MAX_FRAME_MESSAGES", tt.value)
+		if got := maxFrameMessagesFromEnv(); got != tt.expected {
+			t.Errorf("%q: expected %d, got %d", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestWebSocketManager_SubscribeVisibleAgainAt(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name              string
+		visibilityTimeout interface{}
+		expectVisibleAt   bool
+	}{
+		{"queue default applies", nil, true},
+		{"override applies", 60, true},
+		{"peek leaves it unset and releases the message", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			mockClient.AddMessage(queueURL, "msg-1", "a")
+
+			wsManager := NewWebSocketManager(mockClient)
+			server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+			defer server.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			subscribe := map[string]interface{}{"type": "subscribe", "queueUrl": queueURL}
+			if tt.visibilityTimeout != nil {
+				subscribe["visibilityTimeout"] = tt.visibilityTimeout
+			}
+			if err := conn.WriteJSON(subscribe); err != nil {
+				t.Fatalf("Failed to subscribe: %v", err)
+			}
+
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			var frame struct {
+				Type     string `json:"type"`
+				Messages []struct {
+					VisibleAgainAt *int64 `json:"visibleAgainAt"`
+				} `json:"messages"`
+			}
+			if err := conn.ReadJSON(&frame); err != nil {
+				t.Fatalf("Failed to read frame: %v", err)
+			}
+			if frame.Type != "initial_messages" || len(frame.Messages) != 1 {
+				t.Fatalf("Expected one initial message, got %+v", frame)
+			}
+			if got := frame.Messages[0].VisibleAgainAt != nil; got != tt.expectVisibleAt {
+				t.Errorf("Expected visibleAgainAt present %v, got %v", tt.expectVisibleAt, got)
+			}
+			if tt.expectVisibleAt && *frame.Messages[0].VisibleAgainAt <= time.Now().UnixMilli() {
+				t.Errorf("Expected visibleAgainAt in the future, got %d", *frame.Messages[0].VisibleAgainAt)
+			}
+
+			// Only a peek makes the received message visible again
+			released := mockClient.ChangeMessageVisibilityInputs()
+			if peek := !tt.expectVisibleAt; peek != (len(released) > 0) {
+				t.Errorf("Expected release %v, got %+v", peek, released)
+			}
+			for _, call := range released {
+				if call.ReceiptHandle != "receipt-msg-1" || call.VisibilityTimeout != 0 {
+					t.Errorf("Unexpected release %+v", call)
+				}
+			}
+		})
+	}
+}
+
+func TestWebSocketManager_SubscribeClockSkew(t *testing.T) {
+	queueURL := "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	tests := []struct {
+		name       string
+		sentOffset time.Duration
+		expectSkew bool
+	}{
+		{"sent in the past", -time.Minute, false},
+		{"sent an hour ahead", time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := helpers.NewMockSQSClient()
+			mockClient.AddQueue(queueURL)
+			sent := time.Now().Add(tt.sentOffset).UnixMilli()
+			mockClient.AddMessageWithTimestamp(queueURL, "msg-1", "a", strconv.FormatInt(sent, 10))
+
+			wsManager := NewWebSocketManager(mockClient)
+			server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+			defer server.Close()
+
+			conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+			if err != nil {
+				t.Fatalf("Failed to connect: %v", err)
+			}
+			defer conn.Close()
+
+			if err := conn.WriteJSON(map[string]interface{}{"type": "subscribe", "queueUrl": queueURL}); err != nil {
+				t.Fatalf("Failed to subscribe: %v", err)
+			}
+
+			conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+			var frame struct {
+				Type              string `json:"type"`
+				ClockSkewDetected bool   `json:"clockSkewDetected"`
+				Messages          []struct {
+					AgeSeconds *int64 `json:"ageSeconds"`
+				} `json:"messages"`
+			}
+			if err := conn.ReadJSON(&frame); err != nil {
+				t.Fatalf("Failed to read frame: %v", err)
+			}
+			if frame.Type != "initial_messages" || len(frame.Messages) != 1 {
+				t.Fatalf("Expected one initial message, got %+v", frame)
+			}
+			if frame.ClockSkewDetected != tt.expectSkew {
+				t.Errorf("Expected clockSkewDetected %v, got %v", tt.expectSkew, frame.ClockSkewDetected)
+			}
+			if frame.Messages[0].AgeSeconds == nil {
+				t.Error("Expected ageSeconds on the streamed message")
+			}
+		})
+	}
+}
+
+func TestWriteTimeoutFromEnv(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", defaultWriteTimeout},
+		{"3", 3 * time.Second},
+		{"0", defaultWriteTimeout},
+		{"abc", defaultWriteTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("WS_WRITE_TIMEOUT_SECONDS", tt.value)
+		if got := writeTimeoutFromEnv(); got != tt.expected {
+			t.Errorf("%q: expected %v, got %v", tt.value, tt.expected, got)
+		}
+	}
+}
+
+func TestBackoffScheduleFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []time.Duration
+	}{
+		{"unset uses default", "", defaultBackoffSchedule},
+		{"custom schedule", "5, 15,45", []time.Duration{5 * time.Second, 15 * time.Second, 45 * time.Second}},
+		{"invalid entries skipped", "5,abc,-1,30", []time.Duration{5 * time.Second, 30 * time.Second}},
+		{"all invalid uses default", "abc,0", defaultBackoffSchedule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("WS_BACKOFF_SCHEDULE", tt.value)
+			got := backoffScheduleFromEnv()
+			if fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestCheckOrigin_AllowsEmptyOrigin(t *testing.T) {
 	req := httptest.NewRequest("GET", "/ws", nil)
 	// No Origin header = same-origin request
@@ -372,3 +1256,111 @@ func BenchmarkWebSocketManager_MessageProcessing(b *testing.B) {
 		}
 	}
 }
+
+func TestWebSocketManager_ReadOnlyKeepsStreaming(t *testing.T) {
+	t.Setenv("READ_ONLY", "true")
+
+	wsManager := NewWebSocketManager(helpers.NewMockSQSClient())
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "listSubscriptions"}); err != nil {
+		t.Fatalf("Failed to send frame: %v", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(2 * time.Second)); err != nil {
+		t.Fatalf("Failed to set read deadline: %v", err)
+	}
+	var frame map[string]interface{}
+	if err := conn.ReadJSON(&frame); err != nil {
+		t.Fatalf("Failed to read frame: %v", err)
+	}
+	if frame["type"] != "subscriptions" {
+		t.Errorf("expected a subscriptions frame in read-only mode, got %v", frame)
+	}
+}
+
+func TestWebSocketManager_QuietQueueNotStarvedByBusyQueue(t *testing.T) {
+	t.Setenv("WS_MAX_FRAME_MESSAGES", "1")
+
+	busyURL := "https://sqs.us-east-1.amazonaws.com/123456789012/busy-queue"
+	quietURL := "https://sqs.us-east-1.amazonaws.com/123456789012/quiet-queue"
+	mockClient := helpers.NewMockSQSClient()
+	mockClient.AddQueue(busyURL)
+	mockClient.AddQueue(quietURL)
+	// One 16 KB message per frame makes the busy queue's initial load 500
+	// frames, far more than the socket buffers hold
+	body := strings.Repeat("b", 16<<10)
+	for i := 1; i <= 500; i++ {
+		mockClient.AddMessage(busyURL, fmt.Sprintf("busy-%d", i), body)
+	}
+	mockClient.AddMessage(quietURL, "quiet-1", "quiet")
+
+	wsManager := NewWebSocketManager(mockClient)
+	server := httptest.NewServer(http.HandlerFunc(wsManager.HandleWebSocket))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteJSON(map[string]interface{}{"type": "subscribe", "queueUrl": busyURL}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var frame map[string]interface{}
+	if err := conn.ReadJSON(&frame); err != nil || frame["queueUrl"] != busyURL {
+		t.Fatalf("Expected the busy queue's first frame, got %v (%v)", frame, err)
+	}
+
+	// Subscribe the quiet queue while the busy one is still streaming
+	if err := conn.WriteJSON(map[string]interface{}{"type": "subscribe", "queueUrl": quietURL}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	start := time.Now()
+	busyFrames := 0
+	for {
+		frame = nil
+		if err := conn.ReadJSON(&frame); err != nil {
+			t.Fatalf("Failed to read frame after %d busy frames: %v", busyFrames, err)
+		}
+		if frame["queueUrl"] == quietURL {
+			break
+		}
+		busyFrames++
+		// A slow reader keeps the busy queue's frames backed up
+		time.Sleep(time.Millisecond)
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the quiet queue's frame within 2s, took %v", elapsed)
+	}
+	// The busy queue was far from done, so the quiet frame was interleaved
+	if busyFrames >= 499 {
+		t.Errorf("Expected the quiet queue's frame before the busy queue finished, got it after %d busy frames", busyFrames)
+	}
+}
+
+func TestWriteQueueSizeFromEnv(t *testing.T) {
+	t.Setenv("WS_WRITE_QUEUE_SIZE", "")
+	if got := writeQueueSizeFromEnv(); got != defaultWriteQueueSize {
+		t.Errorf("expected default %d, got %d", defaultWriteQueueSize, got)
+	}
+	t.Setenv("WS_WRITE_QUEUE_SIZE", "4")
+	if got := writeQueueSizeFromEnv(); got != 4 {
+		t.Errorf("expected 4, got %d", got)
+	}
+	t.Setenv("WS_WRITE_QUEUE_SIZE", "0")
+	if got := writeQueueSizeFromEnv(); got != defaultWriteQueueSize {
+		t.Errorf("expected default for 0, got %d", got)
+	}
+}
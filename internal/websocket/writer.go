@@ -0,0 +1,100 @@
+package websocket
+
+import (
+	"errors"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// defaultWriteQueueSize bounds the frames waiting for a connection's writer.
+const defaultWriteQueueSize = 16
+
+// writeQueueSizeFromEnv reads WS_WRITE_QUEUE_SIZE, falling back to the
+// default for missing or non-positive values.
+func writeQueueSizeFromEnv() int {
+	if n, err := strconv.Atoi(os.Getenv("WS_WRITE_QUEUE_SIZE")); err == nil && n > 0 {
+		return n
+	}
+	return defaultWriteQueueSize
+}
+
+// errConnectionClosed is returned for frames sent after the connection's
+// writer stopped.
+var errConnectionClosed = errors.New("websocket connection closed")
+
+// queuedFrame is a frame waiting for the writer, with where to report the
+// write's outcome.
+type queuedFrame struct {
+	v    interface{}
+	done chan error
+}
+
+// connWriter funnels every frame of one connection through a bounded queue
+// to a single writer goroutine. Senders wait for their own frame to be
+// written, so each poller has at most one frame queued and the queue's FIFO
+// order serves subscriptions round-robin: a busy queue's stream of frames
+// cannot hold the socket while a quiet queue's frame waits.
+type connWriter struct {
+	frames chan queuedFrame
+	closed chan struct{}
+}
+
+// startWriter starts the writer goroutine for conn.
+func (wsm *WebSocketManager) startWriter(conn *websocket.Conn) *connWriter {
+	writer := &connWriter{
+		frames: make(chan queuedFrame, wsm.writeQueueSize),
+		closed: make(chan struct{}),
+	}
+	go func() {
+		for {
+			select {
+			case frame := <-writer.frames:
+				frame.done <- wsm.writeFrame(conn, frame.v)
+			case <-writer.closed:
+				return
+			}
+		}
+	}()
+	return writer
+}
+
+// stop ends the writer goroutine; frames still queued fail with
+// errConnectionClosed.
+func (cw *connWriter) stop() {
+	close(cw.closed)
+}
+
+// send queues v and waits until it is written or the writer stops.
+func (cw *connWriter) send(v interface{}) error {
+	done := make(chan error, 1)
+	select {
+	case cw.frames <- queuedFrame{v: v, done: done}:
+	case <-cw.closed:
+		return errConnectionClosed
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-cw.closed:
+		return errConnectionClosed
+	}
+}
+
+// writeFrame writes one frame with the manager's write timeout; on failure
+// the connection is closed, which ends the read loop and tears down its
+// subscriptions.
+func (wsm *WebSocketManager) writeFrame(conn *websocket.Conn, v interface{}) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(wsm.writeTimeout)); err != nil {
+		return err
+	}
+	if err := conn.WriteJSON(v); err != nil {
+		log.Printf("WebSocket write failed, disconnecting: %v", err)
+		conn.Close()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+import { describe, it, expect, afterEach } from 'vitest';
+
+import { withBasePath } from '../internal/static/files/modules/basePath.js';
+
+describe('withBasePath', () => {
+  afterEach(() => {
+    delete window.SQS_UI_BASE_PATH;
+  });
+
+  it('should leave paths unchanged when served at the root', () => {
+    expect(withBasePath('/api/queues')).toBe('/api/queues');
+  });
+
+  it('should prefix paths with the published base path', () => {
+    window.SQS_UI_BASE_PATH = '/sqs-ui';
+    expect(withBasePath('/api/queues')).toBe('/sqs-ui/api/queues');
+    expect(withBasePath('/ws')).toBe('/sqs-ui/ws');
+  });
+});
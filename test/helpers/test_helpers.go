@@ -4,6 +4,7 @@ package helpers
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
@@ -12,8 +13,9 @@ import (
 
 // SendMessageCall records the arguments of a SendMessage invocation for assertion.
 type SendMessageCall struct {
-	QueueURL string
-	Body     string
+	QueueURL          string
+	Body              string
+	MessageAttributes map[string]types.MessageAttributeValue
 }
 
 // DeleteMessageCall records the arguments of a DeleteMessage invocation for assertion.
@@ -22,13 +24,32 @@ type DeleteMessageCall struct {
 	ReceiptHandle string
 }
 
+// ChangeMessageVisibilityCall records the arguments of a
+// ChangeMessageVisibility invocation for assertion.
+type ChangeMessageVisibilityCall struct {
+	QueueURL          string
+	ReceiptHandle     string
+	VisibilityTimeout int32
+}
+
 // MockSQSClient implements the SQSClientInterface for testing with configurable mock data.
 type MockSQSClient struct {
-	queues             []string
-	messages           map[string][]types.Message
-	errors             map[string]error
+	// mu guards the queues, messages and recorded calls; WebSocket pollers
+	// and handlers call the mock concurrently
+	mu       sync.Mutex
+	queues   []string
+	messages map[string][]types.Message
+	errors   map[string]error
+	// depths overrides the ApproximateNumberOfMessages reported per queue
+	depths             map[string]int
 	SendMessageCalls   []SendMessageCall
 	DeleteMessageCalls []DeleteMessageCall
+	// ReceiveMessageCalls records a copy of every ReceiveMessage input.
+	ReceiveMessageCalls []sqs.ReceiveMessageInput
+	// SendMessageBatchCalls records a copy of every SendMessageBatch input.
+	SendMessageBatchCalls []sqs.SendMessageBatchInput
+	// ChangeMessageVisibilityCalls records every ChangeMessageVisibility.
+	ChangeMessageVisibilityCalls []ChangeMessageVisibilityCall
 }
 
 // NewMockSQSClient creates a new mock SQS client for testing.
@@ -37,6 +58,7 @@ func NewMockSQSClient() *MockSQSClient {
 		queues:             []string{},
 		messages:           make(map[string][]types.Message),
 		errors:             make(map[string]error),
+		depths:             make(map[string]int),
 		SendMessageCalls:   []SendMessageCall{},
 		DeleteMessageCalls: []DeleteMessageCall{},
 	}
@@ -44,12 +66,24 @@ func NewMockSQSClient() *MockSQSClient {
 
 // AddQueue adds a queue URL to the mock client's queue list.
 func (m *MockSQSClient) AddQueue(url string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.queues = append(m.queues, url)
 	if m.messages[url] == nil {
 		m.messages[url] = []types.Message{}
 	}
 }
 
+// SetQueueDepth sets the ApproximateNumberOfMessages GetQueueAttributes
+// reports for a queue (otherwise "5").
+func (m *MockSQSClient) SetQueueDepth(queueURL string, depth int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.depths[queueURL] = depth
+}
+
 // AddMessage adds a test message to the specified queue.
 func (m *MockSQSClient) AddMessage(queueURL, messageID, body string) {
 	m.AddMessageWithTimestamp(queueURL, messageID, body, "1640995200000")
@@ -58,6 +92,9 @@ func (m *MockSQSClient) AddMessage(queueURL, messageID, body string) {
 // AddMessageWithTimestamp adds a message with an explicit SentTimestamp, letting
 // tests control ordering (GetMessages sorts on SentTimestamp before paginating).
 func (m *MockSQSClient) AddMessageWithTimestamp(queueURL, messageID, body, sentTimestamp string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	msg := types.Message{
 		MessageId:     aws.String(messageID),
 		Body:          aws.String(body),
@@ -71,22 +108,31 @@ func (m *MockSQSClient) AddMessageWithTimestamp(queueURL, messageID, body, sentT
 
 // SetError configures the mock client to return an error for a specific operation.
 func (m *MockSQSClient) SetError(operation string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.errors[operation] = err
 }
 
 // ListQueues returns the mock list of queues.
 func (m *MockSQSClient) ListQueues(ctx context.Context, params *sqs.ListQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListQueuesOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if err, exists := m.errors["ListQueues"]; exists {
 		return nil, err
 	}
 
 	return &sqs.ListQueuesOutput{
-		QueueUrls: m.queues,
+		QueueUrls: append([]string(nil), m.queues...),
 	}, nil
 }
 
 // ListQueueTags returns mock queue tags for testing tag-based filtering.
 func (m *MockSQSClient) ListQueueTags(ctx context.Context, params *sqs.ListQueueTagsInput, optFns ...func(*sqs.Options)) (*sqs.ListQueueTagsOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if err, exists := m.errors["ListQueueTags"]; exists {
 		return nil, err
 	}
@@ -103,6 +149,9 @@ func (m *MockSQSClient) ListQueueTags(ctx context.Context, params *sqs.ListQueue
 
 // GetQueueAttributes returns mock queue attributes including ARN and message counts.
 func (m *MockSQSClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput, optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if err, exists := m.errors["GetQueueAttributes"]; exists {
 		return nil, err
 	}
@@ -118,18 +167,37 @@ func (m *MockSQSClient) GetQueueAttributes(ctx context.Context, params *sqs.GetQ
 		}
 	}
 
+	depth := "5"
+	if d, ok := m.depths[queueURL]; ok {
+		depth = fmt.Sprintf("%d", d)
+	}
+
 	return &sqs.GetQueueAttributesOutput{
 		Attributes: map[string]string{
 			"QueueArn":                    fmt.Sprintf("arn:aws:sqs:us-east-1:123456789012:%s", queueName),
-			"ApproximateNumberOfMessages": "5",
+			"ApproximateNumberOfMessages": depth,
 			"MessageRetentionPeriod":      "1209600",
 			"VisibilityTimeout":           "30",
 		},
 	}, nil
 }
 
+// ReceiveMessageInputs returns a snapshot of the recorded ReceiveMessage
+// inputs, safe to call while pollers are still running.
+func (m *MockSQSClient) ReceiveMessageInputs() []sqs.ReceiveMessageInput {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]sqs.ReceiveMessageInput(nil), m.ReceiveMessageCalls...)
+}
+
 // ReceiveMessage returns mock messages from the specified queue, supporting pagination testing.
 func (m *MockSQSClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ReceiveMessageCalls = append(m.ReceiveMessageCalls, *params)
+
 	if err, exists := m.errors["ReceiveMessage"]; exists {
 		return nil, err
 	}
@@ -151,20 +219,26 @@ func (m *MockSQSClient) ReceiveMessage(ctx context.Context, params *sqs.ReceiveM
 		// Otherwise return all messages for pagination testing
 	}
 
-	if maxMessages > len(messages) {
-		maxMessages = len(messages)
-	}
+	maxMessages = max(0, min(maxMessages, len(messages)))
+
+	// Return a copy: DeleteMessage shifts the backing array in place
+	received := make([]types.Message, maxMessages)
+	copy(received, messages[:maxMessages])
 
 	return &sqs.ReceiveMessageOutput{
-		Messages: messages[:maxMessages],
+		Messages: received,
 	}, nil
 }
 
 // SendMessage simulates sending a message and returns a mock message ID.
 func (m *MockSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.SendMessageCalls = append(m.SendMessageCalls, SendMessageCall{
-		QueueURL: aws.ToString(params.QueueUrl),
-		Body:     aws.ToString(params.MessageBody),
+		QueueURL:          aws.ToString(params.QueueUrl),
+		Body:              aws.ToString(params.MessageBody),
+		MessageAttributes: params.MessageAttributes,
 	})
 
 	if err, exists := m.errors["SendMessage"]; exists {
@@ -177,8 +251,92 @@ func (m *MockSQSClient) SendMessage(ctx context.Context, params *sqs.SendMessage
 	}, nil
 }
 
+// SendMessageBatch records the batch and reports every entry as successful.
+func (m *MockSQSClient) SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.SendMessageBatchCalls = append(m.SendMessageBatchCalls, *params)
+
+	if err, exists := m.errors["SendMessageBatch"]; exists {
+		return nil, err
+	}
+
+	output := &sqs.SendMessageBatchOutput{}
+	for _, entry := range params.Entries {
+		output.Successful = append(output.Successful, types.SendMessageBatchResultEntry{
+			Id:        entry.Id,
+			MessageId: aws.String(fmt.Sprintf("test-message-id-%s", aws.ToString(entry.Id))),
+		})
+	}
+	return output, nil
+}
+
+// ListDeadLetterSourceQueues reports no source queues, or the error set for
+// "ListDeadLetterSourceQueues".
+func (m *MockSQSClient) ListDeadLetterSourceQueues(ctx context.Context, params *sqs.ListDeadLetterSourceQueuesInput, optFns ...func(*sqs.Options)) (*sqs.ListDeadLetterSourceQueuesOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err, exists := m.errors["ListDeadLetterSourceQueues"]; exists {
+		return nil, err
+	}
+	return &sqs.ListDeadLetterSourceQueuesOutput{QueueUrls: []string{}}, nil
+}
+
+// DeleteQueue removes a queue and its messages from the mock. Unknown queues
+// fail with QueueDoesNotExist.
+func (m *MockSQSClient) DeleteQueue(ctx context.Context, params *sqs.DeleteQueueInput, optFns ...func(*sqs.Options)) (*sqs.DeleteQueueOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err, exists := m.errors["DeleteQueue"]; exists {
+		return nil, err
+	}
+
+	queueURL := aws.ToString(params.QueueUrl)
+	for i, existing := range m.queues {
+		if existing == queueURL {
+			m.queues = append(m.queues[:i], m.queues[i+1:]...)
+			delete(m.messages, queueURL)
+			return &sqs.DeleteQueueOutput{}, nil
+		}
+	}
+	return nil, &types.QueueDoesNotExist{Message: aws.String("The specified queue does not exist.")}
+}
+
+// ChangeMessageVisibilityInputs returns a snapshot of the recorded
+// ChangeMessageVisibility calls, safe to call while pollers are still running.
+func (m *MockSQSClient) ChangeMessageVisibilityInputs() []ChangeMessageVisibilityCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]ChangeMessageVisibilityCall(nil), m.ChangeMessageVisibilityCalls...)
+}
+
+// ChangeMessageVisibility records the call; the mock does not model
+// visibility, so messages stay receivable either way.
+func (m *MockSQSClient) ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput, optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.ChangeMessageVisibilityCalls = append(m.ChangeMessageVisibilityCalls, ChangeMessageVisibilityCall{
+		QueueURL:          aws.ToString(params.QueueUrl),
+		ReceiptHandle:     aws.ToString(params.ReceiptHandle),
+		VisibilityTimeout: params.VisibilityTimeout,
+	})
+
+	if err, exists := m.errors["ChangeMessageVisibility"]; exists {
+		return nil, err
+	}
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
 // DeleteMessage removes a message from the mock queue using its receipt handle.
 func (m *MockSQSClient) DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	queueURL := aws.ToString(params.QueueUrl)
 	receiptHandle := aws.ToString(params.ReceiptHandle)
 
@@ -201,3 +359,44 @@ func (m *MockSQSClient) DeleteMessage(ctx context.Context, params *sqs.DeleteMes
 
 	return &sqs.DeleteMessageOutput{}, nil
 }
+
+// PutObjectCall records the arguments of a PutObject invocation for assertion.
+type PutObjectCall struct {
+	Bucket string
+	Key    string
+	Body   []byte
+}
+
+// MockS3Client implements the S3ClientInterface for testing, recording every
+// PutObject call and failing configured keys.
+type MockS3Client struct {
+	PutObjectCalls []PutObjectCall
+	keyErrors      map[string]error
+}
+
+// NewMockS3Client creates a new mock S3 client for testing.
+func NewMockS3Client() *MockS3Client {
+	return &MockS3Client{
+		PutObjectCalls: []PutObjectCall{},
+		keyErrors:      make(map[string]error),
+	}
+}
+
+// SetKeyError configures PutObject to fail for a specific object key.
+func (m *MockS3Client) SetKeyError(key string, err error) {
+	m.keyErrors[key] = err
+}
+
+// PutObject records the object and returns the configured error for its key, if any.
+func (m *MockS3Client) PutObject(ctx context.Context, bucket, key string, body []byte) error {
+	m.PutObjectCalls = append(m.PutObjectCalls, PutObjectCall{
+		Bucket: bucket,
+		Key:    key,
+		Body:   body,
+	})
+
+	if err, exists := m.keyErrors[key]; exists {
+		return err
+	}
+	return nil
+}
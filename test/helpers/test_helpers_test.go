@@ -0,0 +1,83 @@
+package helpers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+func TestMockSQSClient_ConcurrentReceiveAndDelete(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	client := NewMockSQSClient()
+	client.AddQueue(queueURL)
+	for i := 0; i < 200; i++ {
+		client.AddMessage(queueURL, fmt.Sprintf("msg-%d", i), "body")
+	}
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				output, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+					QueueUrl:            aws.String(queueURL),
+					MaxNumberOfMessages: 10,
+				})
+				if err != nil {
+					t.Errorf("ReceiveMessage failed: %v", err)
+					return
+				}
+				for _, msg := range output.Messages {
+					_ = aws.ToString(msg.Body)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				output, _ := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+					QueueUrl:            aws.String(queueURL),
+					MaxNumberOfMessages: 1,
+				})
+				for _, msg := range output.Messages {
+					client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+						QueueUrl:      aws.String(queueURL),
+						ReceiptHandle: msg.ReceiptHandle,
+					})
+				}
+				client.AddMessage(queueURL, fmt.Sprintf("late-%d", j), "body")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMockSQSClient_ReceiveMessageReturnsCopy(t *testing.T) {
+	const queueURL = "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue"
+
+	client := NewMockSQSClient()
+	client.AddQueue(queueURL)
+	client.AddMessage(queueURL, "msg-1", "a")
+	client.AddMessage(queueURL, "msg-2", "b")
+
+	ctx := context.Background()
+	output, _ := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(queueURL),
+		MaxNumberOfMessages: 10,
+	})
+	client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(queueURL),
+		ReceiptHandle: output.Messages[0].ReceiptHandle,
+	})
+
+	if got := aws.ToString(output.Messages[1].MessageId); got != "msg-2" {
+		t.Errorf("received slice was mutated by DeleteMessage: expected msg-2 at index 1, got %s", got)
+	}
+}